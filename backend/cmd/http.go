@@ -22,22 +22,105 @@ var httpCmd = &cobra.Command{
 
 		// --- Repository ---
 		userRepo := repository.NewUserRepository(db)
+		sessionRepo := repository.NewSessionRepository(db)
+		loginAttemptRepo := repository.NewLoginAttemptRepository(db)
+		mfaRepo := repository.NewMFARepository(db)
+		permissionRepo := repository.NewPermissionRepository(db)
 		managerRepo := repository.NewManagerRepository(db)
 		zookeeperRepo := repository.NewZookeeperRepository(db)
 		cageRepo := repository.NewCageRepository(db)
 		animalRepo := repository.NewAnimalRepository(db)
 		taskRepo := repository.NewTaskRepository(db)
+		templateRepo := repository.NewTaskTemplateRepository(db)
+		medicalRepo := repository.NewMedicalRepository(db)
+		feedingRepo := repository.NewFeedingRepository(db)
+		measurementRepo := repository.NewMeasurementRepository(db)
+		assignmentRepo := repository.NewCageAssignmentRepository(db)
+		speciesRepo := repository.NewSpeciesRepository(db)
+		compatibilityRepo := repository.NewCompatibilityRepository(db)
+		quarantineRepo := repository.NewQuarantineRepository(db)
+		breedingRepo := repository.NewBreedingRepository(db)
+		sensorRepo := repository.NewSensorRepository(db)
+		zoneRepo := repository.NewZoneRepository(db)
+		shiftRepo := repository.NewShiftRepository(db)
+		leaveRepo := repository.NewLeaveRepository(db)
+		skillRepo := repository.NewSkillRepository(db)
 
 		// --- Service ---
+		zookeeperService := application.NewZookeeperService(zookeeperRepo, idGen)
+		lockoutService := application.NewLockoutService(
+			userRepo,
+			loginAttemptRepo,
+			zookeeperService,
+			application.LockoutOptions{
+				MaxFailedAttempts:   cfg.LoginMaxFailedAttempts,
+				IPMaxFailedAttempts: cfg.LoginIPMaxFailedAttempts,
+				AttemptWindow:       cfg.LoginAttemptWindow,
+				LockoutBase:         cfg.LoginLockoutBase,
+				LockoutMax:          cfg.LoginLockoutMax,
+			},
+		)
+		mfaService := application.NewMFAService(mfaRepo, userRepo, cfg.MFAIssuer)
 		authService := application.NewAuthService(
 			userRepo,
-			cfg.JWTSecret,
+			sessionRepo,
+			lockoutService,
+			mfaService,
+			idGen,
+			application.AuthOptions{
+				JWTSecret:       cfg.JWTSecret,
+				AccessTokenTTL:  cfg.AccessTokenTTL,
+				RefreshTokenTTL: cfg.RefreshTokenTTL,
+				MFAPendingTTL:   cfg.MFAPendingTTL,
+			},
 		)
+		permissionService := application.NewPermissionService(permissionRepo)
 		managerService := application.NewManagerService(managerRepo, idGen)
-		zookeeperService := application.NewZookeeperService(zookeeperRepo, idGen)
-		cageService := application.NewCageService(cageRepo, idGen)
-		animalService := application.NewAnimalService(animalRepo, idGen)
-		taskService := application.NewTaskService(taskRepo, idGen)
+		zoneService := application.NewZoneService(zoneRepo, idGen, cfg.ZoneScopedAccess)
+		cageService := application.NewCageService(cageRepo, zoneService, idGen)
+		compatibilityService := application.NewCompatibilityService(compatibilityRepo, zoneService)
+		animalService := application.NewAnimalService(animalRepo, compatibilityService, zoneService, idGen)
+		shiftService := application.NewShiftService(shiftRepo, zookeeperService, idGen, cfg.ShiftMinRest)
+		templateService := application.NewTaskTemplateService(templateRepo, animalService, zoneService, idGen)
+		skillService := application.NewSkillService(skillRepo, templateService, idGen)
+		leaveService := application.NewLeaveService(leaveRepo, taskRepo, zookeeperService, shiftService, skillService, idGen)
+		assignmentService := application.NewAssignmentService(taskRepo, animalService, zookeeperService, shiftService, leaveService, skillService)
+		taskService := application.NewTaskService(taskRepo, animalService, zoneService, zookeeperService, shiftService, leaveService, skillService, assignmentService, idGen)
+		medicalService := application.NewMedicalService(medicalRepo, animalService, zoneService, idGen)
+		feedingService := application.NewFeedingService(feedingRepo, animalService, zoneService, idGen)
+		measurementService := application.NewMeasurementService(
+			measurementRepo,
+			animalService,
+			zoneService,
+			idGen,
+			application.MeasurementOptions{
+				MaxChangePercent: cfg.WeightAlertMaxChangePercent,
+				WindowDays:       cfg.WeightAlertWindowDays,
+			},
+		)
+		transferService := application.NewTransferService(assignmentRepo, animalService, zoneService, compatibilityService)
+		speciesService := application.NewSpeciesService(speciesRepo, idGen)
+		quarantineService := application.NewQuarantineService(
+			quarantineRepo,
+			animalService,
+			zoneService,
+			compatibilityService,
+			idGen,
+		)
+		breedingService := application.NewBreedingService(
+			breedingRepo,
+			animalService,
+			zoneService,
+			compatibilityService,
+			idGen,
+		)
+		sensorService := application.NewSensorService(sensorRepo, zoneService, idGen)
+		passwordService := application.NewPasswordService(
+			userRepo,
+			sessionRepo,
+			zookeeperService,
+			cfg.PasswordResetTTL,
+		)
 
 		// --- Handler ---
 		authHandler := handler.NewAuthHandler(log, authService)
@@ -46,17 +129,55 @@ var httpCmd = &cobra.Command{
 		cageHandler := handler.NewCageHandler(log, cageService)
 		animalHandler := handler.NewAnimalHandler(log, animalService)
 		taskHandler := handler.NewTaskHandler(log, taskService)
+		passwordHandler := handler.NewPasswordHandler(log, passwordService)
+		lockoutHandler := handler.NewLockoutHandler(log, lockoutService)
+		mfaHandler := handler.NewMFAHandler(log, mfaService)
+		permissionHandler := handler.NewPermissionHandler(log, permissionService)
+		templateHandler := handler.NewTaskTemplateHandler(log, templateService)
+		medicalHandler := handler.NewMedicalHandler(log, medicalService)
+		feedingHandler := handler.NewFeedingHandler(log, feedingService)
+		measurementHandler := handler.NewMeasurementHandler(log, measurementService)
+		transferHandler := handler.NewTransferHandler(log, transferService)
+		speciesHandler := handler.NewSpeciesHandler(log, speciesService)
+		compatibilityHandler := handler.NewCompatibilityHandler(log, compatibilityService)
+		quarantineHandler := handler.NewQuarantineHandler(log, quarantineService)
+		breedingHandler := handler.NewBreedingHandler(log, breedingService)
+		sensorHandler := handler.NewSensorHandler(log, sensorService)
+		zoneHandler := handler.NewZoneHandler(log, zoneService)
+		shiftHandler := handler.NewShiftHandler(log, shiftService)
+		leaveHandler := handler.NewLeaveHandler(log, leaveService)
+		skillHandler := handler.NewSkillHandler(log, skillService)
 
 		// --- Server ---
 		app := server.NewHTTPServer(
 			cfg,
 			log,
+			authService,
+			permissionService,
 			authHandler,
 			managerHandler,
 			zookeeperHandler,
 			cageHandler,
 			animalHandler,
 			taskHandler,
+			passwordHandler,
+			lockoutHandler,
+			mfaHandler,
+			permissionHandler,
+			templateHandler,
+			medicalHandler,
+			feedingHandler,
+			measurementHandler,
+			transferHandler,
+			speciesHandler,
+			compatibilityHandler,
+			quarantineHandler,
+			breedingHandler,
+			sensorHandler,
+			zoneHandler,
+			shiftHandler,
+			leaveHandler,
+			skillHandler,
 		)
 		app.Start()
 	},
@@ -0,0 +1,103 @@
+package cmd
+
+/*
+Copyright © 2026 NAME HERE aprianfirlanda@gmail.com
+*/
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	"wit-leisure-park/backend/internal/adapters/repository"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var schedulerOnce bool
+
+var schedulerCmd = &cobra.Command{
+	Use:   "scheduler",
+	Short: "Generate tasks from recurring task templates and open quarantines",
+	Run: func(cmd *cobra.Command, args []string) {
+		idGen := id.NewUUIDGenerator()
+		templateRepo := repository.NewTaskTemplateRepository(db)
+		zoneService := application.NewZoneService(repository.NewZoneRepository(db), idGen, cfg.ZoneScopedAccess)
+		animalService := application.NewAnimalService(
+			repository.NewAnimalRepository(db),
+			application.NewCompatibilityService(repository.NewCompatibilityRepository(db), zoneService),
+			zoneService,
+			idGen,
+		)
+		skillService := application.NewSkillService(
+			repository.NewSkillRepository(db),
+			application.NewTaskTemplateService(templateRepo, animalService, zoneService, idGen),
+			idGen,
+		)
+		taskRepo := repository.NewTaskRepository(db)
+		zookeeperService := application.NewZookeeperService(repository.NewZookeeperRepository(db), idGen)
+		leaveService := application.NewLeaveService(
+			repository.NewLeaveRepository(db),
+			taskRepo,
+			zookeeperService,
+			application.NewShiftService(repository.NewShiftRepository(db), zookeeperService, idGen, cfg.ShiftMinRest),
+			skillService,
+			idGen,
+		)
+
+		scheduler := application.NewTaskScheduler(
+			log,
+			templateRepo,
+			repository.NewQuarantineRepository(db),
+			taskRepo,
+			leaveService,
+			skillService,
+			idGen,
+		)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		runScheduler(ctx, scheduler)
+		if schedulerOnce {
+			return
+		}
+
+		log.Infof("scheduler running every %s", cfg.SchedulerInterval)
+
+		ticker := time.NewTicker(cfg.SchedulerInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info("scheduler stopped")
+				return
+			case <-ticker.C:
+				runScheduler(ctx, scheduler)
+			}
+		}
+	},
+}
+
+func runScheduler(ctx context.Context, scheduler *application.TaskScheduler) {
+	created, err := scheduler.Run(ctx, time.Now())
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"created": created,
+			"error":   err.Error(),
+		}).Error("scheduler run finished with errors")
+		return
+	}
+
+	log.WithField("created", created).Info("scheduler run finished")
+}
+
+func init() {
+	schedulerCmd.Flags().BoolVar(&schedulerOnce, "once", false, "run a single generation pass and exit")
+	rootCmd.AddCommand(schedulerCmd)
+}
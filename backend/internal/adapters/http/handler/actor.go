@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// actorFrom builds the service actor from the claims set by middleware.JWT.
+func actorFrom(c *fiber.Ctx) application.Actor {
+	userID, _ := c.Locals("user_id").(string)
+	role, _ := c.Locals("role").(string)
+
+	return application.Actor{
+		PublicID: userID,
+		Role:     domain.Role(role),
+	}
+}
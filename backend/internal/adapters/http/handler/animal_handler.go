@@ -1,7 +1,12 @@
 package handler
 
 import (
+	"errors"
+	"strings"
+	"time"
 	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/domain/animal"
+	"wit-leisure-park/backend/internal/ports"
 	"wit-leisure-park/backend/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -22,10 +27,11 @@ func NewAnimalHandler(
 
 func (h *AnimalHandler) Create(c *fiber.Ctx) error {
 	var req struct {
-		Name        string  `json:"name"`
-		Species     string  `json:"species"`
-		CageID      string  `json:"cage_public_id"`
-		DateOfBirth *string `json:"date_of_birth"`
+		Name           string  `json:"name"`
+		SpeciesID      string  `json:"species_public_id"`
+		CageID         string  `json:"cage_public_id"`
+		DateOfBirth    *string `json:"date_of_birth"`
+		OverrideReason *string `json:"override_reason"`
 	}
 
 	if err := c.BodyParser(&req); err != nil {
@@ -40,20 +46,22 @@ func (h *AnimalHandler) Create(c *fiber.Ctx) error {
 	}
 	result, err := h.service.Create(
 		c.Context(),
+		actorFrom(c),
 		req.Name,
-		req.Species,
+		req.SpeciesID,
 		req.CageID,
 		parsedDateOfBirth,
+		req.OverrideReason,
 	)
 	if err != nil {
 		h.log.WithFields(logrus.Fields{
 			"name":    req.Name,
-			"species": req.Species,
+			"species": req.SpeciesID,
 			"cage_id": req.CageID,
 			"error":   err.Error(),
 		}).Warn("failed to create animal")
 
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(errorStatus(err)).JSON(errorBody(err))
 	}
 
 	h.log.WithField("public_id", result.PublicID).
@@ -63,7 +71,28 @@ func (h *AnimalHandler) Create(c *fiber.Ctx) error {
 }
 
 func (h *AnimalHandler) List(c *fiber.Ctx) error {
-	result, err := h.service.List(c.Context())
+	params, err := parseListParams(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	statuses, err := parseAnimalStatuses(c.Query("status"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.List(c.Context(), actorFrom(c), ports.AnimalListQuery{
+		ListParams:      params,
+		Species:         queryString(c, "species"),
+		SpeciesPublicID: queryString(c, "species_public_id"),
+		CagePublicID:    queryString(c, "cage_public_id"),
+		ZonePublicID:    queryString(c, "zone_public_id"),
+		Search:          queryString(c, "q"),
+		Statuses:        statuses,
+	})
+	if errors.Is(err, ports.ErrInvalidSort) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
 	if err != nil {
 		h.log.Error("failed to list animals: ", err)
 		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
@@ -76,7 +105,7 @@ func (h *AnimalHandler) List(c *fiber.Ctx) error {
 func (h *AnimalHandler) FindByID(c *fiber.Ctx) error {
 	publicID := c.Params("public_id")
 
-	result, err := h.service.FindByID(c.Context(), publicID)
+	result, err := h.service.FindByID(c.Context(), actorFrom(c), publicID)
 	if err != nil {
 		h.log.WithField("public_id", publicID).
 			Warn("animal not found")
@@ -93,10 +122,11 @@ func (h *AnimalHandler) Update(c *fiber.Ctx) error {
 	publicID := c.Params("public_id")
 
 	var req struct {
-		Name        string  `json:"name"`
-		Species     string  `json:"species"`
-		CageID      string  `json:"cage_public_id"`
-		DateOfBirth *string `json:"date_of_birth"`
+		Name           string  `json:"name"`
+		SpeciesID      string  `json:"species_public_id"`
+		CageID         string  `json:"cage_public_id"`
+		DateOfBirth    *string `json:"date_of_birth"`
+		OverrideReason *string `json:"override_reason"`
 	}
 
 	if err := c.BodyParser(&req); err != nil {
@@ -111,11 +141,13 @@ func (h *AnimalHandler) Update(c *fiber.Ctx) error {
 	}
 	err = h.service.Update(
 		c.Context(),
+		actorFrom(c),
 		publicID,
 		req.Name,
-		req.Species,
+		req.SpeciesID,
 		req.CageID,
 		parsedDateOfBirth,
+		req.OverrideReason,
 	)
 	if err != nil {
 		h.log.WithFields(logrus.Fields{
@@ -123,7 +155,7 @@ func (h *AnimalHandler) Update(c *fiber.Ctx) error {
 			"error":     err.Error(),
 		}).Warn("failed to update animal")
 
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(errorStatus(err)).JSON(errorBody(err))
 	}
 
 	h.log.WithField("public_id", publicID).
@@ -137,12 +169,12 @@ func (h *AnimalHandler) Update(c *fiber.Ctx) error {
 func (h *AnimalHandler) Delete(c *fiber.Ctx) error {
 	publicID := c.Params("public_id")
 
-	err := h.service.Delete(c.Context(), publicID)
+	err := h.service.Delete(c.Context(), actorFrom(c), publicID)
 	if err != nil {
 		h.log.WithField("public_id", publicID).
 			Warn("failed to delete animal")
 
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	h.log.WithField("public_id", publicID).
@@ -150,3 +182,81 @@ func (h *AnimalHandler) Delete(c *fiber.Ctx) error {
 
 	return c.SendStatus(204)
 }
+
+// parseAnimalStatuses reads a comma separated status filter. "all" lists
+// every animal, and an empty value keeps the default of animals the park
+// still cares for.
+func parseAnimalStatuses(raw string) ([]ports.AnimalStatus, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	if strings.EqualFold(raw, "all") {
+		return animal.AllStatuses(), nil
+	}
+
+	var statuses []ports.AnimalStatus
+	for _, part := range strings.Split(raw, ",") {
+		status := ports.AnimalStatus(strings.ToUpper(strings.TrimSpace(part)))
+		if !status.Valid() {
+			return nil, animal.ErrInvalidStatus
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+type changeAnimalStatusRequest struct {
+	Status         ports.AnimalStatus `json:"status"`
+	Reason         string             `json:"reason"`
+	EffectiveAt    *time.Time         `json:"effective_at"`
+	OverrideReason *string            `json:"override_reason"`
+}
+
+func (h *AnimalHandler) ChangeStatus(c *fiber.Ctx) error {
+	publicID := c.Params("public_id")
+
+	var req changeAnimalStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("public_id", publicID).Warn("invalid animal status request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.ChangeStatus(
+		c.Context(),
+		actorFrom(c),
+		publicID,
+		ports.AnimalStatus(strings.ToUpper(string(req.Status))),
+		req.Reason,
+		req.EffectiveAt,
+		req.OverrideReason,
+	)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"public_id": publicID,
+			"status":    req.Status,
+			"error":     err.Error(),
+		}).Warn("failed to change animal status")
+
+		return c.Status(errorStatus(err)).JSON(errorBody(err))
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"public_id": publicID,
+		"status":    result.Status,
+	}).Info("animal status changed successfully")
+
+	return c.JSON(result)
+}
+
+func (h *AnimalHandler) StatusHistory(c *fiber.Ctx) error {
+	publicID := c.Params("public_id")
+
+	result, err := h.service.StatusHistory(c.Context(), actorFrom(c), publicID)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
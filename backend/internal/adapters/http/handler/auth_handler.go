@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+	"strconv"
 	"wit-leisure-park/backend/internal/application"
 
 	"github.com/gofiber/fiber/v2"
@@ -28,7 +30,25 @@ type loginRequest struct {
 }
 
 type loginResponse struct {
-	AccessToken string `json:"access_token"`
+	AccessToken        string `json:"access_token"`
+	RefreshToken       string `json:"refresh_token"`
+	ExpiresIn          int64  `json:"expires_in"`
+	MustChangePassword bool   `json:"must_change_password"`
+}
+
+type mfaPendingResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+type verifyMFARequest struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
@@ -42,20 +62,145 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
-	token, err := h.authService.Login(
+	result, err := h.authService.Login(
 		c.Context(),
 		req.Username,
 		req.Password,
+		c.IP(),
 	)
-	if err != nil {
+
+	var lockedErr *application.LockedError
+	if errors.As(err, &lockedErr) {
+		h.log.WithFields(logrus.Fields{
+			"username": req.Username,
+			"ip":       c.IP(),
+		}).Warn("login refused, too many failed attempts")
+
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(lockedErr.RetryAfter.Seconds())+1))
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": lockedErr.Error(),
+		})
+	}
+	if errors.Is(err, application.ErrInvalidCredentials) {
 		h.log.Warn("failed login attempt for user: ", req.Username)
 
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"error": "invalid credentials",
 		})
 	}
+	if err != nil {
+		h.log.Error("failed to login: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	if result.Tokens == nil {
+		return c.JSON(mfaPendingResponse{
+			MFARequired: true,
+			MFAToken:    result.MFAToken,
+			ExpiresIn:   result.MFAExpiresIn,
+		})
+	}
+
+	return c.JSON(newLoginResponse(*result.Tokens))
+}
+
+func (h *AuthHandler) VerifyMFA(c *fiber.Ctx) error {
+	var req verifyMFARequest
+
+	if err := c.BodyParser(&req); err != nil || req.MFAToken == "" {
+		h.log.Warn("invalid mfa verify request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	tokens, err := h.authService.VerifyMFA(
+		c.Context(),
+		req.MFAToken,
+		req.Code,
+		c.IP(),
+	)
+
+	var lockedErr *application.LockedError
+	if errors.As(err, &lockedErr) {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(lockedErr.RetryAfter.Seconds())+1))
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": lockedErr.Error(),
+		})
+	}
+	if errors.Is(err, application.ErrInvalidMFAToken) ||
+		errors.Is(err, application.ErrInvalidMFACode) {
+		h.log.WithField("ip", c.IP()).Warn("failed mfa verification")
+
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err != nil {
+		h.log.Error("failed to verify mfa: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(newLoginResponse(tokens))
+}
+
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	var req refreshRequest
+
+	if err := c.BodyParser(&req); err != nil || req.RefreshToken == "" {
+		h.log.Warn("invalid refresh request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	tokens, err := h.authService.Refresh(c.Context(), req.RefreshToken)
+	if errors.Is(err, application.ErrRefreshTokenReused) {
+		h.log.Warn("refresh token reuse detected, session revoked")
 
-	return c.JSON(loginResponse{
-		AccessToken: token,
-	})
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if errors.Is(err, application.ErrInvalidRefreshToken) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err != nil {
+		h.log.Error("failed to refresh token: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(newLoginResponse(tokens))
+}
+
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	var req refreshRequest
+
+	if err := c.BodyParser(&req); err != nil || req.RefreshToken == "" {
+		h.log.Warn("invalid logout request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	if err := h.authService.Logout(c.Context(), req.RefreshToken); err != nil {
+		h.log.Error("failed to logout: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.SendStatus(204)
+}
+
+func newLoginResponse(tokens application.AuthTokens) loginResponse {
+	return loginResponse{
+		AccessToken:        tokens.AccessToken,
+		RefreshToken:       tokens.RefreshToken,
+		ExpiresIn:          tokens.ExpiresIn,
+		MustChangePassword: tokens.MustChangePassword,
+	}
 }
@@ -0,0 +1,375 @@
+package handler
+
+import (
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type BreedingHandler struct {
+	log     *logrus.Logger
+	service *application.BreedingService
+}
+
+func NewBreedingHandler(
+	log *logrus.Logger,
+	s *application.BreedingService,
+) *BreedingHandler {
+	return &BreedingHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type studbookRequest struct {
+	Sex          string  `json:"sex"`
+	DamPublicID  *string `json:"dam_public_id"`
+	SirePublicID *string `json:"sire_public_id"`
+	MicrochipID  *string `json:"microchip_id"`
+	StudbookID   *string `json:"studbook_id"`
+}
+
+func (h *BreedingHandler) SetStudbook(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	var req studbookRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("animal_id", animalID).Warn("invalid studbook request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.SetStudbook(c.Context(), actorFrom(c), ports.StudbookInput{
+		AnimalPublicID: animalID,
+		DamPublicID:    req.DamPublicID,
+		SirePublicID:   req.SirePublicID,
+		MicrochipID:    req.MicrochipID,
+		StudbookID:     req.StudbookID,
+	}, req.Sex)
+	if err != nil {
+		return h.fail(c, animalID, "update studbook record", err)
+	}
+
+	h.log.WithField("animal_id", animalID).Info("studbook record updated")
+
+	return c.JSON(result)
+}
+
+func (h *BreedingHandler) Pedigree(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	result, err := h.service.Pedigree(c.Context(), actorFrom(c), animalID, c.QueryInt("generations"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *BreedingHandler) AssessPairing(c *fiber.Ctx) error {
+
+	result, err := h.service.AssessPairing(
+		c.Context(),
+		actorFrom(c),
+		c.Query("dam_public_id"),
+		c.Query("sire_public_id"),
+		c.QueryInt("generations"),
+	)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+type pairingRequest struct {
+	DamPublicID  string  `json:"dam_public_id"`
+	SirePublicID string  `json:"sire_public_id"`
+	PairedOn     *string `json:"paired_on"`
+	Notes        *string `json:"notes"`
+}
+
+func (h *BreedingHandler) CreatePairing(c *fiber.Ctx) error {
+
+	var req pairingRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid pairing request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	pairedOn, err := utils.ParseDate(req.PairedOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "paired_on: " + err.Error()})
+	}
+
+	input := ports.PairingInput{
+		DamPublicID:  req.DamPublicID,
+		SirePublicID: req.SirePublicID,
+		Notes:        req.Notes,
+	}
+	if pairedOn != nil {
+		input.PairedOn = *pairedOn
+	}
+
+	result, err := h.service.CreatePairing(c.Context(), actorFrom(c), input)
+	if err != nil {
+		return h.fail(c, req.DamPublicID, "create pairing", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"pairing_id": result.PublicID,
+		"dam_id":     result.DamPublicID,
+		"sire_id":    result.SirePublicID,
+	}).Info("pairing created")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *BreedingHandler) ListPairings(c *fiber.Ctx) error {
+
+	result, err := h.service.ListPairings(c.Context(), actorFrom(c), queryString(c, "animal_public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *BreedingHandler) FindPairing(c *fiber.Ctx) error {
+
+	result, err := h.service.FindPairing(c.Context(), actorFrom(c), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *BreedingHandler) EndPairing(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req struct {
+		SeparatedOn *string `json:"separated_on"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+		}
+	}
+
+	separatedOn, err := utils.ParseDate(req.SeparatedOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "separated_on: " + err.Error()})
+	}
+
+	result, err := h.service.EndPairing(c.Context(), actorFrom(c), publicID, separatedOn)
+	if err != nil {
+		return h.fail(c, publicID, "end pairing", err)
+	}
+
+	return c.JSON(result)
+}
+
+type pregnancyRequest struct {
+	DamPublicID     string  `json:"dam_public_id"`
+	SirePublicID    *string `json:"sire_public_id"`
+	PairingPublicID *string `json:"pairing_public_id"`
+	DetectedOn      *string `json:"detected_on"`
+	ExpectedDueOn   *string `json:"expected_due_on"`
+	Notes           *string `json:"notes"`
+}
+
+func (h *BreedingHandler) CreatePregnancy(c *fiber.Ctx) error {
+
+	var req pregnancyRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid pregnancy request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	detectedOn, err := utils.ParseDate(req.DetectedOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "detected_on: " + err.Error()})
+	}
+	expectedDueOn, err := utils.ParseDate(req.ExpectedDueOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "expected_due_on: " + err.Error()})
+	}
+
+	input := ports.PregnancyInput{
+		DamPublicID:     req.DamPublicID,
+		SirePublicID:    req.SirePublicID,
+		PairingPublicID: req.PairingPublicID,
+		ExpectedDueOn:   expectedDueOn,
+		Notes:           req.Notes,
+	}
+	if detectedOn != nil {
+		input.DetectedOn = *detectedOn
+	}
+
+	result, err := h.service.CreatePregnancy(c.Context(), actorFrom(c), input)
+	if err != nil {
+		return h.fail(c, req.DamPublicID, "record pregnancy", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"pregnancy_id": result.PublicID,
+		"dam_id":       result.DamPublicID,
+	}).Info("pregnancy recorded")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *BreedingHandler) ListPregnancies(c *fiber.Ctx) error {
+
+	result, err := h.service.ListPregnancies(c.Context(), actorFrom(c), queryString(c, "status"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *BreedingHandler) FindPregnancy(c *fiber.Ctx) error {
+
+	result, err := h.service.FindPregnancy(c.Context(), actorFrom(c), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *BreedingHandler) MarkPregnancyLost(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req struct {
+		EndedOn *string `json:"ended_on"`
+		Notes   *string `json:"notes"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+		}
+	}
+
+	endedOn, err := utils.ParseDate(req.EndedOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "ended_on: " + err.Error()})
+	}
+
+	result, err := h.service.MarkPregnancyLost(c.Context(), actorFrom(c), publicID, endedOn, req.Notes)
+	if err != nil {
+		return h.fail(c, publicID, "end pregnancy", err)
+	}
+
+	return c.JSON(result)
+}
+
+type birthRequest struct {
+	DamPublicID       string  `json:"dam_public_id"`
+	SirePublicID      *string `json:"sire_public_id"`
+	PregnancyPublicID *string `json:"pregnancy_public_id"`
+	CagePublicID      *string `json:"cage_public_id"`
+	BornOn            *string `json:"born_on"`
+	StillbornCount    int     `json:"stillborn_count"`
+	Notes             *string `json:"notes"`
+	Offspring         []struct {
+		Name        string  `json:"name"`
+		Sex         string  `json:"sex"`
+		MicrochipID *string `json:"microchip_id"`
+		StudbookID  *string `json:"studbook_id"`
+	} `json:"offspring"`
+	OverrideReason *string `json:"override_reason"`
+}
+
+func (h *BreedingHandler) RecordBirth(c *fiber.Ctx) error {
+
+	var req birthRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid birth request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	bornOn, err := utils.ParseDate(req.BornOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "born_on: " + err.Error()})
+	}
+
+	input := ports.BirthInput{
+		DamPublicID:       req.DamPublicID,
+		SirePublicID:      req.SirePublicID,
+		PregnancyPublicID: req.PregnancyPublicID,
+		CagePublicID:      req.CagePublicID,
+		StillbornCount:    req.StillbornCount,
+		Notes:             req.Notes,
+	}
+	if bornOn != nil {
+		input.BornOn = *bornOn
+	}
+
+	offspring := make([]application.OffspringRequest, 0, len(req.Offspring))
+	for _, o := range req.Offspring {
+		offspring = append(offspring, application.OffspringRequest{
+			Name:        o.Name,
+			Sex:         o.Sex,
+			MicrochipID: o.MicrochipID,
+			StudbookID:  o.StudbookID,
+		})
+	}
+
+	result, err := h.service.RecordBirth(c.Context(), actorFrom(c), input, offspring, req.OverrideReason)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"dam_id": req.DamPublicID,
+			"error":  err.Error(),
+		}).Warn("failed to record birth")
+
+		return c.Status(errorStatus(err)).JSON(errorBody(err))
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"birth_id":  result.PublicID,
+		"dam_id":    result.DamPublicID,
+		"offspring": len(result.Offspring),
+	}).Info("birth recorded")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *BreedingHandler) ListBirths(c *fiber.Ctx) error {
+
+	result, err := h.service.ListBirths(c.Context(), actorFrom(c), queryString(c, "dam_public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *BreedingHandler) FindBirth(c *fiber.Ctx) error {
+
+	result, err := h.service.FindBirth(c.Context(), actorFrom(c), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *BreedingHandler) fail(c *fiber.Ctx, publicID, action string, err error) error {
+	h.log.WithFields(logrus.Fields{
+		"public_id": publicID,
+		"error":     err.Error(),
+	}).Warn("failed to " + action)
+
+	return c.Status(errorStatus(err)).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
@@ -19,22 +21,29 @@ func NewCageHandler(
 	return &CageHandler{log: log, service: s}
 }
 
+type cageRequest struct {
+	Code          string                   `json:"code"`
+	Location      string                   `json:"location"`
+	Capacity      *int                     `json:"capacity"`
+	AreaPublicID  *string                  `json:"area_public_id"`
+	SpeciesLimits []ports.CageSpeciesLimit `json:"species_limits"`
+}
+
 func (h *CageHandler) Create(c *fiber.Ctx) error {
-	var req struct {
-		Code     string `json:"code"`
-		Location string `json:"location"`
-	}
+	var req cageRequest
 
 	if err := c.BodyParser(&req); err != nil {
 		h.log.Warn("invalid create cage request body")
 		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
 	}
 
-	result, err := h.service.Create(
-		c.Context(),
-		req.Code,
-		req.Location,
-	)
+	result, err := h.service.Create(c.Context(), actorFrom(c), ports.CageInput{
+		Code:          req.Code,
+		Location:      req.Location,
+		Capacity:      req.Capacity,
+		AreaPublicID:  req.AreaPublicID,
+		SpeciesLimits: req.SpeciesLimits,
+	})
 	if err != nil {
 		h.log.WithFields(logrus.Fields{
 			"code":     req.Code,
@@ -42,7 +51,7 @@ func (h *CageHandler) Create(c *fiber.Ctx) error {
 			"error":    err.Error(),
 		}).Warn("failed to create cage")
 
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	h.log.WithField("public_id", result.PublicID).
@@ -52,7 +61,21 @@ func (h *CageHandler) Create(c *fiber.Ctx) error {
 }
 
 func (h *CageHandler) List(c *fiber.Ctx) error {
-	result, err := h.service.List(c.Context())
+	params, err := parseListParams(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.List(c.Context(), actorFrom(c), ports.CageListQuery{
+		ListParams:   params,
+		Location:     queryString(c, "location"),
+		ZonePublicID: queryString(c, "zone_public_id"),
+		AreaPublicID: queryString(c, "area_public_id"),
+		Search:       queryString(c, "q"),
+	})
+	if errors.Is(err, ports.ErrInvalidSort) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
 	if err != nil {
 		h.log.Error("failed to list cages: ", err)
 		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
@@ -65,7 +88,7 @@ func (h *CageHandler) List(c *fiber.Ctx) error {
 func (h *CageHandler) FindByID(c *fiber.Ctx) error {
 	publicID := c.Params("public_id")
 
-	result, err := h.service.FindByID(c.Context(), publicID)
+	result, err := h.service.FindByID(c.Context(), actorFrom(c), publicID)
 	if err != nil {
 		h.log.WithField("public_id", publicID).
 			Warn("cage not found")
@@ -81,29 +104,28 @@ func (h *CageHandler) FindByID(c *fiber.Ctx) error {
 func (h *CageHandler) Update(c *fiber.Ctx) error {
 	publicID := c.Params("public_id")
 
-	var req struct {
-		Code     string `json:"code"`
-		Location string `json:"location"`
-	}
+	var req cageRequest
 
 	if err := c.BodyParser(&req); err != nil {
 		h.log.Warn("invalid update cage request body")
 		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
 	}
 
-	err := h.service.Update(
-		c.Context(),
-		publicID,
-		req.Code,
-		req.Location,
-	)
+	err := h.service.Update(c.Context(), actorFrom(c), ports.CageInput{
+		PublicID:      publicID,
+		Code:          req.Code,
+		Location:      req.Location,
+		Capacity:      req.Capacity,
+		AreaPublicID:  req.AreaPublicID,
+		SpeciesLimits: req.SpeciesLimits,
+	})
 	if err != nil {
 		h.log.WithFields(logrus.Fields{
 			"public_id": publicID,
 			"error":     err.Error(),
 		}).Warn("failed to update cage")
 
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	h.log.WithField("public_id", publicID).
@@ -117,12 +139,12 @@ func (h *CageHandler) Update(c *fiber.Ctx) error {
 func (h *CageHandler) Delete(c *fiber.Ctx) error {
 	publicID := c.Params("public_id")
 
-	err := h.service.Delete(c.Context(), publicID)
+	err := h.service.Delete(c.Context(), actorFrom(c), publicID)
 	if err != nil {
 		h.log.WithField("public_id", publicID).
 			Warn("failed to delete cage")
 
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	h.log.WithField("public_id", publicID).
@@ -130,3 +152,19 @@ func (h *CageHandler) Delete(c *fiber.Ctx) error {
 
 	return c.SendStatus(204)
 }
+
+func (h *CageHandler) Occupancy(c *fiber.Ctx) error {
+	publicID := c.Params("public_id")
+
+	result, err := h.service.Occupancy(c.Context(), actorFrom(c), publicID)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"public_id": publicID,
+			"error":     err.Error(),
+		}).Warn("failed to get cage occupancy")
+
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
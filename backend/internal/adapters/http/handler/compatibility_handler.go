@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CompatibilityHandler struct {
+	log     *logrus.Logger
+	service *application.CompatibilityService
+}
+
+func NewCompatibilityHandler(
+	log *logrus.Logger,
+	s *application.CompatibilityService,
+) *CompatibilityHandler {
+	return &CompatibilityHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+func (h *CompatibilityHandler) ListRules(c *fiber.Ctx) error {
+
+	result, err := h.service.ListRules(c.Context(), queryString(c, "species_public_id"))
+	if err != nil {
+		h.log.Error("failed to list compatibility rules: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *CompatibilityHandler) SetRule(c *fiber.Ctx) error {
+
+	var req struct {
+		Rule  ports.CompatibilityRule `json:"rule"`
+		Notes *string                 `json:"notes"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid compatibility rule request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.SetRule(c.Context(), ports.CompatibilityRuleDTO{
+		SpeciesAPublicID: c.Params("public_id"),
+		SpeciesBPublicID: c.Params("other_public_id"),
+		Rule:             req.Rule,
+		Notes:            req.Notes,
+	})
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"user_id":   c.Locals("user_id"),
+		"species_a": result.SpeciesAPublicID,
+		"species_b": result.SpeciesBPublicID,
+		"rule":      result.Rule,
+	}).Info("compatibility rule updated")
+
+	return c.JSON(result)
+}
+
+func (h *CompatibilityHandler) DeleteRule(c *fiber.Ctx) error {
+
+	err := h.service.DeleteRule(c.Context(), c.Params("public_id"), c.Params("other_public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.SendStatus(204)
+}
+
+func (h *CompatibilityHandler) ListOverrides(c *fiber.Ctx) error {
+
+	result, err := h.service.ListOverrides(c.Context(), actorFrom(c), queryString(c, "cage_public_id"))
+	if err != nil {
+		h.log.Error("failed to list compatibility overrides: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
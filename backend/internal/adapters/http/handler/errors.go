@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/domain/animal"
+	"wit-leisure-park/backend/internal/domain/leave"
+	"wit-leisure-park/backend/internal/domain/task"
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// errorStatus maps service errors to HTTP status codes. Anything not
+// recognised is treated as a client error, like the handlers always did.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, application.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrNotFound),
+		errors.Is(err, application.ErrTaskNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ports.ErrConflict),
+		errors.Is(err, ports.ErrDuplicate),
+		errors.Is(err, ports.ErrCapacityExceeded),
+		errors.Is(err, ports.ErrInUse),
+		errors.Is(err, ports.ErrQuarantined),
+		errors.Is(err, application.ErrIncompatibleSpecies),
+		errors.Is(err, application.ErrShiftConflict),
+		errors.Is(err, application.ErrZookeeperUnavailable),
+		errors.Is(err, application.ErrNotCertified),
+		errors.Is(err, application.ErrNoAssignee),
+		errors.Is(err, task.ErrInvalidTransition),
+		errors.Is(err, animal.ErrInvalidTransition),
+		errors.Is(err, leave.ErrInvalidTransition):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusBadRequest
+	}
+}
+
+// errorBody is the JSON error response. Compatibility failures also list
+// the conflicting animals so the client can show them or ask for approval,
+// shift clashes list the shifts in the way, missing certifications list the
+// skills and a failed automatic assignment lists the candidates.
+func errorBody(err error) fiber.Map {
+	body := fiber.Map{"error": err.Error()}
+
+	var incompatible *application.IncompatibleError
+	if errors.As(err, &incompatible) {
+		body["conflicts"] = incompatible.Conflicts
+		body["approvable"] = incompatible.Approvable
+	}
+
+	var shiftConflict *application.ShiftConflictError
+	if errors.As(err, &shiftConflict) {
+		body["conflicts"] = shiftConflict.Conflicts
+	}
+
+	var notCertified *application.CertificationError
+	if errors.As(err, &notCertified) {
+		body["missing_skills"] = notCertified.Missing
+	}
+
+	var noAssignee *application.NoAssigneeError
+	if errors.As(err, &noAssignee) {
+		body["candidates"] = noAssignee.Candidates
+	}
+
+	return body
+}
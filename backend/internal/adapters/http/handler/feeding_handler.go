@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"time"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type FeedingHandler struct {
+	log     *logrus.Logger
+	service *application.FeedingService
+}
+
+func NewFeedingHandler(
+	log *logrus.Logger,
+	s *application.FeedingService,
+) *FeedingHandler {
+	return &FeedingHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type dietPlanRequest struct {
+	FoodItem    string  `json:"food_item"`
+	Quantity    float64 `json:"quantity"`
+	Unit        string  `json:"unit"`
+	TimesPerDay int     `json:"times_per_day"`
+	Notes       *string `json:"notes"`
+	StartsOn    *string `json:"starts_on"`
+	EndsOn      *string `json:"ends_on"`
+}
+
+func (r dietPlanRequest) toInput(animalID string) (ports.DietPlanInput, error) {
+	input := ports.DietPlanInput{
+		AnimalPublicID: animalID,
+		FoodItem:       r.FoodItem,
+		Quantity:       r.Quantity,
+		Unit:           r.Unit,
+		TimesPerDay:    r.TimesPerDay,
+		Notes:          r.Notes,
+	}
+
+	startsOn, err := utils.ParseDate(r.StartsOn)
+	if err != nil {
+		return input, err
+	}
+	if startsOn != nil {
+		input.StartsOn = *startsOn
+	}
+
+	input.EndsOn, err = utils.ParseDate(r.EndsOn)
+	return input, err
+}
+
+func (h *FeedingHandler) CreateDietPlan(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	var req dietPlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("animal_id", animalID).Warn("invalid diet plan request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	input, err := req.toInput(animalID)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.CreateDietPlan(c.Context(), actorFrom(c), input)
+	if err != nil {
+		return h.fail(c, animalID, "failed to create diet plan", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"animal_id": animalID,
+		"plan_id":   result.PublicID,
+	}).Info("diet plan created successfully")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *FeedingHandler) ListDietPlans(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	result, err := h.service.ListDietPlans(c.Context(), actorFrom(c), animalID)
+	if err != nil {
+		return h.fail(c, animalID, "failed to list diet plans", err)
+	}
+
+	return c.JSON(result)
+}
+
+func (h *FeedingHandler) UpdateDietPlan(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	var req dietPlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("animal_id", animalID).Warn("invalid diet plan request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	input, err := req.toInput(animalID)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	input.PublicID = c.Params("plan_id")
+
+	result, err := h.service.UpdateDietPlan(c.Context(), actorFrom(c), input)
+	if err != nil {
+		return h.fail(c, animalID, "failed to update diet plan", err)
+	}
+
+	return c.JSON(result)
+}
+
+func (h *FeedingHandler) DeleteDietPlan(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	err := h.service.DeleteDietPlan(c.Context(), actorFrom(c), animalID, c.Params("plan_id"))
+	if err != nil {
+		return h.fail(c, animalID, "failed to delete diet plan", err)
+	}
+
+	return c.SendStatus(204)
+}
+
+type feedingLogRequest struct {
+	DietPlanPublicID *string    `json:"diet_plan_public_id"`
+	FedAt            *time.Time `json:"fed_at"`
+	FoodItem         string     `json:"food_item"`
+	QuantityGiven    float64    `json:"quantity_given"`
+	QuantityRefused  float64    `json:"quantity_refused"`
+	Unit             string     `json:"unit"`
+	Notes            *string    `json:"notes"`
+}
+
+func (h *FeedingHandler) LogFeeding(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	var req feedingLogRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("animal_id", animalID).Warn("invalid feeding log request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	input := ports.FeedingLogInput{
+		AnimalPublicID:   animalID,
+		DietPlanPublicID: req.DietPlanPublicID,
+		FoodItem:         req.FoodItem,
+		QuantityGiven:    req.QuantityGiven,
+		QuantityRefused:  req.QuantityRefused,
+		Unit:             req.Unit,
+		Notes:            req.Notes,
+	}
+	if req.FedAt != nil {
+		input.FedAt = *req.FedAt
+	}
+
+	result, err := h.service.LogFeeding(c.Context(), actorFrom(c), input)
+	if err != nil {
+		return h.fail(c, animalID, "failed to log feeding", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"animal_id": animalID,
+		"user_id":   c.Locals("user_id"),
+	}).Info("feeding logged successfully")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *FeedingHandler) ListFeedingLogs(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	from, to, err := dateRange(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.ListFeedingLogs(c.Context(), actorFrom(c), animalID, from, to)
+	if err != nil {
+		return h.fail(c, animalID, "failed to list feeding logs", err)
+	}
+
+	return c.JSON(result)
+}
+
+func (h *FeedingHandler) Missed(c *fiber.Ctx) error {
+
+	day, err := queryDate(c, "date")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.MissedFeedings(c.Context(), actorFrom(c), day)
+	if err != nil {
+		h.log.Error("failed to list missed feedings: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *FeedingHandler) fail(c *fiber.Ctx, animalID, message string, err error) error {
+	h.log.WithFields(logrus.Fields{
+		"animal_id": animalID,
+		"error":     err.Error(),
+	}).Warn(message)
+
+	return c.Status(errorStatus(err)).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
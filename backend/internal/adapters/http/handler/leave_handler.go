@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"strings"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type LeaveHandler struct {
+	log     *logrus.Logger
+	service *application.LeaveService
+}
+
+func NewLeaveHandler(
+	log *logrus.Logger,
+	s *application.LeaveService,
+) *LeaveHandler {
+	return &LeaveHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type leaveRequest struct {
+	Type     string  `json:"type"`
+	StartsOn *string `json:"starts_on"`
+	EndsOn   *string `json:"ends_on"`
+	Reason   *string `json:"reason"`
+}
+
+type leaveDecisionRequest struct {
+	Note *string `json:"note"`
+}
+
+type leaveReassignRequest struct {
+	ZookeeperPublicID string `json:"zookeeper_public_id"`
+}
+
+func (h *LeaveHandler) Submit(c *fiber.Ctx) error {
+
+	var req leaveRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid leave request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	startsOn, err := utils.ParseDate(req.StartsOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid starts_on"})
+	}
+	endsOn, err := utils.ParseDate(req.EndsOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid ends_on"})
+	}
+
+	actor := actorFrom(c)
+	result, err := h.service.Submit(c.Context(), actor, application.LeaveRequest{
+		Type:     req.Type,
+		StartsOn: startsOn,
+		EndsOn:   endsOn,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		return h.fail(c, actor.PublicID, "request leave", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"leave_id":     result.PublicID,
+		"zookeeper_id": actor.PublicID,
+	}).Info("leave requested")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *LeaveHandler) List(c *fiber.Ctx) error {
+
+	from, to, err := dateRange(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	query := ports.LeaveQuery{
+		ZookeeperPublicID: queryString(c, "zookeeper_public_id"),
+		ManagerPublicID:   queryString(c, "manager_public_id"),
+	}
+	if from != nil {
+		query.From = *from
+	}
+	if to != nil {
+		query.To = *to
+	}
+	if v := queryString(c, "status"); v != nil {
+		status := ports.LeaveStatus(strings.ToUpper(*v))
+		if !status.Valid() {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid status"})
+		}
+		query.Statuses = []ports.LeaveStatus{status}
+	}
+
+	result, err := h.service.List(c.Context(), actorFrom(c), query)
+	if err != nil {
+		h.log.Error("failed to list leave requests: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *LeaveHandler) FindByID(c *fiber.Ctx) error {
+
+	result, err := h.service.FindByID(c.Context(), actorFrom(c), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+// Approve also returns the zookeeper's open tasks due during the leave,
+// which can then be handed over with Reassign.
+func (h *LeaveHandler) Approve(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req leaveDecisionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+		}
+	}
+
+	result, err := h.service.Approve(c.Context(), actorFrom(c), publicID, req.Note)
+	if err != nil {
+		return h.fail(c, publicID, "approve leave", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"leave_id":   publicID,
+		"open_tasks": len(result.OpenTasks),
+	}).Info("leave approved")
+
+	return c.JSON(result)
+}
+
+func (h *LeaveHandler) Reject(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req leaveDecisionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+		}
+	}
+
+	result, err := h.service.Reject(c.Context(), actorFrom(c), publicID, req.Note)
+	if err != nil {
+		return h.fail(c, publicID, "reject leave", err)
+	}
+
+	h.log.WithField("leave_id", publicID).Info("leave rejected")
+
+	return c.JSON(result)
+}
+
+func (h *LeaveHandler) Cancel(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req leaveDecisionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+		}
+	}
+
+	result, err := h.service.Cancel(c.Context(), actorFrom(c), publicID, req.Note)
+	if err != nil {
+		return h.fail(c, publicID, "cancel leave", err)
+	}
+
+	h.log.WithField("leave_id", publicID).Info("leave cancelled")
+
+	return c.JSON(result)
+}
+
+func (h *LeaveHandler) Reassign(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req leaveReassignRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("leave_id", publicID).Warn("invalid reassign request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.ReassignTasks(c.Context(), actorFrom(c), publicID, req.ZookeeperPublicID)
+	if err != nil {
+		return h.fail(c, publicID, "reassign tasks", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"leave_id":     publicID,
+		"zookeeper_id": result.ZookeeperPublicID,
+		"reassigned":   result.Reassigned,
+	}).Info("tasks reassigned")
+
+	return c.JSON(result)
+}
+
+// Availability lists who can take work on ?date=, today by default.
+func (h *LeaveHandler) Availability(c *fiber.Ctx) error {
+
+	day, err := queryDate(c, "date")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.Availability(c.Context(), day, queryString(c, "manager_public_id"))
+	if err != nil {
+		h.log.Error("failed to list availability: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *LeaveHandler) fail(c *fiber.Ctx, publicID, action string, err error) error {
+	h.log.WithFields(logrus.Fields{
+		"public_id": publicID,
+		"error":     err.Error(),
+	}).Warn("failed to " + action)
+
+	return c.Status(errorStatus(err)).JSON(errorBody(err))
+}
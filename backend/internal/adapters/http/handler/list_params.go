@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var errInvalidLimit = errors.New("limit must be a positive number")
+var errInvalidOffset = errors.New("offset must not be negative")
+
+// parseListParams reads ?limit=, ?offset= or ?cursor= and ?sort= shared by
+// all list endpoints. A cursor takes precedence over offset.
+func parseListParams(c *fiber.Ctx) (ports.ListParams, error) {
+	p := ports.ListParams{
+		Limit: ports.DefaultListLimit,
+		Sort:  strings.TrimSpace(c.Query("sort")),
+	}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return p, errInvalidLimit
+		}
+		p.Limit = min(limit, ports.MaxListLimit)
+	}
+
+	if v := c.Query("cursor"); v != "" {
+		offset, err := ports.DecodeCursor(v)
+		if err != nil {
+			return p, err
+		}
+		p.Offset = offset
+		return p, nil
+	}
+
+	if v := c.Query("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return p, errInvalidOffset
+		}
+		p.Offset = offset
+	}
+
+	return p, nil
+}
+
+// queryString returns nil for absent or blank query parameters so they can
+// be used directly as optional filters.
+func queryString(c *fiber.Ctx, key string) *string {
+	v := strings.TrimSpace(c.Query(key))
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+func queryDate(c *fiber.Ctx, key string) (*time.Time, error) {
+	t, err := utils.ParseDate(queryString(c, key))
+	if err != nil {
+		return nil, errors.New(key + ": " + err.Error())
+	}
+	return t, nil
+}
+
+// dateRange reads the optional ?from= and ?to= dates.
+func dateRange(c *fiber.Ctx) (*time.Time, *time.Time, error) {
+	from, err := queryDate(c, "from")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to, err := queryDate(c, "to")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"errors"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type LockoutHandler struct {
+	log     *logrus.Logger
+	service *application.LockoutService
+}
+
+func NewLockoutHandler(
+	log *logrus.Logger,
+	s *application.LockoutService,
+) *LockoutHandler {
+	return &LockoutHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+func (h *LockoutHandler) ListLocked(c *fiber.Ctx) error {
+	result, err := h.service.ListLocked(c.Context(), actorFrom(c))
+	if err != nil {
+		h.log.Error("failed to list locked users: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *LockoutHandler) LockState(c *fiber.Ctx) error {
+	publicID := c.Params("public_id")
+
+	result, err := h.service.LockState(c.Context(), actorFrom(c), publicID)
+	if errors.Is(err, application.ErrForbidden) {
+		return c.Status(403).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, ports.ErrNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "user not found"})
+	}
+	if err != nil {
+		h.log.Error("failed to get lock state: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *LockoutHandler) Unlock(c *fiber.Ctx) error {
+	publicID := c.Params("public_id")
+	managerID := c.Locals("user_id").(string)
+
+	err := h.service.Unlock(c.Context(), actorFrom(c), publicID)
+	if errors.Is(err, application.ErrForbidden) {
+		return c.Status(403).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, ports.ErrNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "user not found"})
+	}
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"manager_id": managerID,
+			"target":     publicID,
+			"error":      err.Error(),
+		}).Error("failed to unlock user")
+
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"manager_id": managerID,
+		"target":     publicID,
+	}).Info("user unlocked")
+
+	return c.SendStatus(204)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"time"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type MeasurementHandler struct {
+	log     *logrus.Logger
+	service *application.MeasurementService
+}
+
+func NewMeasurementHandler(
+	log *logrus.Logger,
+	s *application.MeasurementService,
+) *MeasurementHandler {
+	return &MeasurementHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type measurementRequest struct {
+	MeasuredAt         *time.Time `json:"measured_at"`
+	WeightKg           *float64   `json:"weight_kg"`
+	LengthCm           *float64   `json:"length_cm"`
+	BodyConditionScore *float64   `json:"body_condition_score"`
+	Notes              *string    `json:"notes"`
+}
+
+func (h *MeasurementHandler) Create(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	var req measurementRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("animal_id", animalID).Warn("invalid measurement request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	input := ports.MeasurementInput{
+		AnimalPublicID:     animalID,
+		WeightKg:           req.WeightKg,
+		LengthCm:           req.LengthCm,
+		BodyConditionScore: req.BodyConditionScore,
+		Notes:              req.Notes,
+	}
+	if req.MeasuredAt != nil {
+		input.MeasuredAt = *req.MeasuredAt
+	}
+
+	result, err := h.service.Record(c.Context(), actorFrom(c), input)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"animal_id": animalID,
+			"error":     err.Error(),
+		}).Warn("failed to record measurement")
+
+		return c.Status(errorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"animal_id":      animalID,
+		"measurement_id": result.PublicID,
+	}).Info("measurement recorded successfully")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *MeasurementHandler) List(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	from, to, err := dateRange(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.List(c.Context(), actorFrom(c), animalID, from, to)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *MeasurementHandler) Trend(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	from, to, err := dateRange(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.Trend(c.Context(), actorFrom(c), animalID, c.Query("metric"), from, to)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *MeasurementHandler) Alerts(c *fiber.Ctx) error {
+
+	result, err := h.service.WeightAlerts(c.Context(), actorFrom(c))
+	if err != nil {
+		h.log.Error("failed to evaluate weight alerts: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *MeasurementHandler) ListThresholds(c *fiber.Ctx) error {
+
+	result, err := h.service.ListThresholds(c.Context())
+	if err != nil {
+		h.log.Error("failed to list weight thresholds: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *MeasurementHandler) SetThreshold(c *fiber.Ctx) error {
+
+	var req struct {
+		MaxChangePercent float64 `json:"max_change_percent"`
+		WindowDays       int     `json:"window_days"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid weight threshold request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.SetThreshold(c.Context(), ports.WeightThresholdDTO{
+		SpeciesPublicID:  c.Params("species_public_id"),
+		MaxChangePercent: req.MaxChangePercent,
+		WindowDays:       req.WindowDays,
+	})
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"user_id": c.Locals("user_id"),
+		"species": result.SpeciesPublicID,
+	}).Info("weight threshold updated")
+
+	return c.JSON(result)
+}
+
+func (h *MeasurementHandler) DeleteThreshold(c *fiber.Ctx) error {
+
+	if err := h.service.DeleteThreshold(c.Context(), c.Params("species_public_id")); err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.SendStatus(204)
+}
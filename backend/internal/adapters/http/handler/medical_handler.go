@@ -0,0 +1,306 @@
+package handler
+
+import (
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type MedicalHandler struct {
+	log     *logrus.Logger
+	service *application.MedicalService
+}
+
+func NewMedicalHandler(
+	log *logrus.Logger,
+	s *application.MedicalService,
+) *MedicalHandler {
+	return &MedicalHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+func (h *MedicalHandler) Timeline(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	var types []string
+	if v := queryString(c, "type"); v != nil {
+		types = strings.Split(*v, ",")
+	}
+
+	result, err := h.service.Timeline(c.Context(), actorFrom(c), animalID, types)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"animal_id": animalID,
+			"error":     err.Error(),
+		}).Warn("failed to load medical timeline")
+
+		return c.Status(errorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(result)
+}
+
+type vetVisitRequest struct {
+	VisitedAt *time.Time `json:"visited_at"`
+	VetName   string     `json:"vet_name"`
+	Reason    *string    `json:"reason"`
+	Notes     *string    `json:"notes"`
+}
+
+func (h *MedicalHandler) CreateVisit(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	var req vetVisitRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("animal_id", animalID).Warn("invalid vet visit request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	input := ports.VetVisitInput{
+		AnimalPublicID: animalID,
+		VetName:        req.VetName,
+		Reason:         req.Reason,
+		Notes:          req.Notes,
+	}
+	if req.VisitedAt != nil {
+		input.VisitedAt = *req.VisitedAt
+	}
+
+	result, err := h.service.RecordVisit(c.Context(), actorFrom(c), input)
+	if err != nil {
+		return h.fail(c, animalID, "vet visit", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"animal_id": animalID,
+		"visit_id":  result.PublicID,
+	}).Info("vet visit recorded")
+
+	return c.Status(201).JSON(result)
+}
+
+type diagnosisRequest struct {
+	VisitPublicID *string `json:"visit_public_id"`
+	Condition     string  `json:"condition"`
+	Severity      string  `json:"severity"`
+	Notes         *string `json:"notes"`
+	DiagnosedOn   *string `json:"diagnosed_on"`
+}
+
+func (h *MedicalHandler) CreateDiagnosis(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	var req diagnosisRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("animal_id", animalID).Warn("invalid diagnosis request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	diagnosedOn, err := utils.ParseDate(req.DiagnosedOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "diagnosed_on: " + err.Error()})
+	}
+
+	input := ports.DiagnosisInput{
+		AnimalPublicID: animalID,
+		VisitPublicID:  req.VisitPublicID,
+		Condition:      req.Condition,
+		Severity:       req.Severity,
+		Notes:          req.Notes,
+	}
+	if diagnosedOn != nil {
+		input.DiagnosedOn = *diagnosedOn
+	}
+
+	result, err := h.service.RecordDiagnosis(c.Context(), actorFrom(c), input)
+	if err != nil {
+		return h.fail(c, animalID, "diagnosis", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"animal_id":    animalID,
+		"diagnosis_id": result.PublicID,
+	}).Info("diagnosis recorded")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *MedicalHandler) ResolveDiagnosis(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+	diagnosisID := c.Params("diagnosis_id")
+
+	var req struct {
+		ResolvedOn *string `json:"resolved_on"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+		}
+	}
+
+	resolvedOn, err := utils.ParseDate(req.ResolvedOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "resolved_on: " + err.Error()})
+	}
+
+	err = h.service.ResolveDiagnosis(c.Context(), actorFrom(c), animalID, diagnosisID, resolvedOn)
+	if err != nil {
+		return h.fail(c, animalID, "diagnosis resolution", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "diagnosis resolved successfully",
+	})
+}
+
+type prescriptionRequest struct {
+	VisitPublicID     *string `json:"visit_public_id"`
+	DiagnosisPublicID *string `json:"diagnosis_public_id"`
+	Medication        string  `json:"medication"`
+	Dosage            string  `json:"dosage"`
+	Route             *string `json:"route"`
+	IntervalHours     int     `json:"interval_hours"`
+	StartsOn          *string `json:"starts_on"`
+	EndsOn            *string `json:"ends_on"`
+	Instructions      *string `json:"instructions"`
+}
+
+func (h *MedicalHandler) CreatePrescription(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	var req prescriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("animal_id", animalID).Warn("invalid prescription request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	startsOn, err := utils.ParseDate(req.StartsOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "starts_on: " + err.Error()})
+	}
+	endsOn, err := utils.ParseDate(req.EndsOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "ends_on: " + err.Error()})
+	}
+
+	input := ports.PrescriptionInput{
+		AnimalPublicID:    animalID,
+		VisitPublicID:     req.VisitPublicID,
+		DiagnosisPublicID: req.DiagnosisPublicID,
+		Medication:        req.Medication,
+		Dosage:            req.Dosage,
+		Route:             req.Route,
+		IntervalHours:     req.IntervalHours,
+		EndsOn:            endsOn,
+		Instructions:      req.Instructions,
+	}
+	if startsOn != nil {
+		input.StartsOn = *startsOn
+	}
+
+	result, err := h.service.RecordPrescription(c.Context(), actorFrom(c), input)
+	if err != nil {
+		return h.fail(c, animalID, "prescription", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"animal_id":       animalID,
+		"prescription_id": result.PublicID,
+	}).Info("prescription recorded")
+
+	return c.Status(201).JSON(result)
+}
+
+type vaccinationRequest struct {
+	VisitPublicID  *string `json:"visit_public_id"`
+	Vaccine        string  `json:"vaccine"`
+	AdministeredOn *string `json:"administered_on"`
+	DueOn          *string `json:"due_on"`
+	BatchNumber    *string `json:"batch_number"`
+	Notes          *string `json:"notes"`
+}
+
+func (h *MedicalHandler) CreateVaccination(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	var req vaccinationRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("animal_id", animalID).Warn("invalid vaccination request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	administeredOn, err := utils.ParseDate(req.AdministeredOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "administered_on: " + err.Error()})
+	}
+	dueOn, err := utils.ParseDate(req.DueOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "due_on: " + err.Error()})
+	}
+
+	input := ports.VaccinationInput{
+		AnimalPublicID: animalID,
+		VisitPublicID:  req.VisitPublicID,
+		Vaccine:        req.Vaccine,
+		DueOn:          dueOn,
+		BatchNumber:    req.BatchNumber,
+		Notes:          req.Notes,
+	}
+	if administeredOn != nil {
+		input.AdministeredOn = *administeredOn
+	}
+
+	result, err := h.service.RecordVaccination(c.Context(), actorFrom(c), input)
+	if err != nil {
+		return h.fail(c, animalID, "vaccination", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"animal_id":      animalID,
+		"vaccination_id": result.PublicID,
+	}).Info("vaccination recorded")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *MedicalHandler) VaccinationsDue(c *fiber.Ctx) error {
+
+	days := c.QueryInt("within_days", 30)
+	if days < 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "within_days must not be negative"})
+	}
+
+	result, err := h.service.VaccinationsDue(c.Context(), actorFrom(c), days)
+	if err != nil {
+		h.log.Error("failed to list due vaccinations: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *MedicalHandler) fail(c *fiber.Ctx, animalID, record string, err error) error {
+	h.log.WithFields(logrus.Fields{
+		"animal_id": animalID,
+		"error":     err.Error(),
+	}).Warn("failed to record " + record)
+
+	return c.Status(errorStatus(err)).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"wit-leisure-park/backend/internal/application"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type MFAHandler struct {
+	log     *logrus.Logger
+	service *application.MFAService
+}
+
+func NewMFAHandler(
+	log *logrus.Logger,
+	s *application.MFAService,
+) *MFAHandler {
+	return &MFAHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type mfaCodeRequest struct {
+	Code string `json:"code"`
+}
+
+func (h *MFAHandler) Enroll(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	result, err := h.service.Enroll(c.Context(), userID)
+	if errors.Is(err, application.ErrMFAAlreadyEnabled) {
+		return c.Status(409).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("failed to enroll mfa")
+
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	h.log.WithField("user_id", userID).Info("mfa enrollment started")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *MFAHandler) Confirm(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	var req mfaCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid mfa confirm request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	codes, err := h.service.Confirm(c.Context(), userID, req.Code)
+	if errors.Is(err, application.ErrInvalidMFACode) ||
+		errors.Is(err, application.ErrMFANotEnrolled) ||
+		errors.Is(err, application.ErrMFAAlreadyEnabled) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("failed to confirm mfa")
+
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	h.log.WithField("user_id", userID).Info("mfa enabled")
+
+	return c.JSON(fiber.Map{
+		"recovery_codes": codes,
+	})
+}
+
+func (h *MFAHandler) Disable(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	var req mfaCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid mfa disable request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	err := h.service.Disable(c.Context(), userID, req.Code)
+	if errors.Is(err, application.ErrInvalidMFACode) ||
+		errors.Is(err, application.ErrMFANotEnrolled) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("failed to disable mfa")
+
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	h.log.WithField("user_id", userID).Info("mfa disabled")
+
+	return c.SendStatus(204)
+}
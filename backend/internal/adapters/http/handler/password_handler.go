@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"errors"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PasswordHandler struct {
+	log     *logrus.Logger
+	service *application.PasswordService
+}
+
+func NewPasswordHandler(
+	log *logrus.Logger,
+	s *application.PasswordService,
+) *PasswordHandler {
+	return &PasswordHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type issueResetRequest struct {
+	ForceChange bool `json:"force_change"`
+}
+
+type resetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+func (h *PasswordHandler) Change(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	var req changePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid change password request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	err := h.service.Change(
+		c.Context(),
+		userID,
+		req.CurrentPassword,
+		req.NewPassword,
+	)
+	if errors.Is(err, application.ErrInvalidCredentials) {
+		h.log.WithField("user_id", userID).
+			Warn("password change with wrong current password")
+
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "current password is incorrect",
+		})
+	}
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Warn("failed to change password")
+
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	h.log.WithField("user_id", userID).
+		Info("password changed successfully")
+
+	return c.JSON(fiber.Map{
+		"message": "password changed, please log in again",
+	})
+}
+
+func (h *PasswordHandler) IssueReset(c *fiber.Ctx) error {
+	targetID := c.Params("public_id")
+	managerID := c.Locals("user_id").(string)
+
+	var req issueResetRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			h.log.Warn("invalid password reset request body")
+			return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+		}
+	}
+
+	result, err := h.service.IssueReset(
+		c.Context(),
+		actorFrom(c),
+		targetID,
+		req.ForceChange,
+	)
+	if errors.Is(err, application.ErrForbidden) {
+		return c.Status(403).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, ports.ErrNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "user not found"})
+	}
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"manager_id": managerID,
+			"target":     targetID,
+			"error":      err.Error(),
+		}).Error("failed to issue password reset")
+
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"manager_id":   managerID,
+		"target":       targetID,
+		"force_change": req.ForceChange,
+	}).Info("password reset issued")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *PasswordHandler) Reset(c *fiber.Ctx) error {
+	var req resetPasswordRequest
+	if err := c.BodyParser(&req); err != nil || req.Token == "" {
+		h.log.Warn("invalid reset password request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	err := h.service.Reset(c.Context(), req.Token, req.NewPassword)
+	if errors.Is(err, application.ErrInvalidResetToken) {
+		h.log.Warn("password reset with invalid token")
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, application.ErrWeakPassword) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		h.log.Error("failed to reset password: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	h.log.Info("password reset completed")
+
+	return c.JSON(fiber.Map{
+		"message": "password reset successfully",
+	})
+}
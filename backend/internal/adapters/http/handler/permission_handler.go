@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PermissionHandler struct {
+	log     *logrus.Logger
+	service *application.PermissionService
+}
+
+func NewPermissionHandler(
+	log *logrus.Logger,
+	s *application.PermissionService,
+) *PermissionHandler {
+	return &PermissionHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type setRolePermissionsRequest struct {
+	Permissions []domain.Permission `json:"permissions"`
+}
+
+func (h *PermissionHandler) ListPermissions(c *fiber.Ctx) error {
+	result, err := h.service.ListPermissions(c.Context())
+	if err != nil {
+		h.log.Error("failed to list permissions: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *PermissionHandler) ListRoles(c *fiber.Ctx) error {
+	result, err := h.service.ListRoles(c.Context())
+	if err != nil {
+		h.log.Error("failed to list roles: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *PermissionHandler) SetRolePermissions(c *fiber.Ctx) error {
+	role := domain.Role(c.Params("role"))
+	userID := c.Locals("user_id").(string)
+
+	var req setRolePermissionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid set role permissions request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	err := h.service.SetRolePermissions(c.Context(), role, req.Permissions)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"role":    role,
+			"error":   err.Error(),
+		}).Warn("failed to set role permissions")
+
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"role":        role,
+		"permissions": req.Permissions,
+	}).Info("role permissions updated")
+
+	return c.JSON(fiber.Map{
+		"message": "role permissions updated successfully",
+	})
+}
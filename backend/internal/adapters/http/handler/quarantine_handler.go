@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type QuarantineHandler struct {
+	log     *logrus.Logger
+	service *application.QuarantineService
+}
+
+func NewQuarantineHandler(
+	log *logrus.Logger,
+	s *application.QuarantineService,
+) *QuarantineHandler {
+	return &QuarantineHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type openQuarantineRequest struct {
+	CagePublicID      string   `json:"cage_public_id"`
+	Reason            string   `json:"reason"`
+	VetName           string   `json:"vet_name"`
+	ZookeeperPublicID string   `json:"zookeeper_public_id"`
+	StartedOn         *string  `json:"started_on"`
+	ExpectedEndOn     *string  `json:"expected_end_on"`
+	Checks            []string `json:"checks"`
+	OverrideReason    *string  `json:"override_reason"`
+}
+
+func (h *QuarantineHandler) Open(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	var req openQuarantineRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("animal_id", animalID).Warn("invalid quarantine request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	startedOn, err := utils.ParseDate(req.StartedOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "started_on: " + err.Error()})
+	}
+	expectedEndOn, err := utils.ParseDate(req.ExpectedEndOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "expected_end_on: " + err.Error()})
+	}
+
+	result, err := h.service.Open(c.Context(), actorFrom(c), application.OpenQuarantineInput{
+		AnimalPublicID:    animalID,
+		CagePublicID:      req.CagePublicID,
+		Reason:            req.Reason,
+		VetName:           req.VetName,
+		ZookeeperPublicID: req.ZookeeperPublicID,
+		StartedOn:         startedOn,
+		ExpectedEndOn:     expectedEndOn,
+		Checks:            req.Checks,
+		OverrideReason:    req.OverrideReason,
+	})
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"animal_id": animalID,
+			"cage_id":   req.CagePublicID,
+			"error":     err.Error(),
+		}).Warn("failed to open quarantine")
+
+		return c.Status(errorStatus(err)).JSON(errorBody(err))
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"animal_id":     animalID,
+		"quarantine_id": result.PublicID,
+	}).Info("quarantine opened")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *QuarantineHandler) List(c *fiber.Ctx) error {
+
+	result, err := h.service.List(c.Context(), actorFrom(c), queryString(c, "status"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *QuarantineHandler) ListByAnimal(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	result, err := h.service.ListByAnimal(c.Context(), actorFrom(c), animalID)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *QuarantineHandler) FindByID(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	result, err := h.service.FindByID(c.Context(), actorFrom(c), publicID)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+type updateQuarantineRequest struct {
+	VetName           string  `json:"vet_name"`
+	ZookeeperPublicID string  `json:"zookeeper_public_id"`
+	ExpectedEndOn     *string `json:"expected_end_on"`
+}
+
+func (h *QuarantineHandler) Update(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req updateQuarantineRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("quarantine_id", publicID).Warn("invalid quarantine update request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	expectedEndOn, err := utils.ParseDate(req.ExpectedEndOn)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "expected_end_on: " + err.Error()})
+	}
+
+	input := ports.QuarantineUpdateInput{
+		PublicID:          publicID,
+		VetName:           req.VetName,
+		ZookeeperPublicID: req.ZookeeperPublicID,
+	}
+	if expectedEndOn != nil {
+		input.ExpectedEndOn = *expectedEndOn
+	}
+
+	result, err := h.service.Update(c.Context(), actorFrom(c), input)
+	if err != nil {
+		return h.fail(c, publicID, "update quarantine", err)
+	}
+
+	h.log.WithField("quarantine_id", publicID).Info("quarantine updated")
+
+	return c.JSON(result)
+}
+
+type quarantineNotesRequest struct {
+	Notes *string `json:"notes"`
+}
+
+func (h *QuarantineHandler) PassCheck(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+	checkID := c.Params("check_id")
+
+	var req quarantineNotesRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+		}
+	}
+
+	result, err := h.service.PassCheck(c.Context(), actorFrom(c), publicID, checkID, req.Notes)
+	if err != nil {
+		return h.fail(c, publicID, "pass quarantine check", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"quarantine_id": publicID,
+		"check_id":      checkID,
+	}).Info("quarantine check passed")
+
+	return c.JSON(result)
+}
+
+func (h *QuarantineHandler) Clear(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req quarantineNotesRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+		}
+	}
+
+	result, err := h.service.Clear(c.Context(), actorFrom(c), publicID, req.Notes)
+	if err != nil {
+		return h.fail(c, publicID, "clear quarantine", err)
+	}
+
+	h.log.WithField("quarantine_id", publicID).Info("quarantine cleared")
+
+	return c.JSON(result)
+}
+
+func (h *QuarantineHandler) fail(c *fiber.Ctx, publicID, action string, err error) error {
+	h.log.WithFields(logrus.Fields{
+		"quarantine_id": publicID,
+		"error":         err.Error(),
+	}).Warn("failed to " + action)
+
+	return c.Status(errorStatus(err)).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
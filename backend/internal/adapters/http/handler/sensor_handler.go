@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"errors"
+	"time"
+	"wit-leisure-park/backend/internal/application"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// DeviceKeyHeader carries the key a sensor authenticates its readings with.
+const DeviceKeyHeader = "X-Device-Key"
+
+type SensorHandler struct {
+	log     *logrus.Logger
+	service *application.SensorService
+}
+
+func NewSensorHandler(
+	log *logrus.Logger,
+	s *application.SensorService,
+) *SensorHandler {
+	return &SensorHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type sensorRequest struct {
+	CagePublicID string  `json:"cage_public_id"`
+	Name         string  `json:"name"`
+	SerialNumber *string `json:"serial_number"`
+	Active       *bool   `json:"active"`
+}
+
+func (r sensorRequest) toService() application.SensorRequest {
+	return application.SensorRequest{
+		CagePublicID: r.CagePublicID,
+		Name:         r.Name,
+		SerialNumber: r.SerialNumber,
+		Active:       r.Active,
+	}
+}
+
+func (h *SensorHandler) Create(c *fiber.Ctx) error {
+
+	var req sensorRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid sensor request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.Register(c.Context(), actorFrom(c), req.toService())
+	if err != nil {
+		return h.fail(c, req.CagePublicID, "register sensor", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"sensor_id": result.PublicID,
+		"cage_id":   result.CagePublicID,
+	}).Info("sensor registered")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *SensorHandler) List(c *fiber.Ctx) error {
+
+	result, err := h.service.List(c.Context(), actorFrom(c), queryString(c, "cage_public_id"))
+	if err != nil {
+		h.log.Error("failed to list sensors: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SensorHandler) FindByID(c *fiber.Ctx) error {
+
+	result, err := h.service.FindByID(c.Context(), actorFrom(c), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SensorHandler) Update(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req sensorRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("sensor_id", publicID).Warn("invalid sensor request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.Update(c.Context(), actorFrom(c), publicID, req.toService())
+	if err != nil {
+		return h.fail(c, publicID, "update sensor", err)
+	}
+
+	h.log.WithField("sensor_id", publicID).Info("sensor updated")
+
+	return c.JSON(result)
+}
+
+func (h *SensorHandler) RotateKey(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	result, err := h.service.RotateKey(c.Context(), actorFrom(c), publicID)
+	if err != nil {
+		return h.fail(c, publicID, "rotate sensor key", err)
+	}
+
+	h.log.WithField("sensor_id", publicID).Info("sensor key rotated")
+
+	return c.JSON(result)
+}
+
+func (h *SensorHandler) Delete(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	if err := h.service.Delete(c.Context(), actorFrom(c), publicID); err != nil {
+		return h.fail(c, publicID, "delete sensor", err)
+	}
+
+	h.log.WithField("sensor_id", publicID).Info("sensor deleted")
+
+	return c.SendStatus(204)
+}
+
+type readingsRequest struct {
+	Readings []struct {
+		Metric     string     `json:"metric"`
+		Value      float64    `json:"value"`
+		RecordedAt *time.Time `json:"recorded_at"`
+	} `json:"readings"`
+}
+
+// Ingest accepts a batch of readings from a device. It is not behind the
+// user login; the device key identifies the sensor.
+func (h *SensorHandler) Ingest(c *fiber.Ctx) error {
+
+	var req readingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	readings := make([]application.ReadingRequest, 0, len(req.Readings))
+	for _, r := range req.Readings {
+		readings = append(readings, application.ReadingRequest{
+			Metric:     r.Metric,
+			Value:      r.Value,
+			RecordedAt: r.RecordedAt,
+		})
+	}
+
+	result, err := h.service.Ingest(c.Context(), c.Get(DeviceKeyHeader), readings)
+	if errors.Is(err, application.ErrInvalidDeviceKey) {
+		h.log.WithField("ip", c.IP()).Warn("sensor readings with an invalid device key")
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		h.log.WithField("error", err.Error()).Warn("failed to ingest sensor readings")
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if result.AlertsOpened > 0 {
+		h.log.WithField("alerts", result.AlertsOpened).Warn("environment alerts opened")
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SensorHandler) Environment(c *fiber.Ctx) error {
+
+	from, to, err := dateRange(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.Aggregates(
+		c.Context(),
+		actorFrom(c),
+		c.Params("public_id"),
+		queryString(c, "metric"),
+		queryString(c, "sensor_public_id"),
+		from, to,
+	)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SensorHandler) ListThresholds(c *fiber.Ctx) error {
+
+	result, err := h.service.ListThresholds(c.Context(), actorFrom(c), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SensorHandler) SetThreshold(c *fiber.Ctx) error {
+
+	cageID := c.Params("public_id")
+
+	var req struct {
+		MinValue *float64 `json:"min_value"`
+		MaxValue *float64 `json:"max_value"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("cage_id", cageID).Warn("invalid environment threshold request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.SetThreshold(c.Context(), actorFrom(c), cageID, c.Params("metric"), req.MinValue, req.MaxValue)
+	if err != nil {
+		return h.fail(c, cageID, "set environment threshold", err)
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SensorHandler) DeleteThreshold(c *fiber.Ctx) error {
+
+	if err := h.service.DeleteThreshold(c.Context(), actorFrom(c), c.Params("public_id"), c.Params("metric")); err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.SendStatus(204)
+}
+
+func (h *SensorHandler) ListAlerts(c *fiber.Ctx) error {
+
+	result, err := h.service.ListAlerts(c.Context(), actorFrom(c), queryString(c, "cage_public_id"), c.Query("status"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SensorHandler) AcknowledgeAlert(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	result, err := h.service.AcknowledgeAlert(c.Context(), actorFrom(c), publicID)
+	if err != nil {
+		return h.fail(c, publicID, "acknowledge environment alert", err)
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SensorHandler) fail(c *fiber.Ctx, publicID, action string, err error) error {
+	h.log.WithFields(logrus.Fields{
+		"public_id": publicID,
+		"error":     err.Error(),
+	}).Warn("failed to " + action)
+
+	return c.Status(errorStatus(err)).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"time"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ShiftHandler struct {
+	log     *logrus.Logger
+	service *application.ShiftService
+}
+
+func NewShiftHandler(
+	log *logrus.Logger,
+	s *application.ShiftService,
+) *ShiftHandler {
+	return &ShiftHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type shiftRequest struct {
+	ZookeeperPublicID string    `json:"zookeeper_public_id"`
+	ZonePublicID      *string   `json:"zone_public_id"`
+	CagePublicID      *string   `json:"cage_public_id"`
+	StartsAt          time.Time `json:"starts_at"`
+	EndsAt            time.Time `json:"ends_at"`
+	Notes             *string   `json:"notes"`
+}
+
+func (r shiftRequest) toService() application.ShiftRequest {
+	return application.ShiftRequest{
+		ZookeeperPublicID: r.ZookeeperPublicID,
+		ZonePublicID:      r.ZonePublicID,
+		CagePublicID:      r.CagePublicID,
+		StartsAt:          r.StartsAt,
+		EndsAt:            r.EndsAt,
+		Notes:             r.Notes,
+	}
+}
+
+func (h *ShiftHandler) Create(c *fiber.Ctx) error {
+
+	var req shiftRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid shift request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.Create(c.Context(), actorFrom(c), req.toService())
+	if err != nil {
+		return h.fail(c, req.ZookeeperPublicID, "schedule shift", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"shift_id":     result.PublicID,
+		"zookeeper_id": result.ZookeeperPublicID,
+	}).Info("shift scheduled")
+
+	return c.Status(201).JSON(result)
+}
+
+// shiftQuery reads the filters shared by the shift list and the roster.
+func shiftQuery(c *fiber.Ctx) ports.ShiftQuery {
+	return ports.ShiftQuery{
+		ZookeeperPublicID: queryString(c, "zookeeper_public_id"),
+		ZonePublicID:      queryString(c, "zone_public_id"),
+		ManagerPublicID:   queryString(c, "manager_public_id"),
+	}
+}
+
+func (h *ShiftHandler) List(c *fiber.Ctx) error {
+
+	from, to, err := dateRange(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.List(c.Context(), actorFrom(c), shiftQuery(c), from, to)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *ShiftHandler) Roster(c *fiber.Ctx) error {
+
+	week, err := queryDate(c, "week")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.Roster(c.Context(), actorFrom(c), shiftQuery(c), week)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *ShiftHandler) FindByID(c *fiber.Ctx) error {
+
+	result, err := h.service.FindByID(c.Context(), actorFrom(c), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *ShiftHandler) Update(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req shiftRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("shift_id", publicID).Warn("invalid shift request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.Update(c.Context(), actorFrom(c), publicID, req.toService())
+	if err != nil {
+		return h.fail(c, publicID, "update shift", err)
+	}
+
+	h.log.WithField("shift_id", publicID).Info("shift updated")
+
+	return c.JSON(result)
+}
+
+func (h *ShiftHandler) Delete(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	if err := h.service.Delete(c.Context(), actorFrom(c), publicID); err != nil {
+		return h.fail(c, publicID, "cancel shift", err)
+	}
+
+	h.log.WithField("shift_id", publicID).Info("shift cancelled")
+
+	return c.SendStatus(204)
+}
+
+func (h *ShiftHandler) fail(c *fiber.Ctx, publicID, action string, err error) error {
+	h.log.WithFields(logrus.Fields{
+		"public_id": publicID,
+		"error":     err.Error(),
+	}).Warn("failed to " + action)
+
+	return c.Status(errorStatus(err)).JSON(errorBody(err))
+}
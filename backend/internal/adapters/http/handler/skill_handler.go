@@ -0,0 +1,339 @@
+package handler
+
+import (
+	"strconv"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultExpiringDays = 30
+
+type SkillHandler struct {
+	log     *logrus.Logger
+	service *application.SkillService
+}
+
+func NewSkillHandler(
+	log *logrus.Logger,
+	s *application.SkillService,
+) *SkillHandler {
+	return &SkillHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type skillRequest struct {
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+func (r skillRequest) toService() application.SkillRequest {
+	return application.SkillRequest{
+		Code:        r.Code,
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+type requiredSkillsRequest struct {
+	SkillPublicIDs []string `json:"skill_public_ids"`
+}
+
+type certificationRequest struct {
+	ZookeeperPublicID string  `json:"zookeeper_public_id"`
+	SkillPublicID     string  `json:"skill_public_id"`
+	CertifiedOn       *string `json:"certified_on"`
+	ExpiresOn         *string `json:"expires_on"`
+	Reference         *string `json:"reference"`
+	Notes             *string `json:"notes"`
+}
+
+func (r certificationRequest) toService() (application.CertificationRequest, error) {
+	certifiedOn, err := utils.ParseDate(r.CertifiedOn)
+	if err != nil {
+		return application.CertificationRequest{}, err
+	}
+	expiresOn, err := utils.ParseDate(r.ExpiresOn)
+	if err != nil {
+		return application.CertificationRequest{}, err
+	}
+
+	return application.CertificationRequest{
+		ZookeeperPublicID: r.ZookeeperPublicID,
+		SkillPublicID:     r.SkillPublicID,
+		CertifiedOn:       certifiedOn,
+		ExpiresOn:         expiresOn,
+		Reference:         r.Reference,
+		Notes:             r.Notes,
+	}, nil
+}
+
+func (h *SkillHandler) CreateSkill(c *fiber.Ctx) error {
+
+	var req skillRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid skill request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.CreateSkill(c.Context(), req.toService())
+	if err != nil {
+		return h.fail(c, req.Code, "create skill", err)
+	}
+
+	h.log.WithField("skill_id", result.PublicID).Info("skill created")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *SkillHandler) ListSkills(c *fiber.Ctx) error {
+
+	result, err := h.service.ListSkills(c.Context())
+	if err != nil {
+		h.log.Error("failed to list skills: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SkillHandler) FindSkill(c *fiber.Ctx) error {
+
+	result, err := h.service.FindSkill(c.Context(), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SkillHandler) UpdateSkill(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req skillRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("skill_id", publicID).Warn("invalid skill request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.UpdateSkill(c.Context(), publicID, req.toService())
+	if err != nil {
+		return h.fail(c, publicID, "update skill", err)
+	}
+
+	h.log.WithField("skill_id", publicID).Info("skill updated")
+
+	return c.JSON(result)
+}
+
+func (h *SkillHandler) DeleteSkill(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	if err := h.service.DeleteSkill(c.Context(), publicID); err != nil {
+		return h.fail(c, publicID, "delete skill", err)
+	}
+
+	h.log.WithField("skill_id", publicID).Info("skill deleted")
+
+	return c.SendStatus(204)
+}
+
+func (h *SkillHandler) SpeciesSkills(c *fiber.Ctx) error {
+
+	result, err := h.service.SpeciesSkills(c.Context(), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SkillHandler) SetSpeciesSkills(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req requiredSkillsRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("species_id", publicID).Warn("invalid required skills body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.SetSpeciesSkills(c.Context(), publicID, req.SkillPublicIDs)
+	if err != nil {
+		return h.fail(c, publicID, "set species skills", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"species_id": publicID,
+		"skills":     len(result),
+	}).Info("species skills set")
+
+	return c.JSON(result)
+}
+
+func (h *SkillHandler) TemplateSkills(c *fiber.Ctx) error {
+
+	result, err := h.service.TemplateSkills(c.Context(), actorFrom(c), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SkillHandler) SetTemplateSkills(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req requiredSkillsRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("template_id", publicID).Warn("invalid required skills body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.SetTemplateSkills(c.Context(), actorFrom(c), publicID, req.SkillPublicIDs)
+	if err != nil {
+		return h.fail(c, publicID, "set template skills", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"template_id": publicID,
+		"skills":      len(result),
+	}).Info("template skills set")
+
+	return c.JSON(result)
+}
+
+func (h *SkillHandler) CreateCertification(c *fiber.Ctx) error {
+
+	var req certificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid certification request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	input, err := req.toService()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.CreateCertification(c.Context(), actorFrom(c), input)
+	if err != nil {
+		return h.fail(c, req.ZookeeperPublicID, "record certification", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"certification_id": result.PublicID,
+		"zookeeper_id":     result.ZookeeperPublicID,
+		"skill":            result.SkillCode,
+	}).Info("certification recorded")
+
+	return c.Status(201).JSON(result)
+}
+
+// certificationQuery reads the filters shared by the list and the expiry
+// report.
+func certificationQuery(c *fiber.Ctx) ports.CertificationQuery {
+	return ports.CertificationQuery{
+		ZookeeperPublicID: queryString(c, "zookeeper_public_id"),
+		SkillPublicID:     queryString(c, "skill_public_id"),
+		ManagerPublicID:   queryString(c, "manager_public_id"),
+	}
+}
+
+func (h *SkillHandler) ListCertifications(c *fiber.Ctx) error {
+
+	result, err := h.service.ListCertifications(c.Context(), actorFrom(c), certificationQuery(c))
+	if err != nil {
+		h.log.Error("failed to list certifications: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+// Expiring lists the certifications expiring in the next ?days= (30 by
+// default).
+func (h *SkillHandler) Expiring(c *fiber.Ctx) error {
+
+	days := defaultExpiringDays
+	if v := c.Query("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "days must be a number"})
+		}
+		days = n
+	}
+
+	result, err := h.service.Expiring(c.Context(), actorFrom(c), days, certificationQuery(c))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SkillHandler) FindCertification(c *fiber.Ctx) error {
+
+	result, err := h.service.FindCertification(c.Context(), actorFrom(c), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SkillHandler) UpdateCertification(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req certificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("certification_id", publicID).Warn("invalid certification request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	input, err := req.toService()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.UpdateCertification(c.Context(), publicID, input)
+	if err != nil {
+		return h.fail(c, publicID, "update certification", err)
+	}
+
+	h.log.WithField("certification_id", publicID).Info("certification updated")
+
+	return c.JSON(result)
+}
+
+func (h *SkillHandler) DeleteCertification(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	if err := h.service.DeleteCertification(c.Context(), publicID); err != nil {
+		return h.fail(c, publicID, "delete certification", err)
+	}
+
+	h.log.WithField("certification_id", publicID).Info("certification deleted")
+
+	return c.SendStatus(204)
+}
+
+func (h *SkillHandler) fail(c *fiber.Ctx, publicID, action string, err error) error {
+	h.log.WithFields(logrus.Fields{
+		"public_id": publicID,
+		"error":     err.Error(),
+	}).Warn("failed to " + action)
+
+	return c.Status(errorStatus(err)).JSON(errorBody(err))
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"errors"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type SpeciesHandler struct {
+	log     *logrus.Logger
+	service *application.SpeciesService
+}
+
+func NewSpeciesHandler(
+	log *logrus.Logger,
+	s *application.SpeciesService,
+) *SpeciesHandler {
+	return &SpeciesHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type speciesRequest struct {
+	CommonName         string   `json:"common_name"`
+	ScientificName     *string  `json:"scientific_name"`
+	ConservationStatus *string  `json:"conservation_status"`
+	DietType           *string  `json:"diet_type"`
+	MinTemperatureC    *float64 `json:"min_temperature_c"`
+	MaxTemperatureC    *float64 `json:"max_temperature_c"`
+	FeedingsPerDay     *int     `json:"feedings_per_day"`
+	CareNotes          *string  `json:"care_notes"`
+}
+
+func (r speciesRequest) toInput(publicID string) ports.SpeciesInput {
+	return ports.SpeciesInput{
+		PublicID:           publicID,
+		CommonName:         r.CommonName,
+		ScientificName:     r.ScientificName,
+		ConservationStatus: r.ConservationStatus,
+		DietType:           r.DietType,
+		MinTemperatureC:    r.MinTemperatureC,
+		MaxTemperatureC:    r.MaxTemperatureC,
+		FeedingsPerDay:     r.FeedingsPerDay,
+		CareNotes:          r.CareNotes,
+	}
+}
+
+func (h *SpeciesHandler) Create(c *fiber.Ctx) error {
+
+	var req speciesRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid create species request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.Create(c.Context(), req.toInput(""))
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"common_name": req.CommonName,
+			"error":       err.Error(),
+		}).Warn("failed to create species")
+
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	h.log.WithField("public_id", result.PublicID).
+		Info("species created successfully")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *SpeciesHandler) List(c *fiber.Ctx) error {
+	params, err := parseListParams(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.List(c.Context(), ports.SpeciesListQuery{
+		ListParams:         params,
+		ConservationStatus: queryString(c, "conservation_status"),
+		DietType:           queryString(c, "diet_type"),
+		Search:             queryString(c, "q"),
+	})
+	if errors.Is(err, ports.ErrInvalidSort) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		h.log.Error("failed to list species: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SpeciesHandler) FindByID(c *fiber.Ctx) error {
+	publicID := c.Params("public_id")
+
+	result, err := h.service.FindByID(c.Context(), publicID)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *SpeciesHandler) Update(c *fiber.Ctx) error {
+	publicID := c.Params("public_id")
+
+	var req speciesRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid update species request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.Update(c.Context(), req.toInput(publicID))
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"public_id": publicID,
+			"error":     err.Error(),
+		}).Warn("failed to update species")
+
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	h.log.WithField("public_id", publicID).
+		Info("species updated successfully")
+
+	return c.JSON(result)
+}
+
+func (h *SpeciesHandler) Delete(c *fiber.Ctx) error {
+	publicID := c.Params("public_id")
+
+	if err := h.service.Delete(c.Context(), publicID); err != nil {
+		h.log.WithFields(logrus.Fields{
+			"public_id": publicID,
+			"error":     err.Error(),
+		}).Warn("failed to delete species")
+
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	h.log.WithField("public_id", publicID).
+		Info("species deleted successfully")
+
+	return c.SendStatus(204)
+}
+
+func (h *SpeciesHandler) Merge(c *fiber.Ctx) error {
+	publicID := c.Params("public_id")
+
+	var req struct {
+		DuplicatePublicID string `json:"duplicate_public_id"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid merge species request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.Merge(c.Context(), publicID, req.DuplicatePublicID)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"public_id": publicID,
+			"duplicate": req.DuplicatePublicID,
+			"error":     err.Error(),
+		}).Warn("failed to merge species")
+
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"public_id": publicID,
+		"duplicate": req.DuplicatePublicID,
+	}).Info("species merged successfully")
+
+	return c.JSON(result)
+}
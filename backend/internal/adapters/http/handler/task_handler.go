@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"errors"
+	"strings"
 	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/domain/task"
 	"wit-leisure-park/backend/internal/ports"
 	"wit-leisure-park/backend/internal/utils"
 
@@ -30,6 +33,8 @@ type createTaskRequest struct {
 	ZookeeperPublicID string  `json:"zookeeper_public_id"`
 	AnimalPublicID    *string `json:"animal_public_id"`
 	DueDate           *string `json:"due_date"`
+	// AutoAssign lets the service pick the zookeeper instead
+	AutoAssign bool `json:"auto_assign"`
 }
 
 func (h *TaskHandler) Create(c *fiber.Ctx) error {
@@ -58,15 +63,33 @@ func (h *TaskHandler) Create(c *fiber.Ctx) error {
 		h.log.Warn("invalid due date")
 		return c.Status(400).JSON(fiber.Map{"error": "invalid due date"})
 	}
-	publicID, err := h.service.Create(
-		c.Context(),
-		req.Title,
-		req.Description,
-		managerID,
-		req.ZookeeperPublicID,
-		req.AnimalPublicID,
-		parsedDueDate,
-	)
+	if req.AutoAssign && req.ZookeeperPublicID != "" {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "send either zookeeper_public_id or auto_assign",
+		})
+	}
+
+	var result application.CreatedTask
+	if req.AutoAssign {
+		result, err = h.service.CreateAutoAssigned(
+			c.Context(),
+			actorFrom(c),
+			req.Title,
+			req.Description,
+			req.AnimalPublicID,
+			parsedDueDate,
+		)
+	} else {
+		result, err = h.service.Create(
+			c.Context(),
+			actorFrom(c),
+			req.Title,
+			req.Description,
+			req.ZookeeperPublicID,
+			req.AnimalPublicID,
+			parsedDueDate,
+		)
+	}
 
 	if err != nil {
 		h.log.WithFields(logrus.Fields{
@@ -74,19 +97,16 @@ func (h *TaskHandler) Create(c *fiber.Ctx) error {
 			"error":      err.Error(),
 		}).Warn("failed to create task")
 
-		return c.Status(400).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(errorStatus(err)).JSON(errorBody(err))
 	}
 
 	h.log.WithFields(logrus.Fields{
-		"task_id":    publicID,
+		"task_id":    result.PublicID,
 		"manager_id": managerID,
+		"warnings":   len(result.Warnings),
 	}).Info("task created successfully")
 
-	return c.Status(201).JSON(fiber.Map{
-		"public_id": publicID,
-	})
+	return c.Status(201).JSON(result)
 }
 
 func (h *TaskHandler) List(c *fiber.Ctx) error {
@@ -99,15 +119,15 @@ func (h *TaskHandler) List(c *fiber.Ctx) error {
 		"role":    role,
 	}).Info("list tasks request")
 
-	var result []ports.TaskDTO
-	var err error
-
-	if role == "MANAGER" {
-		result, err = h.service.ListByManager(c.Context(), userID)
-	} else {
-		result, err = h.service.ListByZookeeper(c.Context(), userID)
+	query, err := parseTaskListQuery(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	result, err := h.service.List(c.Context(), actorFrom(c), query)
+	if errors.Is(err, ports.ErrInvalidSort) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
 	if err != nil {
 		h.log.WithFields(logrus.Fields{
 			"user_id": userID,
@@ -121,14 +141,47 @@ func (h *TaskHandler) List(c *fiber.Ctx) error {
 
 	h.log.WithFields(logrus.Fields{
 		"user_id": userID,
-		"count":   len(result),
+		"count":   len(result.Items),
+		"total":   result.Total,
 	}).Info("tasks listed successfully")
 
 	return c.JSON(result)
 }
 
+func parseTaskListQuery(c *fiber.Ctx) (ports.TaskListQuery, error) {
+	params, err := parseListParams(c)
+	if err != nil {
+		return ports.TaskListQuery{}, err
+	}
+
+	query := ports.TaskListQuery{
+		ListParams:        params,
+		ZookeeperPublicID: queryString(c, "zookeeper_public_id"),
+		AnimalPublicID:    queryString(c, "animal_public_id"),
+		ZonePublicID:      queryString(c, "zone_public_id"),
+	}
+
+	if v := queryString(c, "status"); v != nil {
+		status := ports.TaskStatus(strings.ToUpper(*v))
+		if !status.Valid() {
+			return ports.TaskListQuery{}, task.ErrInvalidStatus
+		}
+		query.Status = &status
+	}
+
+	if query.DueBefore, err = queryDate(c, "due_before"); err != nil {
+		return ports.TaskListQuery{}, err
+	}
+	if query.DueAfter, err = queryDate(c, "due_after"); err != nil {
+		return ports.TaskListQuery{}, err
+	}
+
+	return query, nil
+}
+
 type updateStatusRequest struct {
 	Status ports.TaskStatus `json:"status"`
+	Note   *string          `json:"note"`
 }
 
 func (h *TaskHandler) UpdateStatus(c *fiber.Ctx) error {
@@ -156,8 +209,10 @@ func (h *TaskHandler) UpdateStatus(c *fiber.Ctx) error {
 
 	err := h.service.UpdateStatus(
 		c.Context(),
+		actorFrom(c),
 		publicID,
 		req.Status,
+		req.Note,
 	)
 
 	if err != nil {
@@ -167,7 +222,7 @@ func (h *TaskHandler) UpdateStatus(c *fiber.Ctx) error {
 			"error":   err.Error(),
 		}).Warn("failed to update task status")
 
-		return c.Status(400).JSON(fiber.Map{
+		return c.Status(errorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -182,6 +237,27 @@ func (h *TaskHandler) UpdateStatus(c *fiber.Ctx) error {
 	})
 }
 
+func (h *TaskHandler) History(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+	userID := c.Locals("user_id").(string)
+
+	result, err := h.service.History(c.Context(), actorFrom(c), publicID)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"task_id": publicID,
+			"error":   err.Error(),
+		}).Warn("failed to get task history")
+
+		return c.Status(errorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(result)
+}
+
 func (h *TaskHandler) Delete(c *fiber.Ctx) error {
 
 	publicID := c.Params("public_id")
@@ -192,7 +268,7 @@ func (h *TaskHandler) Delete(c *fiber.Ctx) error {
 		"task_id": publicID,
 	}).Info("delete task request")
 
-	err := h.service.Delete(c.Context(), publicID)
+	err := h.service.Delete(c.Context(), actorFrom(c), publicID)
 	if err != nil {
 		h.log.WithFields(logrus.Fields{
 			"user_id": userID,
@@ -200,7 +276,7 @@ func (h *TaskHandler) Delete(c *fiber.Ctx) error {
 			"error":   err.Error(),
 		}).Warn("failed to delete task")
 
-		return c.Status(400).JSON(fiber.Map{
+		return c.Status(errorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"time"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TaskTemplateHandler struct {
+	log     *logrus.Logger
+	service *application.TaskTemplateService
+}
+
+func NewTaskTemplateHandler(
+	log *logrus.Logger,
+	s *application.TaskTemplateService,
+) *TaskTemplateHandler {
+	return &TaskTemplateHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type taskTemplateRequest struct {
+	Title             string  `json:"title"`
+	Description       *string `json:"description"`
+	ZookeeperPublicID string  `json:"zookeeper_public_id"`
+	AnimalPublicID    *string `json:"animal_public_id"`
+	Recurrence        string  `json:"recurrence"`
+	StartsOn          *string `json:"starts_on"`
+	EndsOn            *string `json:"ends_on"`
+	LeadDays          *int    `json:"lead_days"`
+	Active            *bool   `json:"active"`
+}
+
+// toInput fills the defaults: starting today, a week of lead time and
+// active.
+func (r taskTemplateRequest) toInput() (ports.TaskTemplateInput, error) {
+	input := ports.TaskTemplateInput{
+		Title:             r.Title,
+		Description:       r.Description,
+		ZookeeperPublicID: r.ZookeeperPublicID,
+		AnimalPublicID:    r.AnimalPublicID,
+		Recurrence:        r.Recurrence,
+		LeadDays:          7,
+		Active:            true,
+	}
+
+	startsOn, err := utils.ParseDate(r.StartsOn)
+	if err != nil {
+		return input, err
+	}
+	if startsOn == nil {
+		today, _ := time.Parse(utils.DateLayout, time.Now().Format(utils.DateLayout))
+		startsOn = &today
+	}
+	input.StartsOn = *startsOn
+
+	input.EndsOn, err = utils.ParseDate(r.EndsOn)
+	if err != nil {
+		return input, err
+	}
+
+	if r.LeadDays != nil {
+		input.LeadDays = *r.LeadDays
+	}
+	if r.Active != nil {
+		input.Active = *r.Active
+	}
+
+	return input, nil
+}
+
+func (h *TaskTemplateHandler) Create(c *fiber.Ctx) error {
+
+	userID := c.Locals("user_id").(string)
+
+	var req taskTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithFields(logrus.Fields{
+			"user_id": userID,
+		}).Warn("invalid create task template request body")
+
+		return c.Status(400).JSON(fiber.Map{
+			"error": "invalid body",
+		})
+	}
+
+	input, err := req.toInput()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	publicID, err := h.service.Create(c.Context(), actorFrom(c), input)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Warn("failed to create task template")
+
+		return c.Status(errorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"template_id": publicID,
+	}).Info("task template created successfully")
+
+	return c.Status(201).JSON(fiber.Map{
+		"public_id": publicID,
+	})
+}
+
+func (h *TaskTemplateHandler) List(c *fiber.Ctx) error {
+
+	userID := c.Locals("user_id").(string)
+
+	result, err := h.service.List(c.Context(), actorFrom(c))
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("failed to list task templates")
+
+		return c.Status(500).JSON(fiber.Map{
+			"error": "internal error",
+		})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *TaskTemplateHandler) Get(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	result, err := h.service.Get(c.Context(), actorFrom(c), publicID)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *TaskTemplateHandler) Update(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+	userID := c.Locals("user_id").(string)
+
+	var req taskTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithFields(logrus.Fields{
+			"user_id":     userID,
+			"template_id": publicID,
+		}).Warn("invalid update task template request body")
+
+		return c.Status(400).JSON(fiber.Map{
+			"error": "invalid body",
+		})
+	}
+
+	input, err := req.toInput()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.service.Update(c.Context(), actorFrom(c), publicID, input)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"user_id":     userID,
+			"template_id": publicID,
+			"error":       err.Error(),
+		}).Warn("failed to update task template")
+
+		return c.Status(errorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "task template updated successfully",
+	})
+}
+
+func (h *TaskTemplateHandler) Delete(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+	userID := c.Locals("user_id").(string)
+
+	err := h.service.Delete(c.Context(), actorFrom(c), publicID)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"user_id":     userID,
+			"template_id": publicID,
+			"error":       err.Error(),
+		}).Warn("failed to delete task template")
+
+		return c.Status(errorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"template_id": publicID,
+	}).Info("task template deleted successfully")
+
+	return c.SendStatus(204)
+}
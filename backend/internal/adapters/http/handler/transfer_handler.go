@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"time"
+	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TransferHandler struct {
+	log     *logrus.Logger
+	service *application.TransferService
+}
+
+func NewTransferHandler(
+	log *logrus.Logger,
+	s *application.TransferService,
+) *TransferHandler {
+	return &TransferHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type transferRequest struct {
+	ToCagePublicID string     `json:"to_cage_public_id"`
+	Reason         string     `json:"reason"`
+	TransferredAt  *time.Time `json:"transferred_at"`
+	OverrideReason *string    `json:"override_reason"`
+}
+
+func (h *TransferHandler) Transfer(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	var req transferRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("animal_id", animalID).Warn("invalid transfer request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.Transfer(c.Context(), actorFrom(c), ports.TransferInput{
+		AnimalPublicID: animalID,
+		ToCagePublicID: req.ToCagePublicID,
+		Reason:         req.Reason,
+		TransferredAt:  req.TransferredAt,
+	}, req.OverrideReason)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"animal_id": animalID,
+			"to_cage":   req.ToCagePublicID,
+			"error":     err.Error(),
+		}).Warn("failed to transfer animal")
+
+		return c.Status(errorStatus(err)).JSON(errorBody(err))
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"animal_id": animalID,
+		"from_cage": result.From.CagePublicID,
+		"to_cage":   result.To.CagePublicID,
+	}).Info("animal transferred successfully")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *TransferHandler) History(c *fiber.Ctx) error {
+
+	animalID := c.Params("public_id")
+
+	result, err := h.service.History(c.Context(), actorFrom(c), animalID)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *TransferHandler) Occupants(c *fiber.Ctx) error {
+
+	cageID := c.Params("public_id")
+
+	from, to, err := dateRange(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.Occupants(c.Context(), actorFrom(c), cageID, from, to)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"wit-leisure-park/backend/internal/application"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ZoneHandler struct {
+	log     *logrus.Logger
+	service *application.ZoneService
+}
+
+func NewZoneHandler(
+	log *logrus.Logger,
+	s *application.ZoneService,
+) *ZoneHandler {
+	return &ZoneHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+type zoneRequest struct {
+	Code            string  `json:"code"`
+	Name            string  `json:"name"`
+	Description     *string `json:"description"`
+	ManagerPublicID *string `json:"manager_public_id"`
+}
+
+func (r zoneRequest) toService() application.ZoneRequest {
+	return application.ZoneRequest{
+		Code:            r.Code,
+		Name:            r.Name,
+		Description:     r.Description,
+		ManagerPublicID: r.ManagerPublicID,
+	}
+}
+
+type areaRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+func (r areaRequest) toService() application.AreaRequest {
+	return application.AreaRequest{
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+func (h *ZoneHandler) Create(c *fiber.Ctx) error {
+
+	var req zoneRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.Warn("invalid zone request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.Create(c.Context(), actorFrom(c), req.toService())
+	if err != nil {
+		return h.fail(c, req.Code, "create zone", err)
+	}
+
+	h.log.WithField("zone_id", result.PublicID).Info("zone created")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *ZoneHandler) List(c *fiber.Ctx) error {
+
+	result, err := h.service.List(c.Context(), actorFrom(c))
+	if err != nil {
+		h.log.Error("failed to list zones: ", err)
+		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *ZoneHandler) FindByID(c *fiber.Ctx) error {
+
+	result, err := h.service.FindByID(c.Context(), actorFrom(c), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *ZoneHandler) Update(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	var req zoneRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("zone_id", publicID).Warn("invalid zone request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.Update(c.Context(), actorFrom(c), publicID, req.toService())
+	if err != nil {
+		return h.fail(c, publicID, "update zone", err)
+	}
+
+	h.log.WithField("zone_id", publicID).Info("zone updated")
+
+	return c.JSON(result)
+}
+
+func (h *ZoneHandler) Delete(c *fiber.Ctx) error {
+
+	publicID := c.Params("public_id")
+
+	if err := h.service.Delete(c.Context(), actorFrom(c), publicID); err != nil {
+		return h.fail(c, publicID, "delete zone", err)
+	}
+
+	h.log.WithField("zone_id", publicID).Info("zone deleted")
+
+	return c.SendStatus(204)
+}
+
+func (h *ZoneHandler) CreateArea(c *fiber.Ctx) error {
+
+	zoneID := c.Params("public_id")
+
+	var req areaRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("zone_id", zoneID).Warn("invalid area request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	result, err := h.service.CreateArea(c.Context(), actorFrom(c), zoneID, req.toService())
+	if err != nil {
+		return h.fail(c, zoneID, "create area", err)
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"zone_id": zoneID,
+		"area_id": result.PublicID,
+	}).Info("area created")
+
+	return c.Status(201).JSON(result)
+}
+
+func (h *ZoneHandler) ListAreas(c *fiber.Ctx) error {
+
+	result, err := h.service.ListAreas(c.Context(), actorFrom(c), c.Params("public_id"))
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(result)
+}
+
+func (h *ZoneHandler) UpdateArea(c *fiber.Ctx) error {
+
+	areaID := c.Params("area_id")
+
+	var req areaRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithField("area_id", areaID).Warn("invalid area request body")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid body"})
+	}
+
+	err := h.service.UpdateArea(c.Context(), actorFrom(c), c.Params("public_id"), areaID, req.toService())
+	if err != nil {
+		return h.fail(c, areaID, "update area", err)
+	}
+
+	h.log.WithField("area_id", areaID).Info("area updated")
+
+	return c.JSON(fiber.Map{
+		"message": "area updated successfully",
+	})
+}
+
+func (h *ZoneHandler) DeleteArea(c *fiber.Ctx) error {
+
+	areaID := c.Params("area_id")
+
+	if err := h.service.DeleteArea(c.Context(), actorFrom(c), c.Params("public_id"), areaID); err != nil {
+		return h.fail(c, areaID, "delete area", err)
+	}
+
+	h.log.WithField("area_id", areaID).Info("area deleted")
+
+	return c.SendStatus(204)
+}
+
+func (h *ZoneHandler) fail(c *fiber.Ctx, publicID, action string, err error) error {
+	h.log.WithFields(logrus.Fields{
+		"public_id": publicID,
+		"error":     err.Error(),
+	}).Warn("failed to " + action)
+
+	return c.Status(errorStatus(err)).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
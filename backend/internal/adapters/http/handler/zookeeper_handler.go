@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"wit-leisure-park/backend/internal/application"
+	"wit-leisure-park/backend/internal/ports"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
@@ -52,7 +54,19 @@ func (h *ZookeeperHandler) Create(c *fiber.Ctx) error {
 }
 
 func (h *ZookeeperHandler) List(c *fiber.Ctx) error {
-	result, err := h.service.List(c.Context())
+	params, err := parseListParams(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.service.List(c.Context(), ports.ZookeeperListQuery{
+		ListParams:      params,
+		ManagerPublicID: queryString(c, "manager_public_id"),
+		Search:          queryString(c, "q"),
+	})
+	if errors.Is(err, ports.ErrInvalidSort) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
 	if err != nil {
 		h.log.Error(err)
 		return c.Status(500).JSON(fiber.Map{"error": "internal error"})
@@ -1,13 +1,20 @@
 package middleware
 
 import (
+	"context"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func JWT(secret string) fiber.Handler {
+// SessionValidator reports whether the session an access token was issued
+// for is still active.
+type SessionValidator interface {
+	IsSessionActive(ctx context.Context, sessionPublicID string) (bool, error)
+}
+
+func JWT(secret string, sessions SessionValidator) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
@@ -18,7 +25,7 @@ func JWT(secret string) fiber.Handler {
 
 		token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
 			return []byte(secret), nil
-		})
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 		if err != nil || !token.Valid {
 			return c.SendStatus(fiber.StatusUnauthorized)
@@ -26,8 +33,29 @@ func JWT(secret string) fiber.Handler {
 
 		claims := token.Claims.(jwt.MapClaims)
 
+		// mfa pending tokens are signed with the same secret but must never
+		// grant API access
+		if claims["typ"] != "access" {
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+
+		sessionID, ok := claims["sid"].(string)
+		if !ok || sessionID == "" {
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+
+		active, err := sessions.IsSessionActive(c.Context(), sessionID)
+		if err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		if !active {
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+
 		c.Locals("user_id", claims["sub"])
 		c.Locals("role", claims["role"])
+		c.Locals("session_id", sessionID)
+		c.Locals("must_change_password", claims["mcp"] == true)
 
 		return c.Next()
 	}
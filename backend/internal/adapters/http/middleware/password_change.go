@@ -0,0 +1,24 @@
+package middleware
+
+import "github.com/gofiber/fiber/v2"
+
+// RequirePasswordChanged blocks users flagged for a forced password change
+// from everything except the given paths until they pick a new password.
+func RequirePasswordChanged(allowedPaths ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		if mustChange, _ := c.Locals("must_change_password").(bool); !mustChange {
+			return c.Next()
+		}
+
+		for _, path := range allowedPaths {
+			if c.Path() == path {
+				return c.Next()
+			}
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "password change required",
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"wit-leisure-park/backend/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PermissionChecker resolves whether a role has been granted a permission.
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, role string, permission domain.Permission) (bool, error)
+}
+
+func RequirePermission(checker PermissionChecker, permission domain.Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		role, ok := c.Locals("role").(string)
+		if !ok {
+			return c.SendStatus(fiber.StatusForbidden)
+		}
+
+		allowed, err := checker.HasPermission(c.Context(), role, permission)
+		if err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		if !allowed {
+			return c.SendStatus(fiber.StatusForbidden)
+		}
+
+		return c.Next()
+	}
+}
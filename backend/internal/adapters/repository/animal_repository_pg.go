@@ -3,10 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	"wit-leisure-park/backend/internal/domain/animal"
 	"wit-leisure-park/backend/internal/ports"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -20,9 +23,10 @@ func NewAnimalRepository(db *pgxpool.Pool) ports.AnimalRepository {
 
 func (r *animalRepository) Create(
 	ctx context.Context,
-	publicID, name, species string,
+	publicID, name, speciesPublicID string,
 	cagePublicID string,
 	dateOfBirth *time.Time,
+	guard ports.PlacementGuard,
 ) (string, error) {
 
 	tx, err := r.db.Begin(ctx)
@@ -31,56 +35,157 @@ func (r *animalRepository) Create(
 	}
 	defer tx.Rollback(ctx)
 
-	var cageID int64
-	err = tx.QueryRow(ctx,
-		`SELECT id FROM cages WHERE public_id=$1`,
-		cagePublicID,
-	).Scan(&cageID)
+	speciesID, _, err := speciesRef(ctx, tx, speciesPublicID)
 	if err != nil {
 		return "", err
 	}
 
-	_, err = tx.Exec(ctx, `
-		INSERT INTO animals (public_id, name, species, cage_id, date_of_birth)
+	cageID, err := reserveCageSlot(ctx, tx, cagePublicID, speciesID, nil)
+	if err != nil {
+		return "", err
+	}
+	override, err := guardPlacement(ctx, tx, guard, speciesID, cageID, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var animalID int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO animals (public_id, name, species_id, cage_id, date_of_birth)
 		VALUES ($1,$2,$3,$4,$5)
-	`, publicID, name, species, cageID, dateOfBirth)
+		RETURNING id
+	`, publicID, name, speciesID, cageID, dateOfBirth).Scan(&animalID)
 
 	if err != nil {
 		return "", err
 	}
 
+	if err := openCageAssignment(ctx, tx, animalID, cageID, nil); err != nil {
+		return "", err
+	}
+	if err := recordOverride(ctx, tx, override, animalID, cageID); err != nil {
+		return "", err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO animal_status_history (animal_id, from_status, to_status, effective_at)
+		VALUES ($1, NULL, 'ACTIVE', NOW())
+	`, animalID)
+	if err != nil {
+		return "", err
+	}
+
 	return publicID, tx.Commit(ctx)
 }
 
-func (r *animalRepository) List(ctx context.Context) ([]ports.AnimalDTO, error) {
+var animalSortColumns = map[string]string{
+	"name":          "a.name",
+	"species":       "s.common_name",
+	"date_of_birth": "a.date_of_birth",
+	"cage":          "c.code",
+}
 
-	rows, err := r.db.Query(ctx, `
-		SELECT a.public_id, a.name, a.species, c.public_id, a.date_of_birth
-		FROM animals a
-		JOIN cages c ON c.id = a.cage_id
-	`)
+const animalSelect = `
+	SELECT a.public_id, a.name, s.public_id, s.common_name, c.public_id,
+	       a.date_of_birth, a.status, a.sex, dam.public_id, sire.public_id,
+	       a.microchip_id, a.studbook_id, z.public_id
+`
+
+const animalFrom = `
+	FROM animals a
+	JOIN species s ON s.id = a.species_id
+	JOIN cages c ON c.id = a.cage_id
+	LEFT JOIN animals dam ON dam.id = a.dam_id
+	LEFT JOIN animals sire ON sire.id = a.sire_id
+	LEFT JOIN areas ar ON ar.id = c.area_id
+	LEFT JOIN zones z ON z.id = ar.zone_id
+`
+
+func scanAnimal(row pgx.Row) (ports.AnimalDTO, error) {
+	var a ports.AnimalDTO
+	err := row.Scan(
+		&a.PublicID,
+		&a.Name,
+		&a.SpeciesPublicID,
+		&a.Species,
+		&a.CageID,
+		&a.DateOfBirth,
+		&a.Status,
+		&a.Sex,
+		&a.DamPublicID,
+		&a.SirePublicID,
+		&a.MicrochipID,
+		&a.StudbookID,
+		&a.ZonePublicID,
+	)
+	return a, err
+}
+
+func (r *animalRepository) List(
+	ctx context.Context,
+	query ports.AnimalListQuery,
+) (ports.Page[ports.AnimalDTO], error) {
+
+	var f listFilter
+	if query.Species != nil {
+		f.add("(LOWER(s.common_name) = LOWER(?) OR LOWER(s.scientific_name) = LOWER(?))", *query.Species)
+	}
+	if query.SpeciesPublicID != nil {
+		f.add("s.public_id = ?", *query.SpeciesPublicID)
+	}
+	if query.CagePublicID != nil {
+		f.add("c.public_id = ?", *query.CagePublicID)
+	}
+	if query.ZonePublicID != nil {
+		f.add("z.public_id = ?", *query.ZonePublicID)
+	}
+	if query.ScopeZonePublicIDs != nil {
+		f.add("z.public_id = ANY(?)", query.ScopeZonePublicIDs)
+	}
+	if query.Search != nil {
+		f.add("(a.name ILIKE '%' || ? || '%' OR a.microchip_id = ? OR a.studbook_id = ?)", *query.Search)
+	}
+
+	statuses := query.Statuses
+	if len(statuses) == 0 {
+		statuses = animal.ActiveStatuses()
+	}
+	names := make([]string, len(statuses))
+	for i, st := range statuses {
+		names[i] = string(st)
+	}
+	f.add("a.status::text = ANY(?)", names)
+
+	order, err := orderBy(query.Sort, animalSortColumns, "name", "a.id")
 	if err != nil {
-		return nil, err
+		return ports.Page[ports.AnimalDTO]{}, err
+	}
+
+	from := animalFrom + f.where()
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*)`+from, f.args...).Scan(&total); err != nil {
+		return ports.Page[ports.AnimalDTO]{}, err
+	}
+
+	limit, args := f.page(query.ListParams)
+	rows, err := r.db.Query(ctx, animalSelect+from+order+limit, args...)
+	if err != nil {
+		return ports.Page[ports.AnimalDTO]{}, err
 	}
 	defer rows.Close()
 
 	result := make([]ports.AnimalDTO, 0)
 
 	for rows.Next() {
-		var a ports.AnimalDTO
-		if err := rows.Scan(
-			&a.PublicID,
-			&a.Name,
-			&a.Species,
-			&a.CageID,
-			&a.DateOfBirth,
-		); err != nil {
-			return nil, err
+		a, err := scanAnimal(rows)
+		if err != nil {
+			return ports.Page[ports.AnimalDTO]{}, err
 		}
 		result = append(result, a)
 	}
 
-	return result, nil
+	return ports.NewPage(result, total, query.ListParams), rows.Err()
 }
 
 func (r *animalRepository) FindByID(
@@ -88,21 +193,12 @@ func (r *animalRepository) FindByID(
 	publicID string,
 ) (ports.AnimalDTO, error) {
 
-	var a ports.AnimalDTO
-
-	err := r.db.QueryRow(ctx, `
-		SELECT a.public_id, a.name, a.species, c.public_id, a.date_of_birth
-		FROM animals a
-		JOIN cages c ON c.id = a.cage_id
+	a, err := scanAnimal(r.db.QueryRow(ctx, animalSelect+animalFrom+`
 		WHERE a.public_id=$1
-	`, publicID).Scan(
-		&a.PublicID,
-		&a.Name,
-		&a.Species,
-		&a.CageID,
-		&a.DateOfBirth,
-	)
-
+	`, publicID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.AnimalDTO{}, ports.ErrNotFound
+	}
 	if err != nil {
 		return ports.AnimalDTO{}, err
 	}
@@ -112,8 +208,9 @@ func (r *animalRepository) FindByID(
 
 func (r *animalRepository) Update(
 	ctx context.Context,
-	publicID, name, species, cagePublicID string,
+	publicID, name, speciesPublicID, cagePublicID string,
 	dateOfBirth *time.Time,
+	guard ports.PlacementGuard,
 ) error {
 
 	tx, err := r.db.Begin(ctx)
@@ -122,52 +219,306 @@ func (r *animalRepository) Update(
 	}
 	defer tx.Rollback(ctx)
 
-	var cageID int64
+	var animalID, currentCageID int64
+	var status ports.AnimalStatus
 	err = tx.QueryRow(ctx,
-		`SELECT id FROM cages WHERE public_id=$1`,
-		cagePublicID,
-	).Scan(&cageID)
+		`SELECT id, cage_id, status FROM animals WHERE public_id=$1 FOR UPDATE`,
+		publicID,
+	).Scan(&animalID, &currentCageID, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	speciesID, _, err := speciesRef(ctx, tx, speciesPublicID)
+	if err != nil {
+		return err
+	}
+
+	var cageID int64
+	if status.InPark() {
+		cageID, err = reserveCageSlot(ctx, tx, cagePublicID, speciesID, &animalID)
+		if err != nil {
+			return err
+		}
+
+		// keep the residence history when the cage is changed by an edit
+		if cageID != currentCageID {
+			if err := ensureNotQuarantined(ctx, tx, animalID); err != nil {
+				return err
+			}
+			if _, _, err := moveAnimal(ctx, tx, animalID, cageID, nil, nil, nil); err != nil {
+				return err
+			}
+		}
+	} else {
+		err = tx.QueryRow(ctx,
+			`SELECT id FROM cages WHERE public_id=$1`,
+			cagePublicID,
+		).Scan(&cageID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: cage %s", ports.ErrNotFound, cagePublicID)
+		}
+		if err != nil {
+			return err
+		}
+		if cageID != currentCageID {
+			return fmt.Errorf("animal is %s and not in a cage, it cannot be moved", status)
+		}
+	}
+
+	override, err := guardPlacement(ctx, tx, guard, speciesID, cageID, &animalID)
 	if err != nil {
 		return err
 	}
 
-	cmd, err := tx.Exec(ctx, `
+	_, err = tx.Exec(ctx, `
 		UPDATE animals
 		SET name=$1,
-		    species=$2,
+		    species_id=$2,
 		    cage_id=$3,
 		    date_of_birth=$4
-		WHERE public_id=$5
-	`, name, species, cageID, dateOfBirth, publicID)
+		WHERE id=$5
+	`, name, speciesID, cageID, dateOfBirth, animalID)
 
 	if err != nil {
 		return err
 	}
 
-	if cmd.RowsAffected() == 0 {
-		return errors.New("animal not found")
+	if err := recordOverride(ctx, tx, override, animalID, cageID); err != nil {
+		return err
 	}
 
 	return tx.Commit(ctx)
 }
 
+// Delete removes an animal entered by mistake. Once anything beyond its
+// creation has been recorded the history must stay, and the animal is
+// retired through a status change instead.
 func (r *animalRepository) Delete(
 	ctx context.Context,
 	publicID string,
 ) error {
 
-	cmd, err := r.db.Exec(ctx,
-		`DELETE FROM animals WHERE public_id=$1`,
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var animalID int64
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM animals WHERE public_id=$1 FOR UPDATE`,
 		publicID,
-	)
+	).Scan(&animalID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var hasHistory bool
+	err = tx.QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM animal_status_history WHERE animal_id = $1) > 1
+		    OR (SELECT COUNT(*) FROM cage_assignments WHERE animal_id = $1) > 1
+		    OR EXISTS (SELECT 1 FROM vet_visits WHERE animal_id = $1)
+		    OR EXISTS (SELECT 1 FROM diagnoses WHERE animal_id = $1)
+		    OR EXISTS (SELECT 1 FROM prescriptions WHERE animal_id = $1)
+		    OR EXISTS (SELECT 1 FROM vaccinations WHERE animal_id = $1)
+		    OR EXISTS (SELECT 1 FROM diet_plans WHERE animal_id = $1)
+		    OR EXISTS (SELECT 1 FROM feeding_logs WHERE animal_id = $1)
+		    OR EXISTS (SELECT 1 FROM animal_measurements WHERE animal_id = $1)
+		    OR EXISTS (SELECT 1 FROM compatibility_overrides WHERE animal_id = $1)
+		    OR EXISTS (SELECT 1 FROM quarantines WHERE animal_id = $1)
+		    OR EXISTS (SELECT 1 FROM tasks WHERE animal_id = $1)
+		    OR EXISTS (SELECT 1 FROM task_templates WHERE animal_id = $1)
+		    OR EXISTS (SELECT 1 FROM breeding_pairings WHERE $1 IN (dam_id, sire_id))
+		    OR EXISTS (SELECT 1 FROM pregnancies WHERE $1 IN (dam_id, sire_id))
+		    OR EXISTS (SELECT 1 FROM births WHERE $1 IN (dam_id, sire_id))
+		    OR EXISTS (SELECT 1 FROM animals WHERE $1 IN (dam_id, sire_id))
+	`, animalID).Scan(&hasHistory)
+	if err != nil {
+		return err
+	}
+	if hasHistory {
+		return fmt.Errorf(
+			"%w: animal has recorded history, change its status with POST /api/animals/%s/status instead",
+			ports.ErrInUse, publicID,
+		)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM animals WHERE id=$1`, animalID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *animalRepository) ChangeStatus(
+	ctx context.Context,
+	change ports.AnimalStatusChange,
+	guard ports.PlacementGuard,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var animalID, speciesID int64
+	var cagePublicID string
+	var status ports.AnimalStatus
+	err = tx.QueryRow(ctx, `
+		SELECT a.id, a.species_id, c.public_id, a.status
+		FROM animals a
+		JOIN cages c ON c.id = a.cage_id
+		WHERE a.public_id = $1
+		FOR UPDATE OF a
+	`, change.PublicID).Scan(&animalID, &speciesID, &cagePublicID, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != change.From {
+		return ports.ErrConflict
+	}
+
+	var quarantineID *int64
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM quarantines WHERE animal_id=$1 AND status='OPEN' FOR UPDATE`,
+		animalID,
+	).Scan(&quarantineID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if quarantineID != nil {
+		if change.To.InPark() {
+			return fmt.Errorf("%w: clear the quarantine instead", ports.ErrQuarantined)
+		}
+
+		// leaving the park ends the quarantine without clearance
+		_, err = tx.Exec(ctx, `
+			UPDATE quarantines
+			SET status = 'CLOSED',
+			    ended_at = COALESCE($2::timestamp, NOW()),
+			    ended_by = (SELECT id FROM users WHERE public_id=$3),
+			    outcome_notes = $4
+			WHERE id = $1
+		`, *quarantineID, change.EffectiveAt, change.ActorPublicID, change.Reason)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case change.From.InPark() && !change.To.InPark():
+		if err := closeCageAssignment(ctx, tx, animalID, change.EffectiveAt); err != nil {
+			return err
+		}
+	case !change.From.InPark() && change.To.InPark():
+		cageID, err := reserveCageSlot(ctx, tx, cagePublicID, speciesID, &animalID)
+		if err != nil {
+			return err
+		}
+		override, err := guardPlacement(ctx, tx, guard, speciesID, cageID, &animalID)
+		if err != nil {
+			return err
+		}
+		if err := openCageAssignment(ctx, tx, animalID, cageID, change.EffectiveAt); err != nil {
+			return err
+		}
+		if err := recordOverride(ctx, tx, override, animalID, cageID); err != nil {
+			return err
+		}
+	}
 
+	if err := setAnimalStatus(ctx, tx, animalID, change); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// setAnimalStatus updates the status and records the change in the status
+// history. A nil EffectiveAt uses the database clock.
+func setAnimalStatus(ctx context.Context, tx pgx.Tx, animalID int64, change ports.AnimalStatusChange) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE animals SET status=$1 WHERE id=$2`,
+		change.To, animalID,
+	)
 	if err != nil {
 		return err
 	}
 
-	if cmd.RowsAffected() == 0 {
-		return errors.New("animal not found")
+	_, err = tx.Exec(ctx, `
+		INSERT INTO animal_status_history
+		(animal_id, from_status, to_status, reason, effective_at, actor_id)
+		VALUES ($1, $2, $3, $4, COALESCE($5::timestamp, NOW()),
+		        (SELECT id FROM users WHERE public_id=$6))
+	`,
+		animalID,
+		change.From,
+		change.To,
+		change.Reason,
+		change.EffectiveAt,
+		change.ActorPublicID,
+	)
+
+	return err
+}
+
+func (r *animalRepository) ListStatusHistory(
+	ctx context.Context,
+	publicID string,
+) ([]ports.AnimalStatusHistoryDTO, error) {
+
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM animals WHERE public_id=$1)`,
+		publicID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ports.ErrNotFound
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT h.from_status, h.to_status, h.reason, h.effective_at, u.public_id, u.username
+		FROM animal_status_history h
+		JOIN animals a ON a.id = h.animal_id
+		LEFT JOIN users u ON u.id = h.actor_id
+		WHERE a.public_id = $1
+		ORDER BY h.effective_at, h.id
+	`, publicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []ports.AnimalStatusHistoryDTO{}
+
+	for rows.Next() {
+		var h ports.AnimalStatusHistoryDTO
+		if err := rows.Scan(
+			&h.FromStatus,
+			&h.ToStatus,
+			&h.Reason,
+			&h.EffectiveAt,
+			&h.ActorPublicID,
+			&h.ActorUsername,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, h)
 	}
 
-	return nil
+	return result, rows.Err()
 }
@@ -0,0 +1,755 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type breedingRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBreedingRepository(db *pgxpool.Pool) ports.BreedingRepository {
+	return &breedingRepository{db: db}
+}
+
+// animalRef resolves an optional animal public id, naming its role in the
+// error when it does not exist.
+func animalRef(ctx context.Context, tx pgx.Tx, role string, publicID *string) (*int64, error) {
+	if publicID == nil {
+		return nil, nil
+	}
+
+	var id int64
+	err := tx.QueryRow(ctx,
+		`SELECT id FROM animals WHERE public_id=$1`,
+		*publicID,
+	).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s %s", ports.ErrNotFound, role, *publicID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+// ensureIdentifiersFree checks that no other animal carries the microchip
+// or studbook id.
+func ensureIdentifiersFree(
+	ctx context.Context,
+	tx pgx.Tx,
+	animalID *int64,
+	microchipID, studbookID *string,
+) error {
+
+	var microchipTaken, studbookTaken bool
+	err := tx.QueryRow(ctx, `
+		SELECT
+			EXISTS (SELECT 1 FROM animals
+			        WHERE microchip_id = $2 AND ($1::bigint IS NULL OR id <> $1)),
+			EXISTS (SELECT 1 FROM animals
+			        WHERE studbook_id = $3 AND ($1::bigint IS NULL OR id <> $1))
+	`, animalID, microchipID, studbookID).Scan(&microchipTaken, &studbookTaken)
+	if err != nil {
+		return err
+	}
+
+	if microchipTaken {
+		return fmt.Errorf("%w: microchip_id %s", ports.ErrDuplicate, *microchipID)
+	}
+	if studbookTaken {
+		return fmt.Errorf("%w: studbook_id %s", ports.ErrDuplicate, *studbookID)
+	}
+
+	return nil
+}
+
+func (r *breedingRepository) SetStudbook(
+	ctx context.Context,
+	input ports.StudbookInput,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var animalID int64
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM animals WHERE public_id=$1 FOR UPDATE`,
+		input.AnimalPublicID,
+	).Scan(&animalID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	damID, err := animalRef(ctx, tx, "dam", input.DamPublicID)
+	if err != nil {
+		return err
+	}
+	sireID, err := animalRef(ctx, tx, "sire", input.SirePublicID)
+	if err != nil {
+		return err
+	}
+
+	// a parent may not descend from the animal, which would make the
+	// pedigree circular
+	var circular bool
+	err = tx.QueryRow(ctx, `
+		WITH RECURSIVE descendants AS (
+			SELECT id FROM animals WHERE dam_id = $1 OR sire_id = $1
+			UNION
+			SELECT a.id
+			FROM animals a
+			JOIN descendants d ON a.dam_id = d.id OR a.sire_id = d.id
+		)
+		SELECT EXISTS (SELECT 1 FROM descendants WHERE id = $2 OR id = $3)
+	`, animalID, damID, sireID).Scan(&circular)
+	if err != nil {
+		return err
+	}
+	if circular {
+		return errors.New("a parent cannot be a descendant of the animal")
+	}
+
+	if err := ensureIdentifiersFree(ctx, tx, &animalID, input.MicrochipID, input.StudbookID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE animals
+		SET sex = $2,
+		    dam_id = $3,
+		    sire_id = $4,
+		    microchip_id = $5,
+		    studbook_id = $6
+		WHERE id = $1
+	`,
+		animalID,
+		input.Sex,
+		damID,
+		sireID,
+		input.MicrochipID,
+		input.StudbookID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *breedingRepository) Ancestors(
+	ctx context.Context,
+	publicIDs []string,
+	generations int,
+) ([]ports.PedigreeEntry, error) {
+
+	rows, err := r.db.Query(ctx, `
+		WITH RECURSIVE tree AS (
+			SELECT id, 0 AS depth
+			FROM animals
+			WHERE public_id::text = ANY($1)
+			UNION
+			SELECT p.id, t.depth + 1
+			FROM tree t
+			JOIN animals child ON child.id = t.id
+			JOIN animals p ON p.id = child.dam_id OR p.id = child.sire_id
+			WHERE t.depth < $2
+		)
+		SELECT a.public_id, a.name, s.public_id, a.sex, a.date_of_birth,
+		       a.studbook_id, a.status, dam.public_id, sire.public_id
+		FROM animals a
+		JOIN species s ON s.id = a.species_id
+		LEFT JOIN animals dam ON dam.id = a.dam_id
+		LEFT JOIN animals sire ON sire.id = a.sire_id
+		WHERE a.id IN (SELECT id FROM tree)
+		ORDER BY a.id
+	`, publicIDs, generations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.PedigreeEntry, 0)
+	for rows.Next() {
+		var e ports.PedigreeEntry
+		if err := rows.Scan(
+			&e.PublicID,
+			&e.Name,
+			&e.SpeciesPublicID,
+			&e.Sex,
+			&e.DateOfBirth,
+			&e.StudbookID,
+			&e.Status,
+			&e.DamPublicID,
+			&e.SirePublicID,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, e)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *breedingRepository) CreatePairing(
+	ctx context.Context,
+	input ports.PairingInput,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		INSERT INTO breeding_pairings
+		(public_id, dam_id, sire_id, paired_on, inbreeding, notes, created_by)
+		SELECT $1, d.id, s.id, $4, $5, $6, (SELECT id FROM users WHERE public_id = $7)
+		FROM animals d, animals s
+		WHERE d.public_id = $2 AND s.public_id = $3
+	`,
+		input.PublicID,
+		input.DamPublicID,
+		input.SirePublicID,
+		input.PairedOn,
+		input.Inbreeding,
+		input.Notes,
+		input.CreatedByPublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+const pairingSelect = `
+	SELECT p.public_id, d.public_id, d.name, s.public_id, s.name,
+	       p.paired_on, p.separated_on, p.inbreeding::float8, p.notes, u.public_id
+	FROM breeding_pairings p
+	JOIN animals d ON d.id = p.dam_id
+	JOIN animals s ON s.id = p.sire_id
+	LEFT JOIN users u ON u.id = p.created_by
+`
+
+func scanPairing(row pgx.Row) (ports.PairingDTO, error) {
+	var p ports.PairingDTO
+	err := row.Scan(
+		&p.PublicID,
+		&p.DamPublicID,
+		&p.DamName,
+		&p.SirePublicID,
+		&p.SireName,
+		&p.PairedOn,
+		&p.SeparatedOn,
+		&p.Inbreeding,
+		&p.Notes,
+		&p.CreatedBy,
+	)
+	return p, err
+}
+
+func (r *breedingRepository) ListPairings(
+	ctx context.Context,
+	animalPublicID *string,
+	scope []string,
+) ([]ports.PairingDTO, error) {
+
+	rows, err := r.db.Query(ctx, pairingSelect+`
+		WHERE ($1::uuid IS NULL OR d.public_id = $1 OR s.public_id = $1)
+		  AND ($2::uuid[] IS NULL OR d.cage_id IN (
+			SELECT c.id FROM cages c
+			JOIN areas ar ON ar.id = c.area_id
+			JOIN zones z ON z.id = ar.zone_id
+			WHERE z.public_id = ANY($2)))
+		ORDER BY p.paired_on DESC, p.id DESC
+	`, animalPublicID, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.PairingDTO, 0)
+	for rows.Next() {
+		p, err := scanPairing(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *breedingRepository) FindPairing(
+	ctx context.Context,
+	publicID string,
+) (ports.PairingDTO, error) {
+
+	p, err := scanPairing(r.db.QueryRow(ctx, pairingSelect+`
+		WHERE p.public_id = $1
+	`, publicID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.PairingDTO{}, ports.ErrNotFound
+	}
+
+	return p, err
+}
+
+func (r *breedingRepository) EndPairing(
+	ctx context.Context,
+	publicID string,
+	separatedOn time.Time,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE breeding_pairings
+		SET separated_on = $2
+		WHERE public_id = $1 AND separated_on IS NULL
+	`, publicID, separatedOn)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *breedingRepository) CreatePregnancy(
+	ctx context.Context,
+	input ports.PregnancyInput,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var damID int64
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM animals WHERE public_id=$1 FOR UPDATE`,
+		input.DamPublicID,
+	).Scan(&damID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var ongoing bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pregnancies WHERE dam_id=$1 AND status='ONGOING')`,
+		damID,
+	).Scan(&ongoing)
+	if err != nil {
+		return err
+	}
+	if ongoing {
+		return fmt.Errorf("%w: dam already has an ongoing pregnancy", ports.ErrConflict)
+	}
+
+	sireID, err := animalRef(ctx, tx, "sire", input.SirePublicID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO pregnancies
+		(public_id, dam_id, sire_id, pairing_id, detected_on, expected_due_on, notes, recorded_by)
+		VALUES ($1, $2, $3, (SELECT id FROM breeding_pairings WHERE public_id = $4),
+		        $5, $6, $7, (SELECT id FROM users WHERE public_id = $8))
+	`,
+		input.PublicID,
+		damID,
+		sireID,
+		input.PairingPublicID,
+		input.DetectedOn,
+		input.ExpectedDueOn,
+		input.Notes,
+		input.RecordedByPublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+const pregnancySelect = `
+	SELECT g.public_id, d.public_id, d.name, s.public_id, p.public_id,
+	       g.detected_on, g.expected_due_on, g.status, g.ended_on, g.notes
+	FROM pregnancies g
+	JOIN animals d ON d.id = g.dam_id
+	LEFT JOIN animals s ON s.id = g.sire_id
+	LEFT JOIN breeding_pairings p ON p.id = g.pairing_id
+`
+
+func scanPregnancy(row pgx.Row) (ports.PregnancyDTO, error) {
+	var p ports.PregnancyDTO
+	err := row.Scan(
+		&p.PublicID,
+		&p.DamPublicID,
+		&p.DamName,
+		&p.SirePublicID,
+		&p.PairingPublicID,
+		&p.DetectedOn,
+		&p.ExpectedDueOn,
+		&p.Status,
+		&p.EndedOn,
+		&p.Notes,
+	)
+	return p, err
+}
+
+func (r *breedingRepository) ListPregnancies(
+	ctx context.Context,
+	status *ports.PregnancyStatus,
+	scope []string,
+) ([]ports.PregnancyDTO, error) {
+
+	rows, err := r.db.Query(ctx, pregnancySelect+`
+		WHERE ($1::text IS NULL OR g.status = $1)
+		  AND ($2::uuid[] IS NULL OR d.cage_id IN (
+			SELECT c.id FROM cages c
+			JOIN areas ar ON ar.id = c.area_id
+			JOIN zones z ON z.id = ar.zone_id
+			WHERE z.public_id = ANY($2)))
+		ORDER BY g.expected_due_on NULLS LAST, g.detected_on DESC, g.id DESC
+	`, status, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.PregnancyDTO, 0)
+	for rows.Next() {
+		p, err := scanPregnancy(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *breedingRepository) FindPregnancy(
+	ctx context.Context,
+	publicID string,
+) (ports.PregnancyDTO, error) {
+
+	p, err := scanPregnancy(r.db.QueryRow(ctx, pregnancySelect+`
+		WHERE g.public_id = $1
+	`, publicID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.PregnancyDTO{}, ports.ErrNotFound
+	}
+
+	return p, err
+}
+
+func (r *breedingRepository) EndPregnancy(
+	ctx context.Context,
+	publicID string,
+	status ports.PregnancyStatus,
+	endedOn time.Time,
+	notes *string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE pregnancies
+		SET status = $2,
+		    ended_on = $3,
+		    notes = COALESCE($4, notes)
+		WHERE public_id = $1 AND status = 'ONGOING'
+	`, publicID, status, endedOn, notes)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *breedingRepository) RecordBirth(
+	ctx context.Context,
+	input ports.BirthInput,
+	guard ports.PlacementGuard,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var damID, speciesID int64
+	var damCagePublicID string
+	err = tx.QueryRow(ctx, `
+		SELECT a.id, a.species_id, c.public_id
+		FROM animals a
+		JOIN cages c ON c.id = a.cage_id
+		WHERE a.public_id = $1
+		FOR UPDATE OF a
+	`, input.DamPublicID).Scan(&damID, &speciesID, &damCagePublicID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	sireID, err := animalRef(ctx, tx, "sire", input.SirePublicID)
+	if err != nil {
+		return err
+	}
+
+	var pregnancyID *int64
+	if input.PregnancyPublicID != nil {
+		var id int64
+		err = tx.QueryRow(ctx, `
+			SELECT id FROM pregnancies
+			WHERE public_id = $1 AND dam_id = $2 AND status = 'ONGOING'
+			FOR UPDATE
+		`, *input.PregnancyPublicID, damID).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: pregnancy is not ongoing for this dam", ports.ErrConflict)
+		}
+		if err != nil {
+			return err
+		}
+		pregnancyID = &id
+
+		_, err = tx.Exec(ctx,
+			`UPDATE pregnancies SET status='BORN', ended_on=$2 WHERE id=$1`,
+			id, input.BornOn,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	var birthID int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO births
+		(public_id, dam_id, sire_id, pregnancy_id, born_on, stillborn_count, notes, recorded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT id FROM users WHERE public_id = $8))
+		RETURNING id
+	`,
+		input.PublicID,
+		damID,
+		sireID,
+		pregnancyID,
+		input.BornOn,
+		input.StillbornCount,
+		input.Notes,
+		input.RecordedByPublicID,
+	).Scan(&birthID)
+	if err != nil {
+		return err
+	}
+
+	cagePublicID := damCagePublicID
+	if input.CagePublicID != nil {
+		cagePublicID = *input.CagePublicID
+	}
+
+	// the newborns are checked against the animals already in the cage,
+	// not against each other, and share one approval
+	var override *ports.CompatibilityOverrideInput
+	for i, o := range input.Offspring {
+		if err := ensureIdentifiersFree(ctx, tx, nil, o.MicrochipID, o.StudbookID); err != nil {
+			return err
+		}
+
+		cageID, err := reserveCageSlot(ctx, tx, cagePublicID, speciesID, nil)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			override, err = guardPlacement(ctx, tx, guard, speciesID, cageID, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		var animalID int64
+		err = tx.QueryRow(ctx, `
+			INSERT INTO animals
+			(public_id, name, species_id, cage_id, date_of_birth, sex,
+			 dam_id, sire_id, microchip_id, studbook_id, birth_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			RETURNING id
+		`,
+			o.PublicID,
+			o.Name,
+			speciesID,
+			cageID,
+			input.BornOn,
+			o.Sex,
+			damID,
+			sireID,
+			o.MicrochipID,
+			o.StudbookID,
+			birthID,
+		).Scan(&animalID)
+		if err != nil {
+			return err
+		}
+
+		bornAt := input.BornOn
+		if err := openCageAssignment(ctx, tx, animalID, cageID, &bornAt); err != nil {
+			return err
+		}
+		if err := recordOverride(ctx, tx, override, animalID, cageID); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO animal_status_history (animal_id, from_status, to_status, reason, effective_at, actor_id)
+			VALUES ($1, NULL, 'ACTIVE', 'born in the park', $2, (SELECT id FROM users WHERE public_id = $3))
+		`, animalID, input.BornOn, input.RecordedByPublicID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+const birthSelect = `
+	SELECT b.id, b.public_id, d.public_id, s.public_id, g.public_id,
+	       b.born_on, b.stillborn_count, b.notes
+	FROM births b
+	JOIN animals d ON d.id = b.dam_id
+	LEFT JOIN animals s ON s.id = b.sire_id
+	LEFT JOIN pregnancies g ON g.id = b.pregnancy_id
+`
+
+// listBirths runs a birthSelect query and loads the offspring of each
+// birth.
+func (r *breedingRepository) listBirths(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]ports.BirthDTO, error) {
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.BirthDTO, 0)
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		var b ports.BirthDTO
+		if err := rows.Scan(
+			&id,
+			&b.PublicID,
+			&b.DamPublicID,
+			&b.SirePublicID,
+			&b.PregnancyPublicID,
+			&b.BornOn,
+			&b.StillbornCount,
+			&b.Notes,
+		); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		result = append(result, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i, id := range ids {
+		offspring, err := r.offspring(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result[i].Offspring = offspring
+	}
+
+	return result, nil
+}
+
+func (r *breedingRepository) offspring(ctx context.Context, birthID int64) ([]ports.AnimalDTO, error) {
+	rows, err := r.db.Query(ctx, animalSelect+animalFrom+`
+		WHERE a.birth_id = $1
+		ORDER BY a.id
+	`, birthID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.AnimalDTO, 0)
+	for rows.Next() {
+		a, err := scanAnimal(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *breedingRepository) ListBirths(
+	ctx context.Context,
+	damPublicID *string,
+	scope []string,
+) ([]ports.BirthDTO, error) {
+	return r.listBirths(ctx, birthSelect+`
+		WHERE ($1::uuid IS NULL OR d.public_id = $1)
+		  AND ($2::uuid[] IS NULL OR d.cage_id IN (
+			SELECT c.id FROM cages c
+			JOIN areas ar ON ar.id = c.area_id
+			JOIN zones z ON z.id = ar.zone_id
+			WHERE z.public_id = ANY($2)))
+		ORDER BY b.born_on DESC, b.id DESC
+	`, damPublicID, scope)
+}
+
+func (r *breedingRepository) FindBirth(
+	ctx context.Context,
+	publicID string,
+) (ports.BirthDTO, error) {
+
+	result, err := r.listBirths(ctx, birthSelect+`
+		WHERE b.public_id = $1
+	`, publicID)
+	if err != nil {
+		return ports.BirthDTO{}, err
+	}
+	if len(result) == 0 {
+		return ports.BirthDTO{}, ports.ErrNotFound
+	}
+
+	return result[0], nil
+}
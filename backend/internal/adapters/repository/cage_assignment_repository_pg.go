@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type cageAssignmentRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCageAssignmentRepository(db *pgxpool.Pool) ports.CageAssignmentRepository {
+	return &cageAssignmentRepository{db: db}
+}
+
+const cageAssignmentSelect = `
+	SELECT a.public_id, a.name, s.public_id, s.common_name, c.public_id, c.code,
+	       ca.started_at, ca.ended_at, ca.reason, u.public_id
+	FROM cage_assignments ca
+	JOIN animals a ON a.id = ca.animal_id
+	JOIN species s ON s.id = a.species_id
+	JOIN cages c ON c.id = ca.cage_id
+	LEFT JOIN users u ON u.id = ca.actor_id
+`
+
+func (r *cageAssignmentRepository) Transfer(
+	ctx context.Context,
+	input ports.TransferInput,
+	guard ports.PlacementGuard,
+) (ports.TransferDTO, error) {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return ports.TransferDTO{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var animalID, speciesID int64
+	var status ports.AnimalStatus
+	err = tx.QueryRow(ctx,
+		`SELECT id, species_id, status FROM animals WHERE public_id=$1 FOR UPDATE`,
+		input.AnimalPublicID,
+	).Scan(&animalID, &speciesID, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.TransferDTO{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return ports.TransferDTO{}, err
+	}
+	// the status may have changed since the service looked
+	if !status.InPark() {
+		return ports.TransferDTO{}, ports.ErrConflict
+	}
+	if err := ensureNotQuarantined(ctx, tx, animalID); err != nil {
+		return ports.TransferDTO{}, err
+	}
+
+	cageID, err := reserveCageSlot(ctx, tx, input.ToCagePublicID, speciesID, &animalID)
+	if err != nil {
+		return ports.TransferDTO{}, err
+	}
+	override, err := guardPlacement(ctx, tx, guard, speciesID, cageID, &animalID)
+	if err != nil {
+		return ports.TransferDTO{}, err
+	}
+
+	closedID, openedID, err := moveAnimal(
+		ctx, tx, animalID, cageID,
+		input.TransferredAt, &input.Reason, &input.ActorPublicID,
+	)
+	if err != nil {
+		return ports.TransferDTO{}, err
+	}
+	if err := recordOverride(ctx, tx, override, animalID, cageID); err != nil {
+		return ports.TransferDTO{}, err
+	}
+
+	var result ports.TransferDTO
+	if closedID != nil {
+		if err := scanCageAssignment(
+			tx.QueryRow(ctx, cageAssignmentSelect+` WHERE ca.id=$1`, *closedID),
+			&result.From,
+		); err != nil {
+			return ports.TransferDTO{}, err
+		}
+	}
+	if err := scanCageAssignment(
+		tx.QueryRow(ctx, cageAssignmentSelect+` WHERE ca.id=$1`, openedID),
+		&result.To,
+	); err != nil {
+		return ports.TransferDTO{}, err
+	}
+
+	return result, tx.Commit(ctx)
+}
+
+func (r *cageAssignmentRepository) ListByAnimal(
+	ctx context.Context,
+	animalPublicID string,
+) ([]ports.CageAssignmentDTO, error) {
+
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM animals WHERE public_id=$1)`,
+		animalPublicID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ports.ErrNotFound
+	}
+
+	rows, err := r.db.Query(ctx, cageAssignmentSelect+`
+		WHERE a.public_id = $1
+		ORDER BY ca.started_at DESC, ca.id DESC
+	`, animalPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectCageAssignments(rows)
+}
+
+func (r *cageAssignmentRepository) ListByCage(
+	ctx context.Context,
+	cagePublicID string,
+	from, to *time.Time,
+) ([]ports.CageAssignmentDTO, error) {
+
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM cages WHERE public_id=$1)`,
+		cagePublicID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ports.ErrNotFound
+	}
+
+	rows, err := r.db.Query(ctx, cageAssignmentSelect+`
+		WHERE c.public_id = $1
+		  AND ($3::timestamp IS NULL OR ca.started_at < $3)
+		  AND ($2::timestamp IS NULL OR ca.ended_at IS NULL OR ca.ended_at > $2)
+		ORDER BY ca.started_at DESC, ca.id DESC
+	`, cagePublicID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectCageAssignments(rows)
+}
+
+func scanCageAssignment(row pgx.Row, a *ports.CageAssignmentDTO) error {
+	return row.Scan(
+		&a.AnimalPublicID,
+		&a.AnimalName,
+		&a.SpeciesPublicID,
+		&a.Species,
+		&a.CagePublicID,
+		&a.CageCode,
+		&a.StartedAt,
+		&a.EndedAt,
+		&a.Reason,
+		&a.MovedBy,
+	)
+}
+
+func collectCageAssignments(rows pgx.Rows) ([]ports.CageAssignmentDTO, error) {
+	defer rows.Close()
+
+	result := make([]ports.CageAssignmentDTO, 0)
+	for rows.Next() {
+		var a ports.CageAssignmentDTO
+		if err := scanCageAssignment(rows, &a); err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+
+	return result, rows.Err()
+}
+
+// openCageAssignment starts a stay of an animal that is not in any cage,
+// such as a new animal or one coming back to the park. A nil at uses the
+// database clock.
+func openCageAssignment(ctx context.Context, tx pgx.Tx, animalID, cageID int64, at *time.Time) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO cage_assignments (animal_id, cage_id, started_at)
+		VALUES ($1, $2, COALESCE($3::timestamp, NOW()))
+	`, animalID, cageID, at)
+
+	return err
+}
+
+// closeCageAssignment ends the animal's current stay when it leaves the
+// park. A nil at uses the database clock.
+func closeCageAssignment(ctx context.Context, tx pgx.Tx, animalID int64, at *time.Time) error {
+	var startedAt time.Time
+	var endedAt time.Time
+	err := tx.QueryRow(ctx, `
+		SELECT started_at, COALESCE($2::timestamp, NOW())::timestamp
+		FROM cage_assignments
+		WHERE animal_id = $1 AND ended_at IS NULL
+	`, animalID, at).Scan(&startedAt, &endedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if endedAt.Before(startedAt) {
+		return errors.New("effective_at is before the animal moved into its cage")
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE cage_assignments
+		SET ended_at = $2
+		WHERE animal_id = $1 AND ended_at IS NULL
+	`, animalID, endedAt)
+
+	return err
+}
+
+// moveAnimal closes the animal's open cage assignment and opens one in
+// cageID, then points animals.cage_id at the new cage. A nil at uses the
+// database clock so the interval never runs backwards. It returns the ids
+// of the closed and the opened assignment; closedID is nil for an animal
+// without history.
+func moveAnimal(
+	ctx context.Context,
+	tx pgx.Tx,
+	animalID, cageID int64,
+	at *time.Time,
+	reason, actorPublicID *string,
+) (*int64, int64, error) {
+
+	var closedID *int64
+	var movedAt time.Time
+	err := tx.QueryRow(ctx, `
+		UPDATE cage_assignments
+		SET ended_at = COALESCE($2::timestamp, NOW())
+		WHERE animal_id = $1 AND ended_at IS NULL
+		RETURNING id, ended_at
+	`, animalID, at).Scan(&closedID, &movedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		closedID = nil
+		err = tx.QueryRow(ctx,
+			`SELECT COALESCE($1::timestamp, NOW())::timestamp`, at,
+		).Scan(&movedAt)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var openedID int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO cage_assignments (animal_id, cage_id, started_at, reason, actor_id)
+		VALUES ($1, $2, $3, $4, (SELECT id FROM users WHERE public_id=$5))
+		RETURNING id
+	`, animalID, cageID, movedAt, reason, actorPublicID).Scan(&openedID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE animals SET cage_id=$1 WHERE id=$2`,
+		cageID, animalID,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return closedID, openedID, nil
+}
@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"wit-leisure-park/backend/internal/ports"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -28,43 +30,160 @@ func (r *cageRepository) CodeExists(ctx context.Context, code string) (bool, err
 
 func (r *cageRepository) Create(
 	ctx context.Context,
-	publicID, code, location string,
+	input ports.CageInput,
 ) (string, error) {
 
-	_, err := r.db.Exec(ctx, `
-		INSERT INTO cages (public_id, code, location)
-		VALUES ($1,$2,$3)
-	`, publicID, code, location)
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
 
+	areaID, err := cageAreaID(ctx, tx, input.AreaPublicID)
 	if err != nil {
 		return "", err
 	}
 
-	return publicID, nil
+	var cageID int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO cages (public_id, code, location, capacity, area_id)
+		VALUES ($1,$2,$3,$4,$5)
+		RETURNING id
+	`, input.PublicID, input.Code, input.Location, input.Capacity, areaID).Scan(&cageID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := replaceSpeciesLimits(ctx, tx, cageID, input.SpeciesLimits); err != nil {
+		return "", err
+	}
+
+	return input.PublicID, tx.Commit(ctx)
 }
 
-func (r *cageRepository) List(ctx context.Context) ([]ports.CageDTO, error) {
+// cageAreaID resolves the area a cage is placed in; nil takes the cage out
+// of its area.
+func cageAreaID(ctx context.Context, tx pgx.Tx, publicID *string) (*int64, error) {
+	if publicID == nil {
+		return nil, nil
+	}
 
-	rows, err := r.db.Query(ctx, `
-		SELECT public_id, code, location
-		FROM cages
-	`)
+	var id int64
+	err := tx.QueryRow(ctx,
+		`SELECT id FROM areas WHERE public_id=$1`,
+		*publicID,
+	).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: area %s", ports.ErrNotFound, *publicID)
+	}
 	if err != nil {
 		return nil, err
 	}
+
+	return &id, nil
+}
+
+var cageSortColumns = map[string]string{
+	"code":      "c.code",
+	"location":  "c.location",
+	"capacity":  "c.capacity",
+	"occupancy": "occupancy",
+	"zone":      "z.code",
+}
+
+const cageSelect = `
+	SELECT c.public_id, c.code, c.location, c.capacity,
+	       (SELECT COUNT(*) FROM animals a
+	        WHERE a.cage_id = c.id AND a.status IN ('ACTIVE', 'QUARANTINED')) AS occupancy,
+	       ar.public_id, ar.name, z.public_id, z.name
+`
+
+const cageFrom = `
+	FROM cages c
+	LEFT JOIN areas ar ON ar.id = c.area_id
+	LEFT JOIN zones z ON z.id = ar.zone_id
+`
+
+func scanCage(row pgx.Row) (ports.CageDTO, error) {
+	var c ports.CageDTO
+	err := row.Scan(
+		&c.PublicID,
+		&c.Code,
+		&c.Location,
+		&c.Capacity,
+		&c.Occupancy,
+		&c.AreaPublicID,
+		&c.Area,
+		&c.ZonePublicID,
+		&c.Zone,
+	)
+	if err != nil {
+		return ports.CageDTO{}, err
+	}
+	c.FreeSlots = freeSlots(c.Capacity, c.Occupancy)
+	return c, nil
+}
+
+func freeSlots(capacity *int, occupancy int) *int {
+	if capacity == nil {
+		return nil
+	}
+	free := max(*capacity-occupancy, 0)
+	return &free
+}
+
+func (r *cageRepository) List(
+	ctx context.Context,
+	query ports.CageListQuery,
+) (ports.Page[ports.CageDTO], error) {
+
+	var f listFilter
+	if query.Location != nil {
+		f.add("c.location ILIKE ?", *query.Location)
+	}
+	if query.ZonePublicID != nil {
+		f.add("z.public_id = ?", *query.ZonePublicID)
+	}
+	if query.AreaPublicID != nil {
+		f.add("ar.public_id = ?", *query.AreaPublicID)
+	}
+	if query.ScopeZonePublicIDs != nil {
+		f.add("z.public_id = ANY(?)", query.ScopeZonePublicIDs)
+	}
+	if query.Search != nil {
+		f.add("(c.code ILIKE '%' || ? || '%' OR c.location ILIKE '%' || ? || '%')", *query.Search)
+	}
+
+	order, err := orderBy(query.Sort, cageSortColumns, "code", "c.id")
+	if err != nil {
+		return ports.Page[ports.CageDTO]{}, err
+	}
+
+	from := cageFrom + f.where()
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*)`+from, f.args...).Scan(&total); err != nil {
+		return ports.Page[ports.CageDTO]{}, err
+	}
+
+	limit, args := f.page(query.ListParams)
+	rows, err := r.db.Query(ctx, cageSelect+from+order+limit, args...)
+	if err != nil {
+		return ports.Page[ports.CageDTO]{}, err
+	}
 	defer rows.Close()
 
 	result := make([]ports.CageDTO, 0)
 
 	for rows.Next() {
-		var c ports.CageDTO
-		if err := rows.Scan(&c.PublicID, &c.Code, &c.Location); err != nil {
-			return nil, err
+		c, err := scanCage(rows)
+		if err != nil {
+			return ports.Page[ports.CageDTO]{}, err
 		}
 		result = append(result, c)
 	}
 
-	return result, nil
+	return ports.NewPage(result, total, query.ListParams), rows.Err()
 }
 
 func (r *cageRepository) FindByID(
@@ -72,18 +191,13 @@ func (r *cageRepository) FindByID(
 	publicID string,
 ) (ports.CageDTO, error) {
 
-	var c ports.CageDTO
-
-	err := r.db.QueryRow(ctx, `
-		SELECT public_id, code, location
-		FROM cages
-		WHERE public_id=$1
-	`, publicID).Scan(
-		&c.PublicID,
-		&c.Code,
-		&c.Location,
-	)
-
+	c, err := scanCage(r.db.QueryRow(ctx,
+		cageSelect+cageFrom+` WHERE c.public_id=$1`,
+		publicID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.CageDTO{}, ports.ErrNotFound
+	}
 	if err != nil {
 		return ports.CageDTO{}, err
 	}
@@ -93,24 +207,63 @@ func (r *cageRepository) FindByID(
 
 func (r *cageRepository) Update(
 	ctx context.Context,
-	publicID, code, location string,
+	input ports.CageInput,
 ) error {
 
-	cmd, err := r.db.Exec(ctx, `
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// the lock keeps animals from moving in while the limits are checked
+	var cageID int64
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM cages WHERE public_id=$1 FOR UPDATE`,
+		input.PublicID,
+	).Scan(&cageID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	areaID, err := cageAreaID(ctx, tx, input.AreaPublicID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
 		UPDATE cages
-		SET code=$1, location=$2
-		WHERE public_id=$3
-	`, code, location, publicID)
+		SET code=$1, location=$2, capacity=$3, area_id=$4
+		WHERE id=$5
+	`, input.Code, input.Location, input.Capacity, areaID, cageID)
+	if err != nil {
+		return err
+	}
 
+	if input.SpeciesLimits != nil {
+		if err := replaceSpeciesLimits(ctx, tx, cageID, input.SpeciesLimits); err != nil {
+			return err
+		}
+	}
+
+	occupancy, err := cageOccupancyTx(ctx, tx, cageID)
 	if err != nil {
 		return err
 	}
 
-	if cmd.RowsAffected() == 0 {
-		return errors.New("cage not found")
+	if input.Capacity != nil && occupancy.Occupancy > *input.Capacity {
+		return fmt.Errorf("%w: cage already holds %d animals", ports.ErrCapacityExceeded, occupancy.Occupancy)
+	}
+	for _, s := range occupancy.Species {
+		if s.MaxCount != nil && s.Count > *s.MaxCount {
+			return fmt.Errorf("%w: cage already holds %d %s", ports.ErrCapacityExceeded, s.Count, s.Species)
+		}
 	}
 
-	return nil
+	return tx.Commit(ctx)
 }
 
 func (r *cageRepository) Delete(
@@ -132,3 +285,172 @@ func (r *cageRepository) Delete(
 
 	return nil
 }
+
+func (r *cageRepository) Occupancy(
+	ctx context.Context,
+	publicID string,
+) (ports.CageOccupancyDTO, error) {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return ports.CageOccupancyDTO{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var cageID int64
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM cages WHERE public_id=$1`,
+		publicID,
+	).Scan(&cageID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.CageOccupancyDTO{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return ports.CageOccupancyDTO{}, err
+	}
+
+	return cageOccupancyTx(ctx, tx, cageID)
+}
+
+func replaceSpeciesLimits(
+	ctx context.Context,
+	tx pgx.Tx,
+	cageID int64,
+	limits []ports.CageSpeciesLimit,
+) error {
+
+	_, err := tx.Exec(ctx,
+		`DELETE FROM cage_species_limits WHERE cage_id=$1`,
+		cageID,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range limits {
+		speciesID, _, err := speciesRef(ctx, tx, l.SpeciesPublicID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO cage_species_limits (cage_id, species_id, max_count)
+			VALUES ($1,$2,$3)
+		`, cageID, speciesID, l.MaxCount)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cageOccupancyTx counts the animals of a cage per species, including
+// species that have a limit but no animals yet.
+func cageOccupancyTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	cageID int64,
+) (ports.CageOccupancyDTO, error) {
+
+	var o ports.CageOccupancyDTO
+	err := tx.QueryRow(ctx, `
+		SELECT c.public_id, c.code, c.capacity,
+		       (SELECT COUNT(*) FROM animals a
+		        WHERE a.cage_id = c.id AND a.status IN ('ACTIVE', 'QUARANTINED'))
+		FROM cages c
+		WHERE c.id = $1
+	`, cageID).Scan(&o.PublicID, &o.Code, &o.Capacity, &o.Occupancy)
+	if err != nil {
+		return ports.CageOccupancyDTO{}, err
+	}
+	o.FreeSlots = freeSlots(o.Capacity, o.Occupancy)
+
+	rows, err := tx.Query(ctx, `
+		SELECT sp.public_id, sp.common_name,
+		       COALESCE(counts.count, 0),
+		       l.max_count
+		FROM (
+			SELECT species_id, COUNT(*) AS count
+			FROM animals
+			WHERE cage_id = $1
+			  AND status IN ('ACTIVE', 'QUARANTINED')
+			GROUP BY species_id
+		) counts
+		FULL JOIN (
+			SELECT species_id, max_count FROM cage_species_limits WHERE cage_id = $1
+		) l ON l.species_id = counts.species_id
+		JOIN species sp ON sp.id = COALESCE(counts.species_id, l.species_id)
+		ORDER BY sp.common_name
+	`, cageID)
+	if err != nil {
+		return ports.CageOccupancyDTO{}, err
+	}
+	defer rows.Close()
+
+	o.Species = make([]ports.SpeciesOccupancyDTO, 0)
+	for rows.Next() {
+		var s ports.SpeciesOccupancyDTO
+		if err := rows.Scan(&s.SpeciesPublicID, &s.Species, &s.Count, &s.MaxCount); err != nil {
+			return ports.CageOccupancyDTO{}, err
+		}
+		s.FreeSlots = freeSlots(s.MaxCount, s.Count)
+		o.Species = append(o.Species, s)
+	}
+
+	return o, rows.Err()
+}
+
+// reserveCageSlot locks the cage and checks that it has room for one more
+// animal of the species, ignoring the animal being moved (if any) so an
+// update in place never counts it twice. It returns the cage id.
+func reserveCageSlot(
+	ctx context.Context,
+	tx pgx.Tx,
+	cagePublicID string,
+	speciesID int64,
+	movingAnimalID *int64,
+) (int64, error) {
+
+	var cageID int64
+	var code string
+	var capacity *int
+	err := tx.QueryRow(ctx,
+		`SELECT id, code, capacity FROM cages WHERE public_id=$1 FOR UPDATE`,
+		cagePublicID,
+	).Scan(&cageID, &code, &capacity)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ports.ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var total, sameSpecies int
+	var speciesLimit *int
+	var speciesName string
+	err = tx.QueryRow(ctx, `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE species_id = $2),
+			(SELECT max_count FROM cage_species_limits
+			 WHERE cage_id = $1 AND species_id = $2),
+			(SELECT common_name FROM species WHERE id = $2)
+		FROM animals
+		WHERE cage_id = $1
+		  AND status IN ('ACTIVE', 'QUARANTINED')
+		  AND ($3::bigint IS NULL OR id <> $3)
+	`, cageID, speciesID, movingAnimalID).Scan(&total, &sameSpecies, &speciesLimit, &speciesName)
+	if err != nil {
+		return 0, err
+	}
+
+	if capacity != nil && total >= *capacity {
+		return 0, fmt.Errorf("%w: cage %s is full (%d of %d)", ports.ErrCapacityExceeded, code, total, *capacity)
+	}
+	if speciesLimit != nil && sameSpecies >= *speciesLimit {
+		return 0, fmt.Errorf("%w: cage %s allows at most %d %s", ports.ErrCapacityExceeded, code, *speciesLimit, speciesName)
+	}
+
+	return cageID, nil
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type compatibilityRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCompatibilityRepository(db *pgxpool.Pool) ports.CompatibilityRepository {
+	return &compatibilityRepository{db: db}
+}
+
+func (r *compatibilityRepository) ListRules(
+	ctx context.Context,
+	speciesPublicID *string,
+) ([]ports.CompatibilityRuleDTO, error) {
+
+	rows, err := r.db.Query(ctx, `
+		SELECT sa.public_id, sa.common_name, sb.public_id, sb.common_name, r.rule, r.notes
+		FROM species_compatibility r
+		JOIN species sa ON sa.id = r.species_a_id
+		JOIN species sb ON sb.id = r.species_b_id
+		WHERE $1::uuid IS NULL OR sa.public_id = $1 OR sb.public_id = $1
+		ORDER BY sa.common_name, sb.common_name
+	`, speciesPublicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.CompatibilityRuleDTO, 0)
+	for rows.Next() {
+		var c ports.CompatibilityRuleDTO
+		if err := rows.Scan(
+			&c.SpeciesAPublicID,
+			&c.SpeciesA,
+			&c.SpeciesBPublicID,
+			&c.SpeciesB,
+			&c.Rule,
+			&c.Notes,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *compatibilityRepository) UpsertRule(
+	ctx context.Context,
+	rule ports.CompatibilityRuleDTO,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		INSERT INTO species_compatibility (species_a_id, species_b_id, rule, notes)
+		SELECT LEAST(a.id, b.id), GREATEST(a.id, b.id), $3, $4
+		FROM species a, species b
+		WHERE a.public_id = $1 AND b.public_id = $2
+		ON CONFLICT (species_a_id, species_b_id) DO UPDATE
+		SET rule = EXCLUDED.rule,
+		    notes = EXCLUDED.notes,
+		    updated_at = NOW()
+	`, rule.SpeciesAPublicID, rule.SpeciesBPublicID, rule.Rule, rule.Notes)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *compatibilityRepository) DeleteRule(
+	ctx context.Context,
+	speciesAPublicID, speciesBPublicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		DELETE FROM species_compatibility r
+		USING species a, species b
+		WHERE a.public_id = $1 AND b.public_id = $2
+		  AND r.species_a_id = LEAST(a.id, b.id)
+		  AND r.species_b_id = GREATEST(a.id, b.id)
+	`, speciesAPublicID, speciesBPublicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *compatibilityRepository) ListOverrides(
+	ctx context.Context,
+	cagePublicID *string,
+	scope []string,
+) ([]ports.CompatibilityOverrideDTO, error) {
+
+	rows, err := r.db.Query(ctx, `
+		SELECT a.public_id, a.name, c.public_id, c.code, u.public_id,
+		       o.reason, o.conflicts, o.created_at
+		FROM compatibility_overrides o
+		JOIN animals a ON a.id = o.animal_id
+		JOIN cages c ON c.id = o.cage_id
+		LEFT JOIN users u ON u.id = o.approved_by
+		WHERE ($1::uuid IS NULL OR c.public_id = $1)
+		  AND ($2::uuid[] IS NULL OR c.area_id IN (
+			SELECT ar.id FROM areas ar
+			JOIN zones z ON z.id = ar.zone_id
+			WHERE z.public_id = ANY($2)))
+		ORDER BY o.created_at DESC, o.id DESC
+	`, cagePublicID, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.CompatibilityOverrideDTO, 0)
+	for rows.Next() {
+		var o ports.CompatibilityOverrideDTO
+		if err := rows.Scan(
+			&o.AnimalPublicID,
+			&o.AnimalName,
+			&o.CagePublicID,
+			&o.CageCode,
+			&o.ApprovedBy,
+			&o.Reason,
+			&o.Conflicts,
+			&o.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, o)
+	}
+
+	return result, rows.Err()
+}
+
+// guardPlacement runs the guard against the animals living in the cage,
+// ignoring the one being moved. The caller must have locked the cage with
+// reserveCageSlot so no other placement can change the answer before the
+// transaction commits.
+func guardPlacement(
+	ctx context.Context,
+	tx pgx.Tx,
+	guard ports.PlacementGuard,
+	speciesID, cageID int64,
+	movingAnimalID *int64,
+) (*ports.CompatibilityOverrideInput, error) {
+
+	if guard == nil {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT a.public_id, a.name, s.public_id, s.common_name, r.rule, r.notes
+		FROM animals a
+		JOIN species s ON s.id = a.species_id
+		JOIN species_compatibility r
+		  ON r.species_a_id = LEAST(a.species_id, $1::bigint)
+		 AND r.species_b_id = GREATEST(a.species_id, $1::bigint)
+		WHERE a.cage_id = $2
+		  AND a.status IN ('ACTIVE', 'QUARANTINED')
+		  AND ($3::bigint IS NULL OR a.id <> $3)
+		  AND r.rule <> 'ALLOWED'
+		ORDER BY a.name, a.id
+	`, speciesID, cageID, movingAnimalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conflicts := make([]ports.CompatibilityConflict, 0)
+	for rows.Next() {
+		var c ports.CompatibilityConflict
+		if err := rows.Scan(
+			&c.AnimalPublicID,
+			&c.AnimalName,
+			&c.SpeciesPublicID,
+			&c.Species,
+			&c.Rule,
+			&c.Notes,
+		); err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return guard(conflicts)
+}
+
+// recordOverride stores the approval returned by guardPlacement, if any.
+func recordOverride(
+	ctx context.Context,
+	tx pgx.Tx,
+	override *ports.CompatibilityOverrideInput,
+	animalID, cageID int64,
+) error {
+
+	if override == nil {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO compatibility_overrides (animal_id, cage_id, approved_by, reason, conflicts)
+		VALUES ($1, $2, (SELECT id FROM users WHERE public_id = $3), $4, $5)
+	`,
+		animalID,
+		cageID,
+		override.ApprovedByPublicID,
+		override.Reason,
+		override.Conflicts,
+	)
+	return err
+}
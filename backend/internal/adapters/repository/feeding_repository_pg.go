@@ -0,0 +1,383 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type feedingRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewFeedingRepository(db *pgxpool.Pool) ports.FeedingRepository {
+	return &feedingRepository{db: db}
+}
+
+func (r *feedingRepository) CreateDietPlan(
+	ctx context.Context,
+	input ports.DietPlanInput,
+) error {
+
+	var animalID int64
+	err := r.db.QueryRow(ctx,
+		`SELECT id FROM animals WHERE public_id=$1`,
+		input.AnimalPublicID,
+	).Scan(&animalID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO diet_plans
+		(public_id, animal_id, food_item, quantity, unit, times_per_day,
+		 notes, starts_on, ends_on, created_by)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,(SELECT id FROM users WHERE public_id=$10))
+	`,
+		input.PublicID,
+		animalID,
+		input.FoodItem,
+		input.Quantity,
+		input.Unit,
+		input.TimesPerDay,
+		input.Notes,
+		input.StartsOn,
+		input.EndsOn,
+		input.CreatedByPublicID,
+	)
+
+	return err
+}
+
+const dietPlanSelect = `
+	SELECT p.public_id, a.public_id, p.food_item, p.quantity, p.unit,
+	       p.times_per_day, p.notes, p.starts_on, p.ends_on
+	FROM diet_plans p
+	JOIN animals a ON a.id = p.animal_id
+`
+
+func scanDietPlan(row pgx.Row) (ports.DietPlanDTO, error) {
+	var p ports.DietPlanDTO
+	err := row.Scan(
+		&p.PublicID,
+		&p.AnimalPublicID,
+		&p.FoodItem,
+		&p.Quantity,
+		&p.Unit,
+		&p.TimesPerDay,
+		&p.Notes,
+		&p.StartsOn,
+		&p.EndsOn,
+	)
+	return p, err
+}
+
+func (r *feedingRepository) ListDietPlans(
+	ctx context.Context,
+	animalPublicID string,
+) ([]ports.DietPlanDTO, error) {
+
+	if err := r.animalExists(ctx, animalPublicID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, dietPlanSelect+`
+		WHERE a.public_id = $1
+		ORDER BY p.starts_on DESC, p.id
+	`, animalPublicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.DietPlanDTO, 0)
+	for rows.Next() {
+		p, err := scanDietPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *feedingRepository) FindDietPlan(
+	ctx context.Context,
+	publicID string,
+) (ports.DietPlanDTO, error) {
+
+	p, err := scanDietPlan(r.db.QueryRow(ctx,
+		dietPlanSelect+` WHERE p.public_id = $1`,
+		publicID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.DietPlanDTO{}, ports.ErrNotFound
+	}
+
+	return p, err
+}
+
+func (r *feedingRepository) UpdateDietPlan(
+	ctx context.Context,
+	input ports.DietPlanInput,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE diet_plans
+		SET food_item=$1,
+		    quantity=$2,
+		    unit=$3,
+		    times_per_day=$4,
+		    notes=$5,
+		    starts_on=$6,
+		    ends_on=$7
+		WHERE public_id=$8
+	`,
+		input.FoodItem,
+		input.Quantity,
+		input.Unit,
+		input.TimesPerDay,
+		input.Notes,
+		input.StartsOn,
+		input.EndsOn,
+		input.PublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *feedingRepository) DeleteDietPlan(
+	ctx context.Context,
+	publicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx,
+		`DELETE FROM diet_plans WHERE public_id=$1`,
+		publicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *feedingRepository) CreateFeedingLog(
+	ctx context.Context,
+	input ports.FeedingLogInput,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var animalID int64
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM animals WHERE public_id=$1`,
+		input.AnimalPublicID,
+	).Scan(&animalID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	// without an explicit plan the feeding counts against the animal's
+	// plan for the same food in effect that day, if there is one
+	var planID *int64
+	if input.DietPlanPublicID != nil {
+		var id int64
+		err = tx.QueryRow(ctx,
+			`SELECT id FROM diet_plans WHERE public_id=$1 AND animal_id=$2`,
+			*input.DietPlanPublicID,
+			animalID,
+		).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ports.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		planID = &id
+	} else {
+		err = tx.QueryRow(ctx, `
+			SELECT id FROM diet_plans
+			WHERE animal_id = $1
+			  AND LOWER(food_item) = LOWER($2)
+			  AND starts_on <= $3::date
+			  AND (ends_on IS NULL OR ends_on >= $3::date)
+			ORDER BY starts_on DESC, id DESC
+			LIMIT 1
+		`, animalID, input.FoodItem, input.FedAt).Scan(&planID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO feeding_logs
+		(public_id, animal_id, diet_plan_id, fed_at, food_item, quantity_given,
+		 quantity_refused, unit, notes, fed_by)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,(SELECT id FROM users WHERE public_id=$10))
+	`,
+		input.PublicID,
+		animalID,
+		planID,
+		input.FedAt,
+		input.FoodItem,
+		input.QuantityGiven,
+		input.QuantityRefused,
+		input.Unit,
+		input.Notes,
+		input.FedByPublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *feedingRepository) ListFeedingLogs(
+	ctx context.Context,
+	animalPublicID string,
+	from, to time.Time,
+) ([]ports.FeedingLogDTO, error) {
+
+	if err := r.animalExists(ctx, animalPublicID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT l.public_id, p.public_id, l.fed_at, l.food_item, l.quantity_given,
+		       l.quantity_refused, l.unit, l.notes, u.public_id
+		FROM feeding_logs l
+		JOIN animals a ON a.id = l.animal_id
+		LEFT JOIN diet_plans p ON p.id = l.diet_plan_id
+		LEFT JOIN users u ON u.id = l.fed_by
+		WHERE a.public_id = $1
+		  AND l.fed_at >= $2
+		  AND l.fed_at < $3
+		ORDER BY l.fed_at DESC, l.id DESC
+	`, animalPublicID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.FeedingLogDTO, 0)
+	for rows.Next() {
+		var l ports.FeedingLogDTO
+		if err := rows.Scan(
+			&l.PublicID,
+			&l.DietPlanPublicID,
+			&l.FedAt,
+			&l.FoodItem,
+			&l.QuantityGiven,
+			&l.QuantityRefused,
+			&l.Unit,
+			&l.Notes,
+			&l.FedBy,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, l)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *feedingRepository) ListMissedFeedings(
+	ctx context.Context,
+	day time.Time,
+	scope []string,
+) ([]ports.MissedFeedingDTO, error) {
+
+	rows, err := r.db.Query(ctx, `
+		SELECT a.public_id, a.name, c.code, p.public_id, p.food_item,
+		       p.quantity, p.unit, p.times_per_day, COUNT(l.id)
+		FROM diet_plans p
+		JOIN animals a ON a.id = p.animal_id
+		JOIN cages c ON c.id = a.cage_id
+		LEFT JOIN feeding_logs l
+			ON l.diet_plan_id = p.id
+			AND l.fed_at >= $1::date
+			AND l.fed_at < $1::date + 1
+		WHERE p.starts_on <= $1::date
+		  AND (p.ends_on IS NULL OR p.ends_on >= $1::date)
+		  AND a.status IN ('ACTIVE', 'QUARANTINED')
+		  AND ($2::uuid[] IS NULL OR c.area_id IN (
+			SELECT ar.id FROM areas ar
+			JOIN zones z ON z.id = ar.zone_id
+			WHERE z.public_id = ANY($2)))
+		GROUP BY a.public_id, a.name, c.code, p.id
+		HAVING COUNT(l.id) < p.times_per_day
+		ORDER BY c.code, a.name, p.food_item
+	`, day, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.MissedFeedingDTO, 0)
+	for rows.Next() {
+		var m ports.MissedFeedingDTO
+		if err := rows.Scan(
+			&m.AnimalPublicID,
+			&m.AnimalName,
+			&m.CageCode,
+			&m.DietPlanPublicID,
+			&m.FoodItem,
+			&m.Quantity,
+			&m.Unit,
+			&m.Expected,
+			&m.Logged,
+		); err != nil {
+			return nil, err
+		}
+		m.Missing = m.Expected - m.Logged
+		result = append(result, m)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *feedingRepository) animalExists(ctx context.Context, publicID string) error {
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM animals WHERE public_id=$1)`,
+		publicID,
+	).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type leaveRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLeaveRepository(db *pgxpool.Pool) ports.LeaveRepository {
+	return &leaveRepository{db: db}
+}
+
+func (r *leaveRepository) Create(
+	ctx context.Context,
+	input ports.LeaveInput,
+) error {
+
+	var zookeeperID int64
+	err := r.db.QueryRow(ctx,
+		`SELECT id FROM users WHERE public_id=$1 AND role='ZOOKEEPER'`,
+		input.ZookeeperPublicID,
+	).Scan(&zookeeperID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: zookeeper %s", ports.ErrNotFound, input.ZookeeperPublicID)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO leave_requests (public_id, zookeeper_id, type, starts_on, ends_on, reason)
+		VALUES ($1,$2,$3,$4,$5,$6)
+	`,
+		input.PublicID,
+		zookeeperID,
+		input.Type,
+		input.StartsOn,
+		input.EndsOn,
+		input.Reason,
+	)
+
+	return err
+}
+
+const leaveSelect = `
+	SELECT l.public_id, u.public_id, u.username, l.type, l.starts_on, l.ends_on,
+	       l.reason, l.status, d.public_id, l.decided_at, l.decision_note, l.created_at
+	FROM leave_requests l
+	JOIN users u ON u.id = l.zookeeper_id
+	LEFT JOIN users d ON d.id = l.decided_by
+`
+
+func scanLeave(row pgx.Row) (ports.LeaveDTO, error) {
+	var l ports.LeaveDTO
+	err := row.Scan(
+		&l.PublicID,
+		&l.ZookeeperPublicID,
+		&l.Zookeeper,
+		&l.Type,
+		&l.StartsOn,
+		&l.EndsOn,
+		&l.Reason,
+		&l.Status,
+		&l.DecidedByPublicID,
+		&l.DecidedAt,
+		&l.DecisionNote,
+		&l.CreatedAt,
+	)
+	return l, err
+}
+
+func (r *leaveRepository) FindByID(
+	ctx context.Context,
+	publicID string,
+) (ports.LeaveDTO, error) {
+
+	l, err := scanLeave(r.db.QueryRow(ctx, leaveSelect+` WHERE l.public_id=$1`, publicID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.LeaveDTO{}, ports.ErrNotFound
+	}
+
+	return l, err
+}
+
+func (r *leaveRepository) List(
+	ctx context.Context,
+	query ports.LeaveQuery,
+) ([]ports.LeaveDTO, error) {
+
+	var f listFilter
+	if query.ZookeeperPublicID != nil {
+		f.add("u.public_id = ?", *query.ZookeeperPublicID)
+	}
+	if query.ManagerPublicID != nil {
+		f.add(`l.zookeeper_id IN (
+			SELECT zk.user_id FROM zookeepers zk
+			JOIN users mu ON mu.id = zk.manager_id
+			WHERE mu.public_id = ?)`, *query.ManagerPublicID)
+	}
+	if len(query.Statuses) > 0 {
+		names := make([]string, len(query.Statuses))
+		for i, st := range query.Statuses {
+			names[i] = string(st)
+		}
+		f.add("l.status = ANY(?)", names)
+	}
+	if !query.From.IsZero() {
+		f.add("l.ends_on >= ?", query.From)
+	}
+	if !query.To.IsZero() {
+		f.add("l.starts_on <= ?", query.To)
+	}
+
+	rows, err := r.db.Query(ctx, leaveSelect+f.where()+` ORDER BY l.starts_on, u.username`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.LeaveDTO, 0)
+	for rows.Next() {
+		l, err := scanLeave(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, l)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *leaveRepository) Decide(
+	ctx context.Context,
+	decision ports.LeaveDecision,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE leave_requests
+		SET status=$3,
+		    decided_by=(SELECT id FROM users WHERE public_id=$4),
+		    decided_at=NOW(),
+		    decision_note=$5
+		WHERE public_id=$1 AND status=$2
+	`,
+		decision.PublicID,
+		decision.From,
+		decision.To,
+		decision.ActorPublicID,
+		decision.Note,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrConflict
+	}
+
+	return nil
+}
+
+func (r *leaveRepository) Availability(
+	ctx context.Context,
+	day time.Time,
+	managerPublicID *string,
+) ([]ports.AvailabilityDTO, error) {
+
+	rows, err := r.db.Query(ctx, `
+		SELECT u.public_id, u.username, zk.name, l.public_id, l.type, l.ends_on
+		FROM zookeepers zk
+		JOIN users u ON u.id = zk.user_id
+		JOIN users mu ON mu.id = zk.manager_id
+		LEFT JOIN LATERAL (
+			SELECT lr.public_id, lr.type, lr.ends_on
+			FROM leave_requests lr
+			WHERE lr.zookeeper_id = u.id
+			  AND lr.status = 'APPROVED'
+			  AND lr.starts_on <= $1
+			  AND lr.ends_on >= $1
+			ORDER BY lr.starts_on
+			LIMIT 1
+		) l ON TRUE
+		WHERE $2::uuid IS NULL OR mu.public_id = $2
+		ORDER BY zk.name
+	`, day, managerPublicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.AvailabilityDTO, 0)
+	for rows.Next() {
+		var a ports.AvailabilityDTO
+		err := rows.Scan(
+			&a.ZookeeperPublicID,
+			&a.Zookeeper,
+			&a.Name,
+			&a.LeavePublicID,
+			&a.LeaveType,
+			&a.LeaveEndsOn,
+		)
+		if err != nil {
+			return nil, err
+		}
+		a.Available = a.LeavePublicID == nil
+		result = append(result, a)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+// listFilter collects WHERE conditions for list queries. Conditions use "?"
+// as the placeholder for their argument, which is numbered on add.
+type listFilter struct {
+	conds []string
+	args  []any
+}
+
+func (f *listFilter) add(cond string, arg any) {
+	f.args = append(f.args, arg)
+	f.conds = append(f.conds, strings.ReplaceAll(cond, "?", "$"+strconv.Itoa(len(f.args))))
+}
+
+func (f *listFilter) where() string {
+	if len(f.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(f.conds, " AND ")
+}
+
+// page returns the LIMIT/OFFSET clause and the arguments for the full
+// query.
+func (f *listFilter) page(p ports.ListParams) (string, []any) {
+	n := len(f.args)
+	args := append(append([]any{}, f.args...), p.Limit, p.Offset)
+	return " LIMIT $" + strconv.Itoa(n+1) + " OFFSET $" + strconv.Itoa(n+2), args
+}
+
+// orderBy maps the requested sort field onto a whitelisted column. The
+// tiebreak column keeps pages stable when sort values repeat.
+func orderBy(sort string, columns map[string]string, fallback, tiebreak string) (string, error) {
+	if sort == "" {
+		sort = fallback
+	}
+
+	dir := "ASC"
+	if strings.HasPrefix(sort, "-") {
+		dir = "DESC"
+		sort = sort[1:]
+	}
+
+	column, ok := columns[sort]
+	if !ok {
+		return "", ports.ErrInvalidSort
+	}
+
+	return " ORDER BY " + column + " " + dir + " NULLS LAST, " + tiebreak + " " + dir, nil
+}
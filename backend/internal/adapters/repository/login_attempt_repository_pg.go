@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type loginAttemptRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLoginAttemptRepository(db *pgxpool.Pool) ports.LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+func (r *loginAttemptRepository) Record(
+	ctx context.Context,
+	username, ipAddress string,
+	success bool,
+) error {
+
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO login_attempts (username, ip_address, success)
+		VALUES ($1,$2,$3)
+	`, username, ipAddress, success)
+
+	return err
+}
+
+func (r *loginAttemptRepository) CountFailuresByIP(
+	ctx context.Context,
+	ipAddress string,
+	since time.Time,
+) (int, error) {
+
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM login_attempts
+		WHERE ip_address=$1 AND success = FALSE AND attempted_at >= $2
+	`, ipAddress, since).Scan(&count)
+
+	return count, err
+}
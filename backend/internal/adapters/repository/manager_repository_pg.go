@@ -65,15 +65,42 @@ func (r *managerRepository) CreateManager(
 	return publicID, nil
 }
 
-func (r *managerRepository) ListManagers(ctx context.Context) ([]ports.ManagerDTO, error) {
-	rows, err := r.db.Query(ctx, `
-		SELECT u.public_id, u.username, m.name
+var managerSortColumns = map[string]string{
+	"name":     "m.name",
+	"username": "u.username",
+}
+
+func (r *managerRepository) ListManagers(
+	ctx context.Context,
+	query ports.ManagerListQuery,
+) (ports.Page[ports.ManagerDTO], error) {
+
+	f := listFilter{conds: []string{"u.role = 'MANAGER'"}}
+	if query.Search != nil {
+		f.add("(m.name ILIKE '%' || ? || '%' OR u.username ILIKE '%' || ? || '%')", *query.Search)
+	}
+
+	order, err := orderBy(query.Sort, managerSortColumns, "name", "u.id")
+	if err != nil {
+		return ports.Page[ports.ManagerDTO]{}, err
+	}
+
+	from := `
 		FROM users u
 		JOIN zookeeper_managers m ON m.user_id = u.id
-		WHERE u.role = 'MANAGER'
-	`)
+	` + f.where()
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*)`+from, f.args...).Scan(&total); err != nil {
+		return ports.Page[ports.ManagerDTO]{}, err
+	}
+
+	limit, args := f.page(query.ListParams)
+	rows, err := r.db.Query(ctx, `
+		SELECT u.public_id, u.username, m.name
+	`+from+order+limit, args...)
 	if err != nil {
-		return nil, err
+		return ports.Page[ports.ManagerDTO]{}, err
 	}
 	defer rows.Close()
 
@@ -81,12 +108,12 @@ func (r *managerRepository) ListManagers(ctx context.Context) ([]ports.ManagerDT
 	for rows.Next() {
 		var m ports.ManagerDTO
 		if err := rows.Scan(&m.PublicID, &m.Username, &m.Name); err != nil {
-			return nil, err
+			return ports.Page[ports.ManagerDTO]{}, err
 		}
 		result = append(result, m)
 	}
 
-	return result, nil
+	return ports.NewPage(result, total, query.ListParams), rows.Err()
 }
 
 func (r *managerRepository) FindByPublicID(
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type measurementRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewMeasurementRepository(db *pgxpool.Pool) ports.MeasurementRepository {
+	return &measurementRepository{db: db}
+}
+
+func (r *measurementRepository) Create(
+	ctx context.Context,
+	input ports.MeasurementInput,
+) error {
+
+	var animalID int64
+	err := r.db.QueryRow(ctx,
+		`SELECT id FROM animals WHERE public_id=$1`,
+		input.AnimalPublicID,
+	).Scan(&animalID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO animal_measurements
+		(public_id, animal_id, measured_at, weight_kg, length_cm,
+		 body_condition_score, notes, recorded_by)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,(SELECT id FROM users WHERE public_id=$8))
+	`,
+		input.PublicID,
+		animalID,
+		input.MeasuredAt,
+		input.WeightKg,
+		input.LengthCm,
+		input.BodyConditionScore,
+		input.Notes,
+		input.RecordedByPublicID,
+	)
+
+	return err
+}
+
+func (r *measurementRepository) ListByAnimal(
+	ctx context.Context,
+	animalPublicID string,
+	from, to time.Time,
+) ([]ports.MeasurementDTO, error) {
+
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM animals WHERE public_id=$1)`,
+		animalPublicID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ports.ErrNotFound
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT m.public_id, m.measured_at, m.weight_kg, m.length_cm,
+		       m.body_condition_score, m.notes, u.public_id
+		FROM animal_measurements m
+		JOIN animals a ON a.id = m.animal_id
+		LEFT JOIN users u ON u.id = m.recorded_by
+		WHERE a.public_id = $1
+		  AND m.measured_at >= $2
+		  AND m.measured_at < $3
+		ORDER BY m.measured_at, m.id
+	`, animalPublicID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.MeasurementDTO, 0)
+	for rows.Next() {
+		var m ports.MeasurementDTO
+		if err := rows.Scan(
+			&m.PublicID,
+			&m.MeasuredAt,
+			&m.WeightKg,
+			&m.LengthCm,
+			&m.BodyConditionScore,
+			&m.Notes,
+			&m.RecordedBy,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *measurementRepository) ListWeightsSince(
+	ctx context.Context,
+	since time.Time,
+	scope []string,
+) ([]ports.WeightSample, error) {
+
+	rows, err := r.db.Query(ctx, `
+		SELECT a.public_id, a.name, s.public_id, s.common_name, m.measured_at, m.weight_kg
+		FROM animal_measurements m
+		JOIN animals a ON a.id = m.animal_id
+		JOIN species s ON s.id = a.species_id
+		WHERE m.weight_kg IS NOT NULL
+		  AND m.measured_at >= $1
+		  AND a.status NOT IN ('DECEASED', 'TRANSFERRED_OUT')
+		  AND ($2::uuid[] IS NULL OR a.cage_id IN (
+			SELECT c.id FROM cages c
+			JOIN areas ar ON ar.id = c.area_id
+			JOIN zones z ON z.id = ar.zone_id
+			WHERE z.public_id = ANY($2)))
+		ORDER BY a.id, m.measured_at, m.id
+	`, since, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.WeightSample, 0)
+	for rows.Next() {
+		var w ports.WeightSample
+		if err := rows.Scan(
+			&w.AnimalPublicID,
+			&w.AnimalName,
+			&w.SpeciesPublicID,
+			&w.Species,
+			&w.MeasuredAt,
+			&w.WeightKg,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, w)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *measurementRepository) ListThresholds(ctx context.Context) ([]ports.WeightThresholdDTO, error) {
+
+	rows, err := r.db.Query(ctx, `
+		SELECT s.public_id, s.common_name, t.max_change_percent, t.window_days
+		FROM species_weight_thresholds t
+		JOIN species s ON s.id = t.species_id
+		ORDER BY s.common_name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.WeightThresholdDTO, 0)
+	for rows.Next() {
+		var t ports.WeightThresholdDTO
+		if err := rows.Scan(&t.SpeciesPublicID, &t.Species, &t.MaxChangePercent, &t.WindowDays); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *measurementRepository) UpsertThreshold(
+	ctx context.Context,
+	threshold ports.WeightThresholdDTO,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		INSERT INTO species_weight_thresholds (species_id, max_change_percent, window_days)
+		SELECT id, $2, $3 FROM species WHERE public_id = $1
+		ON CONFLICT (species_id) DO UPDATE
+		SET max_change_percent = EXCLUDED.max_change_percent,
+		    window_days = EXCLUDED.window_days,
+		    updated_at = NOW()
+	`, threshold.SpeciesPublicID, threshold.MaxChangePercent, threshold.WindowDays)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *measurementRepository) DeleteThreshold(
+	ctx context.Context,
+	speciesPublicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		DELETE FROM species_weight_thresholds t
+		USING species s
+		WHERE s.id = t.species_id AND s.public_id = $1
+	`, speciesPublicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,510 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type medicalRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewMedicalRepository(db *pgxpool.Pool) ports.MedicalRepository {
+	return &medicalRepository{db: db}
+}
+
+func (r *medicalRepository) CreateVisit(
+	ctx context.Context,
+	input ports.VetVisitInput,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	animalID, err := medicalAnimalID(ctx, tx, input.AnimalPublicID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO vet_visits
+		(public_id, animal_id, visited_at, vet_name, reason, notes, recorded_by)
+		VALUES ($1,$2,$3,$4,$5,$6,(SELECT id FROM users WHERE public_id=$7))
+	`,
+		input.PublicID,
+		animalID,
+		input.VisitedAt,
+		input.VetName,
+		input.Reason,
+		input.Notes,
+		input.RecordedByPublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *medicalRepository) CreateDiagnosis(
+	ctx context.Context,
+	input ports.DiagnosisInput,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	animalID, err := medicalAnimalID(ctx, tx, input.AnimalPublicID)
+	if err != nil {
+		return err
+	}
+
+	visitID, err := medicalRecordID(ctx, tx, "vet_visits", animalID, input.VisitPublicID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO diagnoses
+		(public_id, animal_id, visit_id, condition, severity, notes, diagnosed_on, recorded_by)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,(SELECT id FROM users WHERE public_id=$8))
+	`,
+		input.PublicID,
+		animalID,
+		visitID,
+		input.Condition,
+		input.Severity,
+		input.Notes,
+		input.DiagnosedOn,
+		input.RecordedByPublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *medicalRepository) ResolveDiagnosis(
+	ctx context.Context,
+	animalPublicID, diagnosisPublicID string,
+	resolvedOn time.Time,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE diagnoses d
+		SET resolved_on = $1
+		FROM animals a
+		WHERE a.id = d.animal_id
+		  AND a.public_id = $2
+		  AND d.public_id = $3
+	`, resolvedOn, animalPublicID, diagnosisPublicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *medicalRepository) CreatePrescription(
+	ctx context.Context,
+	input ports.PrescriptionInput,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	animalID, err := medicalAnimalID(ctx, tx, input.AnimalPublicID)
+	if err != nil {
+		return err
+	}
+
+	visitID, err := medicalRecordID(ctx, tx, "vet_visits", animalID, input.VisitPublicID)
+	if err != nil {
+		return err
+	}
+
+	diagnosisID, err := medicalRecordID(ctx, tx, "diagnoses", animalID, input.DiagnosisPublicID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO prescriptions
+		(public_id, animal_id, visit_id, diagnosis_id, medication, dosage, route,
+		 interval_hours, starts_on, ends_on, instructions, recorded_by)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,(SELECT id FROM users WHERE public_id=$12))
+	`,
+		input.PublicID,
+		animalID,
+		visitID,
+		diagnosisID,
+		input.Medication,
+		input.Dosage,
+		input.Route,
+		input.IntervalHours,
+		input.StartsOn,
+		input.EndsOn,
+		input.Instructions,
+		input.RecordedByPublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *medicalRepository) CreateVaccination(
+	ctx context.Context,
+	input ports.VaccinationInput,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	animalID, err := medicalAnimalID(ctx, tx, input.AnimalPublicID)
+	if err != nil {
+		return err
+	}
+
+	visitID, err := medicalRecordID(ctx, tx, "vet_visits", animalID, input.VisitPublicID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO vaccinations
+		(public_id, animal_id, visit_id, vaccine, administered_on, due_on,
+		 batch_number, notes, recorded_by)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,(SELECT id FROM users WHERE public_id=$9))
+	`,
+		input.PublicID,
+		animalID,
+		visitID,
+		input.Vaccine,
+		input.AdministeredOn,
+		input.DueOn,
+		input.BatchNumber,
+		input.Notes,
+		input.RecordedByPublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *medicalRepository) ListVisits(
+	ctx context.Context,
+	animalPublicID string,
+) ([]ports.VetVisitDTO, error) {
+
+	if err := r.animalExists(ctx, animalPublicID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT v.public_id, v.visited_at, v.vet_name, v.reason, v.notes, u.public_id
+		FROM vet_visits v
+		JOIN animals a ON a.id = v.animal_id
+		LEFT JOIN users u ON u.id = v.recorded_by
+		WHERE a.public_id = $1
+		ORDER BY v.visited_at DESC, v.id DESC
+	`, animalPublicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.VetVisitDTO, 0)
+	for rows.Next() {
+		var v ports.VetVisitDTO
+		if err := rows.Scan(
+			&v.PublicID,
+			&v.VisitedAt,
+			&v.VetName,
+			&v.Reason,
+			&v.Notes,
+			&v.RecordedBy,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *medicalRepository) ListDiagnoses(
+	ctx context.Context,
+	animalPublicID string,
+) ([]ports.DiagnosisDTO, error) {
+
+	if err := r.animalExists(ctx, animalPublicID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT d.public_id, v.public_id, d.condition, d.severity, d.notes,
+		       d.diagnosed_on, d.resolved_on, u.public_id
+		FROM diagnoses d
+		JOIN animals a ON a.id = d.animal_id
+		LEFT JOIN vet_visits v ON v.id = d.visit_id
+		LEFT JOIN users u ON u.id = d.recorded_by
+		WHERE a.public_id = $1
+		ORDER BY d.diagnosed_on DESC, d.id DESC
+	`, animalPublicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.DiagnosisDTO, 0)
+	for rows.Next() {
+		var d ports.DiagnosisDTO
+		if err := rows.Scan(
+			&d.PublicID,
+			&d.VisitPublicID,
+			&d.Condition,
+			&d.Severity,
+			&d.Notes,
+			&d.DiagnosedOn,
+			&d.ResolvedOn,
+			&d.RecordedBy,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *medicalRepository) ListPrescriptions(
+	ctx context.Context,
+	animalPublicID string,
+) ([]ports.PrescriptionDTO, error) {
+
+	if err := r.animalExists(ctx, animalPublicID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT p.public_id, v.public_id, d.public_id, p.medication, p.dosage,
+		       p.route, p.interval_hours, p.starts_on, p.ends_on, p.instructions,
+		       u.public_id
+		FROM prescriptions p
+		JOIN animals a ON a.id = p.animal_id
+		LEFT JOIN vet_visits v ON v.id = p.visit_id
+		LEFT JOIN diagnoses d ON d.id = p.diagnosis_id
+		LEFT JOIN users u ON u.id = p.recorded_by
+		WHERE a.public_id = $1
+		ORDER BY p.starts_on DESC, p.id DESC
+	`, animalPublicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.PrescriptionDTO, 0)
+	for rows.Next() {
+		var p ports.PrescriptionDTO
+		if err := rows.Scan(
+			&p.PublicID,
+			&p.VisitPublicID,
+			&p.DiagnosisPublicID,
+			&p.Medication,
+			&p.Dosage,
+			&p.Route,
+			&p.IntervalHours,
+			&p.StartsOn,
+			&p.EndsOn,
+			&p.Instructions,
+			&p.RecordedBy,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *medicalRepository) ListVaccinations(
+	ctx context.Context,
+	animalPublicID string,
+) ([]ports.VaccinationDTO, error) {
+
+	if err := r.animalExists(ctx, animalPublicID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT vc.public_id, v.public_id, vc.vaccine, vc.administered_on, vc.due_on,
+		       vc.batch_number, vc.notes, u.public_id
+		FROM vaccinations vc
+		JOIN animals a ON a.id = vc.animal_id
+		LEFT JOIN vet_visits v ON v.id = vc.visit_id
+		LEFT JOIN users u ON u.id = vc.recorded_by
+		WHERE a.public_id = $1
+		ORDER BY vc.administered_on DESC, vc.id DESC
+	`, animalPublicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.VaccinationDTO, 0)
+	for rows.Next() {
+		var v ports.VaccinationDTO
+		if err := rows.Scan(
+			&v.PublicID,
+			&v.VisitPublicID,
+			&v.Vaccine,
+			&v.AdministeredOn,
+			&v.DueOn,
+			&v.BatchNumber,
+			&v.Notes,
+			&v.RecordedBy,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *medicalRepository) ListVaccinationsDue(
+	ctx context.Context,
+	before time.Time,
+	scope []string,
+) ([]ports.VaccinationDueDTO, error) {
+
+	// only the latest dose per animal and vaccine counts; an older due
+	// date is settled by any later dose
+	rows, err := r.db.Query(ctx, `
+		SELECT a.public_id, a.name, latest.vaccine, latest.administered_on, latest.due_on
+		FROM (
+			SELECT DISTINCT ON (animal_id, LOWER(vaccine))
+				animal_id, vaccine, administered_on, due_on
+			FROM vaccinations
+			ORDER BY animal_id, LOWER(vaccine), administered_on DESC, id DESC
+		) latest
+		JOIN animals a ON a.id = latest.animal_id
+		WHERE latest.due_on IS NOT NULL
+		  AND latest.due_on <= $1
+		  AND a.status NOT IN ('DECEASED', 'TRANSFERRED_OUT')
+		  AND ($2::uuid[] IS NULL OR a.cage_id IN (
+			SELECT c.id FROM cages c
+			JOIN areas ar ON ar.id = c.area_id
+			JOIN zones z ON z.id = ar.zone_id
+			WHERE z.public_id = ANY($2)))
+		ORDER BY latest.due_on, a.name
+	`, before, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.VaccinationDueDTO, 0)
+	for rows.Next() {
+		var v ports.VaccinationDueDTO
+		if err := rows.Scan(
+			&v.AnimalPublicID,
+			&v.AnimalName,
+			&v.Vaccine,
+			&v.AdministeredOn,
+			&v.DueOn,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *medicalRepository) animalExists(ctx context.Context, publicID string) error {
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM animals WHERE public_id=$1)`,
+		publicID,
+	).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func medicalAnimalID(ctx context.Context, tx pgx.Tx, publicID string) (int64, error) {
+	var id int64
+	err := tx.QueryRow(ctx,
+		`SELECT id FROM animals WHERE public_id=$1`,
+		publicID,
+	).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ports.ErrNotFound
+	}
+
+	return id, err
+}
+
+// medicalRecordID resolves an optional reference to another record of the
+// same animal. table is always a constant from this file.
+func medicalRecordID(
+	ctx context.Context,
+	tx pgx.Tx,
+	table string,
+	animalID int64,
+	publicID *string,
+) (*int64, error) {
+
+	if publicID == nil {
+		return nil, nil
+	}
+
+	var id int64
+	err := tx.QueryRow(ctx,
+		`SELECT id FROM `+table+` WHERE public_id=$1 AND animal_id=$2`,
+		*publicID,
+		animalID,
+	).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ports.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
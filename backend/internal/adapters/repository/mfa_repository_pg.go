@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type mfaRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewMFARepository(db *pgxpool.Pool) ports.MFARepository {
+	return &mfaRepository{db: db}
+}
+
+func (r *mfaRepository) FindByUser(
+	ctx context.Context,
+	userPublicID string,
+) (ports.MFAConfig, error) {
+
+	var m ports.MFAConfig
+	err := r.db.QueryRow(ctx, `
+		SELECT m.secret, m.enabled_at IS NOT NULL, m.last_used_step
+		FROM user_mfa m
+		JOIN users u ON u.id = m.user_id
+		WHERE u.public_id=$1
+	`, userPublicID).Scan(&m.Secret, &m.Enabled, &m.LastUsedStep)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.MFAConfig{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return ports.MFAConfig{}, err
+	}
+
+	return m, nil
+}
+
+func (r *mfaRepository) SaveSecret(
+	ctx context.Context,
+	userPublicID, secret string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		INSERT INTO user_mfa (user_id, secret)
+		SELECT id, $2 FROM users WHERE public_id=$1
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret,
+		    enabled_at = NULL,
+		    last_used_step = NULL,
+		    created_at = NOW()
+	`, userPublicID, secret)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) Enable(
+	ctx context.Context,
+	userPublicID string,
+	recoveryCodeHashes []string,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var userID int64
+	err = tx.QueryRow(ctx, `
+		UPDATE user_mfa m
+		SET enabled_at = NOW()
+		FROM users u
+		WHERE u.id = m.user_id AND u.public_id=$1
+		RETURNING m.user_id
+	`, userPublicID).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx,
+		`DELETE FROM mfa_recovery_codes WHERE user_id=$1`,
+		userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range recoveryCodeHashes {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO mfa_recovery_codes (user_id, code_hash)
+			VALUES ($1,$2)
+		`, userID, hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *mfaRepository) Disable(
+	ctx context.Context,
+	userPublicID string,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var userID int64
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM users WHERE public_id=$1`,
+		userPublicID,
+	).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id=$1`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM user_mfa WHERE user_id=$1`, userID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *mfaRepository) MarkStepUsed(
+	ctx context.Context,
+	userPublicID string,
+	step int64,
+) (bool, error) {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE user_mfa m
+		SET last_used_step = $2
+		FROM users u
+		WHERE u.id = m.user_id
+		  AND u.public_id = $1
+		  AND (m.last_used_step IS NULL OR m.last_used_step < $2)
+	`, userPublicID, step)
+	if err != nil {
+		return false, err
+	}
+
+	return cmd.RowsAffected() == 1, nil
+}
+
+func (r *mfaRepository) ConsumeRecoveryCode(
+	ctx context.Context,
+	userPublicID, codeHash string,
+) (bool, error) {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE mfa_recovery_codes c
+		SET used_at = NOW()
+		FROM users u
+		WHERE u.id = c.user_id
+		  AND u.public_id = $1
+		  AND c.code_hash = $2
+		  AND c.used_at IS NULL
+	`, userPublicID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	return cmd.RowsAffected() == 1, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+
+	"wit-leisure-park/backend/internal/domain"
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type permissionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPermissionRepository(db *pgxpool.Pool) ports.PermissionRepository {
+	return &permissionRepository{db: db}
+}
+
+func (r *permissionRepository) ListPermissions(ctx context.Context) ([]ports.PermissionDTO, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT code, description
+		FROM permissions
+		ORDER BY code
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.PermissionDTO, 0)
+	for rows.Next() {
+		var p ports.PermissionDTO
+		if err := rows.Scan(&p.Code, &p.Description); err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *permissionRepository) ListRolePermissions(ctx context.Context) ([]ports.RolePermissionsDTO, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT r.role, COALESCE(array_agg(rp.permission_code ORDER BY rp.permission_code)
+		                        FILTER (WHERE rp.permission_code IS NOT NULL), '{}')
+		FROM unnest(enum_range(NULL::user_role)) AS r(role)
+		LEFT JOIN role_permissions rp ON rp.role = r.role
+		GROUP BY r.role
+		ORDER BY r.role
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.RolePermissionsDTO, 0)
+	for rows.Next() {
+		var (
+			role  string
+			codes []string
+		)
+		if err := rows.Scan(&role, &codes); err != nil {
+			return nil, err
+		}
+
+		dto := ports.RolePermissionsDTO{
+			Role:        domain.Role(role),
+			Permissions: make([]domain.Permission, 0, len(codes)),
+		}
+		for _, c := range codes {
+			dto.Permissions = append(dto.Permissions, domain.Permission(c))
+		}
+		result = append(result, dto)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *permissionRepository) ReplaceRolePermissions(
+	ctx context.Context,
+	role domain.Role,
+	permissions []domain.Permission,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx,
+		`DELETE FROM role_permissions WHERE role=$1`,
+		role,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range permissions {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO role_permissions (role, permission_code)
+			VALUES ($1,$2)
+		`, role, p)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,474 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type quarantineRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewQuarantineRepository(db *pgxpool.Pool) ports.QuarantineRepository {
+	return &quarantineRepository{db: db}
+}
+
+// ensureNotQuarantined stops an animal in an open quarantine from being
+// moved out of its quarantine cage.
+func ensureNotQuarantined(ctx context.Context, tx pgx.Tx, animalID int64) error {
+	var code string
+	err := tx.QueryRow(ctx, `
+		SELECT c.code
+		FROM quarantines q
+		JOIN cages c ON c.id = q.cage_id
+		WHERE q.animal_id = $1 AND q.status = 'OPEN'
+	`, animalID).Scan(&code)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w in cage %s until it is cleared", ports.ErrQuarantined, code)
+}
+
+func (r *quarantineRepository) Open(
+	ctx context.Context,
+	input ports.QuarantineInput,
+	from ports.AnimalStatus,
+	guard ports.PlacementGuard,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var animalID, speciesID, currentCageID int64
+	var status ports.AnimalStatus
+	err = tx.QueryRow(ctx,
+		`SELECT id, species_id, cage_id, status FROM animals WHERE public_id=$1 FOR UPDATE`,
+		input.AnimalPublicID,
+	).Scan(&animalID, &speciesID, &currentCageID, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != from {
+		return ports.ErrConflict
+	}
+
+	var open bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM quarantines WHERE animal_id=$1 AND status='OPEN')`,
+		animalID,
+	).Scan(&open)
+	if err != nil {
+		return err
+	}
+	if open {
+		return fmt.Errorf("%w: animal is already in quarantine", ports.ErrConflict)
+	}
+
+	cageID, err := reserveCageSlot(ctx, tx, input.CagePublicID, speciesID, &animalID)
+	if err != nil {
+		return err
+	}
+	override, err := guardPlacement(ctx, tx, guard, speciesID, cageID, &animalID)
+	if err != nil {
+		return err
+	}
+
+	reason := "quarantine: " + input.Reason
+	if !status.InPark() || cageID != currentCageID {
+		if _, _, err := moveAnimal(ctx, tx, animalID, cageID, nil, &reason, &input.ManagerPublicID); err != nil {
+			return err
+		}
+	}
+	if err := recordOverride(ctx, tx, override, animalID, cageID); err != nil {
+		return err
+	}
+
+	if status != ports.AnimalQuarantined {
+		err = setAnimalStatus(ctx, tx, animalID, ports.AnimalStatusChange{
+			From:          status,
+			To:            ports.AnimalQuarantined,
+			Reason:        reason,
+			ActorPublicID: input.ManagerPublicID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var quarantineID int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO quarantines
+		(public_id, animal_id, cage_id, reason, vet_name, manager_id, zookeeper_id,
+		 started_on, expected_end_on)
+		SELECT $1, $2, $3, $4, $5, m.id, z.id, $8, $9
+		FROM users m, users z
+		WHERE m.public_id = $6 AND m.role = 'MANAGER'
+		  AND z.public_id = $7 AND z.role = 'ZOOKEEPER'
+		RETURNING id
+	`,
+		input.PublicID,
+		animalID,
+		cageID,
+		input.Reason,
+		input.VetName,
+		input.ManagerPublicID,
+		input.ZookeeperPublicID,
+		input.StartedOn,
+		input.ExpectedEndOn,
+	).Scan(&quarantineID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: zookeeper %s", ports.ErrNotFound, input.ZookeeperPublicID)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, check := range input.Checks {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO quarantine_checks (public_id, quarantine_id, name)
+			VALUES ($1, $2, $3)
+		`, check.PublicID, quarantineID, check.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+const quarantineSelect = `
+	SELECT q.id, q.public_id, a.public_id, a.name, c.public_id, c.code,
+	       q.reason, q.vet_name, m.public_id, z.public_id,
+	       q.started_on, q.expected_end_on, q.status,
+	       q.ended_at, e.public_id, q.outcome_notes, q.generated_until
+	FROM quarantines q
+	JOIN animals a ON a.id = q.animal_id
+	JOIN cages c ON c.id = q.cage_id
+	JOIN users m ON m.id = q.manager_id
+	JOIN users z ON z.id = q.zookeeper_id
+	LEFT JOIN users e ON e.id = q.ended_by
+`
+
+// listQuarantines runs a quarantineSelect query and loads the checks of
+// every returned quarantine.
+func (r *quarantineRepository) listQuarantines(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]ports.QuarantineDTO, error) {
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.QuarantineDTO, 0)
+	ids := make([]int64, 0)
+	index := make(map[int64]int)
+
+	for rows.Next() {
+		var id int64
+		q := ports.QuarantineDTO{Checks: []ports.QuarantineCheckDTO{}}
+		if err := rows.Scan(
+			&id,
+			&q.PublicID,
+			&q.AnimalPublicID,
+			&q.AnimalName,
+			&q.CagePublicID,
+			&q.CageCode,
+			&q.Reason,
+			&q.VetName,
+			&q.ManagerPublicID,
+			&q.ZookeeperPublicID,
+			&q.StartedOn,
+			&q.ExpectedEndOn,
+			&q.Status,
+			&q.EndedAt,
+			&q.EndedBy,
+			&q.OutcomeNotes,
+			&q.GeneratedUntil,
+		); err != nil {
+			return nil, err
+		}
+		index[id] = len(result)
+		ids = append(ids, id)
+		result = append(result, q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	checks, err := r.db.Query(ctx, `
+		SELECT qc.quarantine_id, qc.public_id, qc.name, qc.passed_at, u.public_id, qc.notes
+		FROM quarantine_checks qc
+		LEFT JOIN users u ON u.id = qc.passed_by
+		WHERE qc.quarantine_id = ANY($1)
+		ORDER BY qc.id
+	`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer checks.Close()
+
+	for checks.Next() {
+		var quarantineID int64
+		var c ports.QuarantineCheckDTO
+		if err := checks.Scan(
+			&quarantineID,
+			&c.PublicID,
+			&c.Name,
+			&c.PassedAt,
+			&c.PassedBy,
+			&c.Notes,
+		); err != nil {
+			return nil, err
+		}
+		i := index[quarantineID]
+		result[i].Checks = append(result[i].Checks, c)
+	}
+
+	return result, checks.Err()
+}
+
+func (r *quarantineRepository) List(
+	ctx context.Context,
+	status *ports.QuarantineStatus,
+	scope []string,
+) ([]ports.QuarantineDTO, error) {
+	return r.listQuarantines(ctx, quarantineSelect+`
+		WHERE ($1::text IS NULL OR q.status = $1)
+		  AND ($2::uuid[] IS NULL OR c.area_id IN (
+			SELECT ar.id FROM areas ar
+			JOIN zones zn ON zn.id = ar.zone_id
+			WHERE zn.public_id = ANY($2)))
+		ORDER BY q.started_on DESC, q.id DESC
+	`, status, scope)
+}
+
+func (r *quarantineRepository) ListByAnimal(
+	ctx context.Context,
+	animalPublicID string,
+) ([]ports.QuarantineDTO, error) {
+
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM animals WHERE public_id=$1)`,
+		animalPublicID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ports.ErrNotFound
+	}
+
+	return r.listQuarantines(ctx, quarantineSelect+`
+		WHERE a.public_id = $1
+		ORDER BY q.started_on DESC, q.id DESC
+	`, animalPublicID)
+}
+
+func (r *quarantineRepository) FindByID(
+	ctx context.Context,
+	publicID string,
+) (ports.QuarantineDTO, error) {
+
+	result, err := r.listQuarantines(ctx, quarantineSelect+`
+		WHERE q.public_id = $1
+	`, publicID)
+	if err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+	if len(result) == 0 {
+		return ports.QuarantineDTO{}, ports.ErrNotFound
+	}
+
+	return result[0], nil
+}
+
+func (r *quarantineRepository) FindOpenByAnimal(
+	ctx context.Context,
+	animalPublicID string,
+) (ports.QuarantineDTO, error) {
+
+	result, err := r.listQuarantines(ctx, quarantineSelect+`
+		WHERE a.public_id = $1 AND q.status = 'OPEN'
+	`, animalPublicID)
+	if err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+	if len(result) == 0 {
+		return ports.QuarantineDTO{}, ports.ErrNotFound
+	}
+
+	return result[0], nil
+}
+
+func (r *quarantineRepository) Update(
+	ctx context.Context,
+	input ports.QuarantineUpdateInput,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE quarantines q
+		SET vet_name = $2,
+		    zookeeper_id = z.id,
+		    expected_end_on = $4
+		FROM users z
+		WHERE q.public_id = $1
+		  AND q.status = 'OPEN'
+		  AND z.public_id = $3 AND z.role = 'ZOOKEEPER'
+	`,
+		input.PublicID,
+		input.VetName,
+		input.ZookeeperPublicID,
+		input.ExpectedEndOn,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *quarantineRepository) PassCheck(
+	ctx context.Context,
+	quarantinePublicID, checkPublicID, actorPublicID string,
+	notes *string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE quarantine_checks qc
+		SET passed_at = NOW(),
+		    passed_by = (SELECT id FROM users WHERE public_id = $3),
+		    notes = $4
+		FROM quarantines q
+		WHERE q.id = qc.quarantine_id
+		  AND q.public_id = $1
+		  AND q.status = 'OPEN'
+		  AND qc.public_id = $2
+	`, quarantinePublicID, checkPublicID, actorPublicID, notes)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *quarantineRepository) Clear(
+	ctx context.Context,
+	publicID, actorPublicID string,
+	notes *string,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var quarantineID, animalID int64
+	var status ports.AnimalStatus
+	err = tx.QueryRow(ctx, `
+		SELECT q.id, a.id, a.status
+		FROM quarantines q
+		JOIN animals a ON a.id = q.animal_id
+		WHERE q.public_id = $1 AND q.status = 'OPEN'
+		FOR UPDATE
+	`, publicID).Scan(&quarantineID, &animalID, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var pending int
+	err = tx.QueryRow(ctx,
+		`SELECT COUNT(*) FROM quarantine_checks WHERE quarantine_id=$1 AND passed_at IS NULL`,
+		quarantineID,
+	).Scan(&pending)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w: %d clearance checks have not passed", ports.ErrQuarantined, pending)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE quarantines
+		SET status = 'CLEARED',
+		    ended_at = NOW(),
+		    ended_by = (SELECT id FROM users WHERE public_id = $2),
+		    outcome_notes = $3
+		WHERE id = $1
+	`, quarantineID, actorPublicID, notes)
+	if err != nil {
+		return err
+	}
+
+	if status == ports.AnimalQuarantined {
+		err = setAnimalStatus(ctx, tx, animalID, ports.AnimalStatusChange{
+			From:          status,
+			To:            ports.AnimalActive,
+			Reason:        "quarantine cleared",
+			ActorPublicID: actorPublicID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *quarantineRepository) ListOpen(ctx context.Context) ([]ports.QuarantineDTO, error) {
+	return r.listQuarantines(ctx, quarantineSelect+`
+		WHERE q.status = 'OPEN'
+		ORDER BY q.id
+	`)
+}
+
+func (r *quarantineRepository) MarkGenerated(
+	ctx context.Context,
+	publicID string,
+	until time.Time,
+) error {
+
+	_, err := r.db.Exec(ctx,
+		`UPDATE quarantines SET generated_until=$2 WHERE public_id=$1`,
+		publicID, until,
+	)
+
+	return err
+}
@@ -0,0 +1,640 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type sensorRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSensorRepository(db *pgxpool.Pool) ports.SensorRepository {
+	return &sensorRepository{db: db}
+}
+
+const sensorSelect = `
+	SELECT s.public_id, c.public_id, c.code, s.name, s.serial_number,
+	       s.active, s.last_seen_at, s.created_at
+	FROM sensors s
+	JOIN cages c ON c.id = s.cage_id
+`
+
+func scanSensor(row pgx.Row) (ports.SensorDTO, error) {
+	var s ports.SensorDTO
+	err := row.Scan(
+		&s.PublicID,
+		&s.CagePublicID,
+		&s.CageCode,
+		&s.Name,
+		&s.SerialNumber,
+		&s.Active,
+		&s.LastSeenAt,
+		&s.CreatedAt,
+	)
+	return s, err
+}
+
+func (r *sensorRepository) cageID(ctx context.Context, publicID string) (int64, error) {
+	var id int64
+	err := r.db.QueryRow(ctx, `SELECT id FROM cages WHERE public_id=$1`, publicID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%w: cage %s", ports.ErrNotFound, publicID)
+	}
+	return id, err
+}
+
+func (r *sensorRepository) Create(
+	ctx context.Context,
+	input ports.SensorInput,
+) error {
+
+	cage, err := r.cageID(ctx, input.CagePublicID)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := r.db.Exec(ctx, `
+		INSERT INTO sensors (public_id, cage_id, name, serial_number, device_key_hash, active)
+		VALUES ($1,$2,$3,$4,$5,$6)
+		ON CONFLICT DO NOTHING
+	`,
+		input.PublicID,
+		cage,
+		input.Name,
+		input.SerialNumber,
+		input.DeviceKeyHash,
+		input.Active,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		if input.SerialNumber == nil {
+			return ports.ErrDuplicate
+		}
+		return fmt.Errorf("%w: serial number %s", ports.ErrDuplicate, *input.SerialNumber)
+	}
+
+	return nil
+}
+
+func (r *sensorRepository) List(
+	ctx context.Context,
+	cagePublicID *string,
+	scope []string,
+) ([]ports.SensorDTO, error) {
+
+	var f listFilter
+	if cagePublicID != nil {
+		f.add("c.public_id = ?", *cagePublicID)
+	}
+	if scope != nil {
+		f.add(`c.area_id IN (
+			SELECT ar.id FROM areas ar
+			JOIN zones z ON z.id = ar.zone_id
+			WHERE z.public_id = ANY(?))`, scope)
+	}
+
+	rows, err := r.db.Query(ctx, sensorSelect+f.where()+` ORDER BY c.code, s.name, s.id`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.SensorDTO, 0)
+	for rows.Next() {
+		s, err := scanSensor(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *sensorRepository) FindByID(
+	ctx context.Context,
+	publicID string,
+) (ports.SensorDTO, error) {
+
+	s, err := scanSensor(r.db.QueryRow(ctx, sensorSelect+` WHERE s.public_id=$1`, publicID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.SensorDTO{}, ports.ErrNotFound
+	}
+
+	return s, err
+}
+
+func (r *sensorRepository) Update(
+	ctx context.Context,
+	input ports.SensorInput,
+) error {
+
+	cage, err := r.cageID(ctx, input.CagePublicID)
+	if err != nil {
+		return err
+	}
+
+	var serialTaken bool
+	err = r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM sensors WHERE serial_number = $1 AND public_id <> $2
+		)
+	`, input.SerialNumber, input.PublicID).Scan(&serialTaken)
+	if err != nil {
+		return err
+	}
+	if serialTaken {
+		return fmt.Errorf("%w: serial number %s", ports.ErrDuplicate, *input.SerialNumber)
+	}
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE sensors
+		SET cage_id=$2, name=$3, serial_number=$4, active=$5, updated_at=NOW()
+		WHERE public_id=$1
+	`,
+		input.PublicID,
+		cage,
+		input.Name,
+		input.SerialNumber,
+		input.Active,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *sensorRepository) RotateKey(
+	ctx context.Context,
+	publicID, deviceKeyHash string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE sensors SET device_key_hash=$2, updated_at=NOW()
+		WHERE public_id=$1
+	`, publicID, deviceKeyHash)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *sensorRepository) Delete(
+	ctx context.Context,
+	publicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		DELETE FROM sensors s
+		WHERE s.public_id=$1
+		  AND NOT EXISTS (SELECT 1 FROM sensor_readings r WHERE r.sensor_id = s.id)
+	`, publicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		if _, err := r.FindByID(ctx, publicID); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: sensor has readings", ports.ErrInUse)
+	}
+
+	return nil
+}
+
+func (r *sensorRepository) FindByKeyHash(
+	ctx context.Context,
+	deviceKeyHash string,
+) (ports.SensorDTO, error) {
+
+	s, err := scanSensor(r.db.QueryRow(ctx, sensorSelect+` WHERE s.device_key_hash=$1`, deviceKeyHash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.SensorDTO{}, ports.ErrNotFound
+	}
+
+	return s, err
+}
+
+func (r *sensorRepository) SaveReadings(
+	ctx context.Context,
+	sensorPublicID string,
+	readings []ports.ReadingInput,
+) ([]ports.ReadingInput, error) {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var sensorID, cage int64
+	err = tx.QueryRow(ctx, `
+		UPDATE sensors SET last_seen_at = NOW()
+		WHERE public_id=$1
+		RETURNING id, cage_id
+	`, sensorPublicID).Scan(&sensorID, &cage)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ports.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &pgx.Batch{}
+	for _, reading := range readings {
+		batch.Queue(`
+			INSERT INTO sensor_readings (sensor_id, cage_id, metric, value, recorded_at)
+			VALUES ($1,$2,$3,$4,$5)
+			ON CONFLICT (sensor_id, metric, recorded_at) DO NOTHING
+		`, sensorID, cage, reading.Metric, reading.Value, reading.RecordedAt)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	saved := make([]ports.ReadingInput, 0, len(readings))
+	for _, reading := range readings {
+		cmd, err := results.Exec()
+		if err != nil {
+			results.Close()
+			return nil, err
+		}
+		if cmd.RowsAffected() > 0 {
+			saved = append(saved, reading)
+		}
+	}
+	if err := results.Close(); err != nil {
+		return nil, err
+	}
+
+	return saved, tx.Commit(ctx)
+}
+
+func (r *sensorRepository) HourlyAggregates(
+	ctx context.Context,
+	query ports.EnvironmentQuery,
+) ([]ports.EnvironmentAggregateDTO, error) {
+
+	cage, err := r.cageID(ctx, query.CagePublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	var f listFilter
+	f.add("r.cage_id = ?", cage)
+	f.add("r.recorded_at >= ?", query.From)
+	f.add("r.recorded_at < ?", query.To)
+	if query.Metric != nil {
+		f.add("r.metric = ?", *query.Metric)
+	}
+	if query.SensorPublicID != nil {
+		f.add("r.sensor_id = (SELECT id FROM sensors WHERE public_id = ?)", *query.SensorPublicID)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT r.metric, date_trunc('hour', r.recorded_at) AS hour,
+		       MIN(r.value), MAX(r.value), AVG(r.value), COUNT(*)
+		FROM sensor_readings r`+f.where()+`
+		GROUP BY r.metric, hour
+		ORDER BY r.metric, hour
+	`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.EnvironmentAggregateDTO, 0)
+	for rows.Next() {
+		var a ports.EnvironmentAggregateDTO
+		if err := rows.Scan(&a.Metric, &a.Hour, &a.Min, &a.Max, &a.Avg, &a.Samples); err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *sensorRepository) ListThresholds(
+	ctx context.Context,
+	cagePublicID string,
+) ([]ports.EnvironmentThresholdDTO, error) {
+
+	cage, err := r.cageID(ctx, cagePublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT metric, min_value, max_value
+		FROM cage_environment_thresholds
+		WHERE cage_id = $1
+		ORDER BY metric
+	`, cage)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.EnvironmentThresholdDTO, 0)
+	for rows.Next() {
+		var t ports.EnvironmentThresholdDTO
+		if err := rows.Scan(&t.Metric, &t.MinValue, &t.MaxValue); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *sensorRepository) FindThreshold(
+	ctx context.Context,
+	cagePublicID string,
+	metric ports.EnvironmentMetric,
+) (ports.EnvironmentThresholdDTO, error) {
+
+	t := ports.EnvironmentThresholdDTO{Metric: metric}
+	err := r.db.QueryRow(ctx, `
+		SELECT t.min_value, t.max_value
+		FROM cage_environment_thresholds t
+		JOIN cages c ON c.id = t.cage_id
+		WHERE c.public_id = $1 AND t.metric = $2
+	`, cagePublicID, metric).Scan(&t.MinValue, &t.MaxValue)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.EnvironmentThresholdDTO{}, ports.ErrNotFound
+	}
+
+	return t, err
+}
+
+func (r *sensorRepository) UpsertThreshold(
+	ctx context.Context,
+	cagePublicID string,
+	threshold ports.EnvironmentThresholdDTO,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		INSERT INTO cage_environment_thresholds (cage_id, metric, min_value, max_value)
+		SELECT id, $2, $3, $4 FROM cages WHERE public_id = $1
+		ON CONFLICT (cage_id, metric) DO UPDATE
+		SET min_value = EXCLUDED.min_value,
+		    max_value = EXCLUDED.max_value,
+		    updated_at = NOW()
+	`, cagePublicID, threshold.Metric, threshold.MinValue, threshold.MaxValue)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *sensorRepository) DeleteThreshold(
+	ctx context.Context,
+	cagePublicID string,
+	metric ports.EnvironmentMetric,
+) error {
+
+	cage, err := r.cageID(ctx, cagePublicID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	cmd, err := tx.Exec(ctx,
+		`DELETE FROM cage_environment_thresholds WHERE cage_id=$1 AND metric=$2`,
+		cage, metric,
+	)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	// without a threshold nothing would ever resolve the alert
+	_, err = tx.Exec(ctx, `
+		UPDATE environment_alerts SET resolved_at = NOW()
+		WHERE cage_id=$1 AND metric=$2 AND resolved_at IS NULL
+	`, cage, metric)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+const alertSelect = `
+	SELECT a.public_id, c.public_id, c.code, s.public_id, a.metric, a.kind,
+	       a.min_value, a.max_value, a.started_at, a.last_reading_at,
+	       a.last_value, a.peak_value, a.resolved_at, a.acknowledged_at, u.public_id
+	FROM environment_alerts a
+	JOIN cages c ON c.id = a.cage_id
+	LEFT JOIN sensors s ON s.id = a.sensor_id
+	LEFT JOIN users u ON u.id = a.acknowledged_by
+`
+
+func scanAlert(row pgx.Row) (ports.EnvironmentAlertDTO, error) {
+	var a ports.EnvironmentAlertDTO
+	err := row.Scan(
+		&a.PublicID,
+		&a.CagePublicID,
+		&a.CageCode,
+		&a.SensorPublicID,
+		&a.Metric,
+		&a.Kind,
+		&a.MinValue,
+		&a.MaxValue,
+		&a.StartedAt,
+		&a.LastReadingAt,
+		&a.LastValue,
+		&a.PeakValue,
+		&a.ResolvedAt,
+		&a.AcknowledgedAt,
+		&a.AcknowledgedBy,
+	)
+	return a, err
+}
+
+func (r *sensorRepository) LatestAlert(
+	ctx context.Context,
+	cagePublicID string,
+	metric ports.EnvironmentMetric,
+) (ports.EnvironmentAlertDTO, error) {
+
+	a, err := scanAlert(r.db.QueryRow(ctx, alertSelect+`
+		WHERE c.public_id = $1 AND a.metric = $2
+		ORDER BY a.resolved_at IS NULL DESC, a.started_at DESC, a.id DESC
+		LIMIT 1
+	`, cagePublicID, metric))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.EnvironmentAlertDTO{}, ports.ErrNotFound
+	}
+
+	return a, err
+}
+
+func (r *sensorRepository) OpenAlert(
+	ctx context.Context,
+	alert ports.EnvironmentAlertDTO,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		INSERT INTO environment_alerts
+		(public_id, cage_id, sensor_id, metric, kind, min_value, max_value,
+		 started_at, last_reading_at, last_value, peak_value)
+		SELECT $1, c.id, (SELECT id FROM sensors WHERE public_id = $3),
+		       $4, $5, $6, $7, $8, $9, $10, $11
+		FROM cages c
+		WHERE c.public_id = $2
+		ON CONFLICT (cage_id, metric) WHERE resolved_at IS NULL DO NOTHING
+	`,
+		alert.PublicID,
+		alert.CagePublicID,
+		alert.SensorPublicID,
+		alert.Metric,
+		alert.Kind,
+		alert.MinValue,
+		alert.MaxValue,
+		alert.StartedAt,
+		alert.LastReadingAt,
+		alert.LastValue,
+		alert.PeakValue,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrDuplicate
+	}
+
+	return nil
+}
+
+func (r *sensorRepository) UpdateAlert(
+	ctx context.Context,
+	alert ports.EnvironmentAlertDTO,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE environment_alerts
+		SET last_reading_at=$2, last_value=$3, peak_value=$4, resolved_at=$5
+		WHERE public_id=$1
+	`,
+		alert.PublicID,
+		alert.LastReadingAt,
+		alert.LastValue,
+		alert.PeakValue,
+		alert.ResolvedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *sensorRepository) ListAlerts(
+	ctx context.Context,
+	query ports.EnvironmentAlertQuery,
+) ([]ports.EnvironmentAlertDTO, error) {
+
+	var f listFilter
+	if query.CagePublicID != nil {
+		f.add("c.public_id = ?", *query.CagePublicID)
+	}
+	if query.Open != nil {
+		f.add("(a.resolved_at IS NULL) = ?", *query.Open)
+	}
+	if query.ScopeZonePublicIDs != nil {
+		f.add(`c.area_id IN (
+			SELECT ar.id FROM areas ar
+			JOIN zones z ON z.id = ar.zone_id
+			WHERE z.public_id = ANY(?))`, query.ScopeZonePublicIDs)
+	}
+
+	rows, err := r.db.Query(ctx, alertSelect+f.where()+` ORDER BY a.started_at DESC, a.id DESC`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.EnvironmentAlertDTO, 0)
+	for rows.Next() {
+		a, err := scanAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *sensorRepository) FindAlert(
+	ctx context.Context,
+	publicID string,
+) (ports.EnvironmentAlertDTO, error) {
+
+	a, err := scanAlert(r.db.QueryRow(ctx, alertSelect+` WHERE a.public_id=$1`, publicID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.EnvironmentAlertDTO{}, ports.ErrNotFound
+	}
+
+	return a, err
+}
+
+func (r *sensorRepository) AcknowledgeAlert(
+	ctx context.Context,
+	publicID, userPublicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE environment_alerts
+		SET acknowledged_at = NOW(),
+		    acknowledged_by = (SELECT id FROM users WHERE public_id = $2)
+		WHERE public_id = $1 AND acknowledged_at IS NULL
+	`, publicID, userPublicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type sessionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSessionRepository(db *pgxpool.Pool) ports.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) Create(
+	ctx context.Context,
+	input ports.SessionCreateInput,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var sessionID int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO sessions (public_id, user_id)
+		SELECT $1, id FROM users WHERE public_id=$2
+		RETURNING id
+	`, input.PublicID, input.UserPublicID).Scan(&sessionID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO refresh_tokens (session_id, token_hash, expires_at)
+		VALUES ($1,$2,$3)
+	`, sessionID, input.RefreshTokenHash, input.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *sessionRepository) FindRefreshToken(
+	ctx context.Context,
+	tokenHash string,
+) (ports.RefreshTokenRecord, error) {
+
+	var rec ports.RefreshTokenRecord
+
+	err := r.db.QueryRow(ctx, `
+		SELECT s.public_id, u.public_id, u.role, u.must_change_password,
+		       rt.expires_at, rt.used_at, s.revoked_at
+		FROM refresh_tokens rt
+		JOIN sessions s ON s.id = rt.session_id
+		JOIN users u ON u.id = s.user_id
+		WHERE rt.token_hash=$1
+	`, tokenHash).Scan(
+		&rec.SessionPublicID,
+		&rec.UserPublicID,
+		&rec.Role,
+		&rec.MustChangePassword,
+		&rec.ExpiresAt,
+		&rec.UsedAt,
+		&rec.SessionRevokedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.RefreshTokenRecord{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return ports.RefreshTokenRecord{}, err
+	}
+
+	return rec, nil
+}
+
+func (r *sessionRepository) Rotate(
+	ctx context.Context,
+	oldTokenHash string,
+	newTokenHash string,
+	expiresAt time.Time,
+) (bool, error) {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	// the used_at guard makes concurrent refreshes with the same token
+	// race safely: only one of them can win the update
+	var sessionID int64
+	err = tx.QueryRow(ctx, `
+		UPDATE refresh_tokens
+		SET used_at = NOW()
+		WHERE token_hash=$1 AND used_at IS NULL
+		RETURNING session_id
+	`, oldTokenHash).Scan(&sessionID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO refresh_tokens (session_id, token_hash, expires_at)
+		VALUES ($1,$2,$3)
+	`, sessionID, newTokenHash, expiresAt)
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+func (r *sessionRepository) Revoke(
+	ctx context.Context,
+	sessionPublicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE sessions
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE public_id=$1
+	`, sessionPublicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) RevokeAllForUser(
+	ctx context.Context,
+	userPublicID string,
+) error {
+
+	_, err := r.db.Exec(ctx, `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE revoked_at IS NULL
+		  AND user_id = (SELECT id FROM users WHERE public_id=$1)
+	`, userPublicID)
+
+	return err
+}
+
+func (r *sessionRepository) IsActive(
+	ctx context.Context,
+	sessionPublicID string,
+) (bool, error) {
+
+	var active bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM sessions
+			WHERE public_id=$1 AND revoked_at IS NULL
+		)
+	`, sessionPublicID).Scan(&active)
+
+	return active, err
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type shiftRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewShiftRepository(db *pgxpool.Pool) ports.ShiftRepository {
+	return &shiftRepository{db: db}
+}
+
+// shiftRefs resolves the zookeeper and the zone or cage a shift covers.
+// The zookeeper's user row stays locked until the transaction ends, so
+// their shifts are checked and written one request at a time.
+func shiftRefs(
+	ctx context.Context,
+	tx pgx.Tx,
+	input ports.ShiftInput,
+) (zookeeperID int64, zoneID, cageID *int64, err error) {
+
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM users WHERE public_id=$1 AND role='ZOOKEEPER' FOR UPDATE`,
+		input.ZookeeperPublicID,
+	).Scan(&zookeeperID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil, nil, fmt.Errorf("%w: zookeeper %s", ports.ErrNotFound, input.ZookeeperPublicID)
+	}
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	if input.ZonePublicID != nil {
+		var id int64
+		err = tx.QueryRow(ctx,
+			`SELECT id FROM zones WHERE public_id=$1`,
+			*input.ZonePublicID,
+		).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil, nil, fmt.Errorf("%w: zone %s", ports.ErrNotFound, *input.ZonePublicID)
+		}
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		zoneID = &id
+	}
+
+	if input.CagePublicID != nil {
+		var id int64
+		err = tx.QueryRow(ctx,
+			`SELECT id FROM cages WHERE public_id=$1`,
+			*input.CagePublicID,
+		).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil, nil, fmt.Errorf("%w: cage %s", ports.ErrNotFound, *input.CagePublicID)
+		}
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		cageID = &id
+	}
+
+	return zookeeperID, zoneID, cageID, nil
+}
+
+// guardShift runs the guard against the zookeeper's other shifts near the
+// new times. The shift being updated is passed on too; the guard skips it.
+func guardShift(
+	ctx context.Context,
+	tx pgx.Tx,
+	guard ports.ShiftGuard,
+	zookeeperID int64,
+	input ports.ShiftInput,
+) error {
+
+	if guard.Check == nil {
+		return nil
+	}
+
+	rows, err := tx.Query(ctx, shiftSelect+`
+		WHERE s.zookeeper_id = $1
+		  AND s.starts_at < $2
+		  AND s.ends_at > $3
+		ORDER BY s.starts_at
+	`, zookeeperID, input.EndsAt.Add(guard.Margin), input.StartsAt.Add(-guard.Margin))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	others := make([]ports.ShiftDTO, 0)
+	for rows.Next() {
+		s, err := scanShift(rows)
+		if err != nil {
+			return err
+		}
+		others = append(others, s)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return guard.Check(others)
+}
+
+func (r *shiftRepository) Create(
+	ctx context.Context,
+	input ports.ShiftInput,
+	guard ports.ShiftGuard,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	zookeeperID, zoneID, cageID, err := shiftRefs(ctx, tx, input)
+	if err != nil {
+		return err
+	}
+
+	if err := guardShift(ctx, tx, guard, zookeeperID, input); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO shifts (
+			public_id, zookeeper_id, zone_id, cage_id,
+			starts_at, ends_at, notes, created_by
+		)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,(SELECT id FROM users WHERE public_id=$8))
+	`,
+		input.PublicID,
+		zookeeperID,
+		zoneID,
+		cageID,
+		input.StartsAt,
+		input.EndsAt,
+		input.Notes,
+		input.ActorPublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *shiftRepository) Update(
+	ctx context.Context,
+	input ports.ShiftInput,
+	guard ports.ShiftGuard,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	zookeeperID, zoneID, cageID, err := shiftRefs(ctx, tx, input)
+	if err != nil {
+		return err
+	}
+
+	if err := guardShift(ctx, tx, guard, zookeeperID, input); err != nil {
+		return err
+	}
+
+	cmd, err := tx.Exec(ctx, `
+		UPDATE shifts
+		SET zookeeper_id=$2, zone_id=$3, cage_id=$4,
+		    starts_at=$5, ends_at=$6, notes=$7, updated_at=NOW()
+		WHERE public_id=$1
+	`,
+		input.PublicID,
+		zookeeperID,
+		zoneID,
+		cageID,
+		input.StartsAt,
+		input.EndsAt,
+		input.Notes,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+const shiftSelect = `
+	SELECT s.public_id, u.public_id, u.username, z.public_id, z.name,
+	       c.public_id, c.code, s.starts_at, s.ends_at, s.notes
+	FROM shifts s
+	JOIN users u ON u.id = s.zookeeper_id
+	LEFT JOIN zones z ON z.id = s.zone_id
+	LEFT JOIN cages c ON c.id = s.cage_id
+`
+
+func scanShift(row pgx.Row) (ports.ShiftDTO, error) {
+	var s ports.ShiftDTO
+	err := row.Scan(
+		&s.PublicID,
+		&s.ZookeeperPublicID,
+		&s.Zookeeper,
+		&s.ZonePublicID,
+		&s.Zone,
+		&s.CagePublicID,
+		&s.Cage,
+		&s.StartsAt,
+		&s.EndsAt,
+		&s.Notes,
+	)
+	return s, err
+}
+
+func (r *shiftRepository) FindByID(
+	ctx context.Context,
+	publicID string,
+) (ports.ShiftDTO, error) {
+
+	s, err := scanShift(r.db.QueryRow(ctx, shiftSelect+` WHERE s.public_id=$1`, publicID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ShiftDTO{}, ports.ErrNotFound
+	}
+
+	return s, err
+}
+
+func (r *shiftRepository) List(
+	ctx context.Context,
+	query ports.ShiftQuery,
+) ([]ports.ShiftDTO, error) {
+
+	var f listFilter
+	f.add("s.starts_at < ?", query.To)
+	f.add("s.ends_at > ?", query.From)
+	if query.ZookeeperPublicID != nil {
+		f.add("u.public_id = ?", *query.ZookeeperPublicID)
+	}
+	if query.ZonePublicID != nil {
+		// cage shifts count for the zone the cage is placed in
+		f.add(`(z.public_id = ? OR c.area_id IN (
+			SELECT ar.id FROM areas ar
+			JOIN zones az ON az.id = ar.zone_id
+			WHERE az.public_id = ?))`, *query.ZonePublicID)
+	}
+	if query.ManagerPublicID != nil {
+		f.add(`s.zookeeper_id IN (
+			SELECT zk.user_id FROM zookeepers zk
+			JOIN users mu ON mu.id = zk.manager_id
+			WHERE mu.public_id = ?)`, *query.ManagerPublicID)
+	}
+
+	rows, err := r.db.Query(ctx, shiftSelect+f.where()+` ORDER BY s.starts_at, u.username`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.ShiftDTO, 0)
+	for rows.Next() {
+		s, err := scanShift(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *shiftRepository) Delete(
+	ctx context.Context,
+	publicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `DELETE FROM shifts WHERE public_id=$1`, publicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,499 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type skillRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSkillRepository(db *pgxpool.Pool) ports.SkillRepository {
+	return &skillRepository{db: db}
+}
+
+func (r *skillRepository) CreateSkill(
+	ctx context.Context,
+	input ports.SkillInput,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		INSERT INTO skills (public_id, code, name, description)
+		VALUES ($1,$2,$3,$4)
+		ON CONFLICT (code) DO NOTHING
+	`, input.PublicID, input.Code, input.Name, input.Description)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: skill code %s", ports.ErrDuplicate, input.Code)
+	}
+
+	return nil
+}
+
+const skillSelect = `SELECT s.public_id, s.code, s.name, s.description FROM skills s`
+
+func (r *skillRepository) querySkills(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]ports.SkillDTO, error) {
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.SkillDTO, 0)
+	for rows.Next() {
+		var s ports.SkillDTO
+		if err := rows.Scan(&s.PublicID, &s.Code, &s.Name, &s.Description); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *skillRepository) ListSkills(ctx context.Context) ([]ports.SkillDTO, error) {
+	return r.querySkills(ctx, skillSelect+` ORDER BY s.code`)
+}
+
+func (r *skillRepository) FindSkill(
+	ctx context.Context,
+	publicID string,
+) (ports.SkillDTO, error) {
+
+	var s ports.SkillDTO
+	err := r.db.QueryRow(ctx, skillSelect+` WHERE s.public_id=$1`, publicID).
+		Scan(&s.PublicID, &s.Code, &s.Name, &s.Description)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.SkillDTO{}, ports.ErrNotFound
+	}
+
+	return s, err
+}
+
+func (r *skillRepository) UpdateSkill(
+	ctx context.Context,
+	input ports.SkillInput,
+) error {
+
+	var codeTaken bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM skills WHERE code=$1 AND public_id<>$2)`,
+		input.Code, input.PublicID,
+	).Scan(&codeTaken)
+	if err != nil {
+		return err
+	}
+	if codeTaken {
+		return fmt.Errorf("%w: skill code %s", ports.ErrDuplicate, input.Code)
+	}
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE skills
+		SET code=$2, name=$3, description=$4, updated_at=NOW()
+		WHERE public_id=$1
+	`, input.PublicID, input.Code, input.Name, input.Description)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *skillRepository) DeleteSkill(
+	ctx context.Context,
+	publicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		DELETE FROM skills s
+		WHERE s.public_id=$1
+		  AND NOT EXISTS (SELECT 1 FROM certifications c WHERE c.skill_id = s.id)
+		  AND NOT EXISTS (SELECT 1 FROM species_skills ss WHERE ss.skill_id = s.id)
+		  AND NOT EXISTS (SELECT 1 FROM task_template_skills ts WHERE ts.skill_id = s.id)
+	`, publicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		if _, err := r.FindSkill(ctx, publicID); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: skill is held or required", ports.ErrInUse)
+	}
+
+	return nil
+}
+
+func (r *skillRepository) SpeciesSkills(
+	ctx context.Context,
+	speciesPublicID string,
+) ([]ports.SkillDTO, error) {
+
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM species WHERE public_id=$1)`,
+		speciesPublicID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ports.ErrNotFound
+	}
+
+	return r.querySkills(ctx, skillSelect+`
+		JOIN species_skills ss ON ss.skill_id = s.id
+		JOIN species sp ON sp.id = ss.species_id
+		WHERE sp.public_id = $1
+		ORDER BY s.code
+	`, speciesPublicID)
+}
+
+func (r *skillRepository) SetSpeciesSkills(
+	ctx context.Context,
+	speciesPublicID string,
+	skillPublicIDs []string,
+) error {
+	return r.setRequired(ctx, "species", "species_skills", "species_id", speciesPublicID, skillPublicIDs)
+}
+
+func (r *skillRepository) TemplateSkills(
+	ctx context.Context,
+	templatePublicID string,
+) ([]ports.SkillDTO, error) {
+
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM task_templates WHERE public_id=$1)`,
+		templatePublicID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ports.ErrNotFound
+	}
+
+	return r.querySkills(ctx, skillSelect+`
+		JOIN task_template_skills ts ON ts.skill_id = s.id
+		JOIN task_templates t ON t.id = ts.template_id
+		WHERE t.public_id = $1
+		ORDER BY s.code
+	`, templatePublicID)
+}
+
+func (r *skillRepository) SetTemplateSkills(
+	ctx context.Context,
+	templatePublicID string,
+	skillPublicIDs []string,
+) error {
+	return r.setRequired(ctx, "task_templates", "task_template_skills", "template_id", templatePublicID, skillPublicIDs)
+}
+
+// setRequired replaces the skill rows of one owner in a requirement table.
+// The table and column names are constants of this file, never input.
+func (r *skillRepository) setRequired(
+	ctx context.Context,
+	ownerTable, table, column string,
+	ownerPublicID string,
+	skillPublicIDs []string,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var ownerID int64
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM `+ownerTable+` WHERE public_id=$1 FOR UPDATE`,
+		ownerPublicID,
+	).Scan(&ownerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	skillIDs := make([]int64, 0, len(skillPublicIDs))
+	for _, publicID := range skillPublicIDs {
+		var id int64
+		err := tx.QueryRow(ctx, `SELECT id FROM skills WHERE public_id=$1`, publicID).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: skill %s", ports.ErrNotFound, publicID)
+		}
+		if err != nil {
+			return err
+		}
+		skillIDs = append(skillIDs, id)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE `+column+`=$1`, ownerID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO `+table+` (`+column+`, skill_id)
+		SELECT $1, UNNEST($2::bigint[])
+		ON CONFLICT DO NOTHING
+	`, ownerID, skillIDs)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *skillRepository) CreateCertification(
+	ctx context.Context,
+	input ports.CertificationInput,
+) error {
+
+	var zookeeperID int64
+	err := r.db.QueryRow(ctx,
+		`SELECT id FROM users WHERE public_id=$1 AND role='ZOOKEEPER'`,
+		input.ZookeeperPublicID,
+	).Scan(&zookeeperID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: zookeeper %s", ports.ErrNotFound, input.ZookeeperPublicID)
+	}
+	if err != nil {
+		return err
+	}
+
+	var skillID int64
+	err = r.db.QueryRow(ctx,
+		`SELECT id FROM skills WHERE public_id=$1`,
+		input.SkillPublicID,
+	).Scan(&skillID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: skill %s", ports.ErrNotFound, input.SkillPublicID)
+	}
+	if err != nil {
+		return err
+	}
+
+	cmd, err := r.db.Exec(ctx, `
+		INSERT INTO certifications
+			(public_id, zookeeper_id, skill_id, certified_on, expires_on, reference, notes, created_by)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,(SELECT id FROM users WHERE public_id=$8))
+		ON CONFLICT (zookeeper_id, skill_id) DO NOTHING
+	`,
+		input.PublicID,
+		zookeeperID,
+		skillID,
+		input.CertifiedOn,
+		input.ExpiresOn,
+		input.Reference,
+		input.Notes,
+		input.ActorPublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: the zookeeper already holds this certification, renew it instead", ports.ErrDuplicate)
+	}
+
+	return nil
+}
+
+const certificationSelect = `
+	SELECT c.public_id, u.public_id, u.username, s.public_id, s.code, s.name,
+	       c.certified_on, c.expires_on, c.reference, c.notes
+	FROM certifications c
+	JOIN users u ON u.id = c.zookeeper_id
+	JOIN skills s ON s.id = c.skill_id
+`
+
+func scanCertification(row pgx.Row) (ports.CertificationDTO, error) {
+	var c ports.CertificationDTO
+	err := row.Scan(
+		&c.PublicID,
+		&c.ZookeeperPublicID,
+		&c.Zookeeper,
+		&c.SkillPublicID,
+		&c.SkillCode,
+		&c.SkillName,
+		&c.CertifiedOn,
+		&c.ExpiresOn,
+		&c.Reference,
+		&c.Notes,
+	)
+	return c, err
+}
+
+func (r *skillRepository) FindCertification(
+	ctx context.Context,
+	publicID string,
+) (ports.CertificationDTO, error) {
+
+	c, err := scanCertification(r.db.QueryRow(ctx, certificationSelect+` WHERE c.public_id=$1`, publicID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.CertificationDTO{}, ports.ErrNotFound
+	}
+
+	return c, err
+}
+
+func (r *skillRepository) ListCertifications(
+	ctx context.Context,
+	query ports.CertificationQuery,
+) ([]ports.CertificationDTO, error) {
+
+	var f listFilter
+	if query.ZookeeperPublicID != nil {
+		f.add("u.public_id = ?", *query.ZookeeperPublicID)
+	}
+	if query.SkillPublicID != nil {
+		f.add("s.public_id = ?", *query.SkillPublicID)
+	}
+	if query.ManagerPublicID != nil {
+		f.add(`c.zookeeper_id IN (
+			SELECT zk.user_id FROM zookeepers zk
+			JOIN users mu ON mu.id = zk.manager_id
+			WHERE mu.public_id = ?)`, *query.ManagerPublicID)
+	}
+	if !query.ExpiresFrom.IsZero() {
+		f.add("c.expires_on >= ?", query.ExpiresFrom)
+	}
+	if !query.ExpiresTo.IsZero() {
+		f.add("c.expires_on <= ?", query.ExpiresTo)
+	}
+
+	rows, err := r.db.Query(ctx,
+		certificationSelect+f.where()+` ORDER BY c.expires_on NULLS LAST, u.username, s.code`,
+		f.args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.CertificationDTO, 0)
+	for rows.Next() {
+		c, err := scanCertification(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *skillRepository) UpdateCertification(
+	ctx context.Context,
+	input ports.CertificationInput,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE certifications
+		SET certified_on=$2, expires_on=$3, reference=$4, notes=$5, updated_at=NOW()
+		WHERE public_id=$1
+	`,
+		input.PublicID,
+		input.CertifiedOn,
+		input.ExpiresOn,
+		input.Reference,
+		input.Notes,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *skillRepository) DeleteCertification(
+	ctx context.Context,
+	publicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `DELETE FROM certifications WHERE public_id=$1`, publicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *skillRepository) MissingSkills(
+	ctx context.Context,
+	zookeeperPublicID string,
+	animalPublicID, templatePublicID *string,
+	day time.Time,
+) ([]ports.MissingSkill, error) {
+
+	rows, err := r.db.Query(ctx, `
+		WITH required AS (
+			SELECT ss.skill_id
+			FROM species_skills ss
+			JOIN animals a ON a.species_id = ss.species_id
+			WHERE a.public_id = $2
+			UNION
+			SELECT ts.skill_id
+			FROM task_template_skills ts
+			JOIN task_templates t ON t.id = ts.template_id
+			WHERE t.public_id = $3
+		)
+		SELECT s.public_id, s.code, s.name,
+		       CASE WHEN c.expires_on < $4 THEN c.expires_on END
+		FROM required rq
+		JOIN skills s ON s.id = rq.skill_id
+		LEFT JOIN certifications c
+		       ON c.skill_id = s.id
+		      AND c.zookeeper_id = (SELECT id FROM users WHERE public_id = $1)
+		WHERE c.id IS NULL
+		   OR c.certified_on > $4
+		   OR c.expires_on < $4
+		ORDER BY s.code
+	`, zookeeperPublicID, animalPublicID, templatePublicID, day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.MissingSkill, 0)
+	for rows.Next() {
+		var m ports.MissingSkill
+		if err := rows.Scan(&m.SkillPublicID, &m.Code, &m.Name, &m.ExpiresOn); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,389 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type speciesRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSpeciesRepository(db *pgxpool.Pool) ports.SpeciesRepository {
+	return &speciesRepository{db: db}
+}
+
+func (r *speciesRepository) NameExists(
+	ctx context.Context,
+	name, excludePublicID string,
+) (bool, error) {
+
+	var exists bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM species
+			WHERE (LOWER(common_name) = LOWER($1) OR LOWER(scientific_name) = LOWER($1))
+			  AND public_id::text <> $2
+		)
+	`, name, excludePublicID).Scan(&exists)
+
+	return exists, err
+}
+
+func (r *speciesRepository) Create(
+	ctx context.Context,
+	input ports.SpeciesInput,
+) error {
+
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO species
+		(public_id, common_name, scientific_name, conservation_status, diet_type,
+		 min_temperature_c, max_temperature_c, feedings_per_day, care_notes)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+	`,
+		input.PublicID,
+		input.CommonName,
+		input.ScientificName,
+		input.ConservationStatus,
+		input.DietType,
+		input.MinTemperatureC,
+		input.MaxTemperatureC,
+		input.FeedingsPerDay,
+		input.CareNotes,
+	)
+
+	return err
+}
+
+var speciesSortColumns = map[string]string{
+	"common_name":         "s.common_name",
+	"scientific_name":     "s.scientific_name",
+	"conservation_status": "s.conservation_status",
+	"animals":             "animal_count",
+}
+
+const speciesSelect = `
+	SELECT s.public_id, s.common_name, s.scientific_name, s.conservation_status,
+	       s.diet_type, s.min_temperature_c::float8, s.max_temperature_c::float8,
+	       s.feedings_per_day, s.care_notes,
+	       (SELECT COUNT(*) FROM animals a WHERE a.species_id = s.id) AS animal_count
+`
+
+func scanSpecies(row pgx.Row) (ports.SpeciesDTO, error) {
+	var s ports.SpeciesDTO
+	err := row.Scan(
+		&s.PublicID,
+		&s.CommonName,
+		&s.ScientificName,
+		&s.ConservationStatus,
+		&s.DietType,
+		&s.MinTemperatureC,
+		&s.MaxTemperatureC,
+		&s.FeedingsPerDay,
+		&s.CareNotes,
+		&s.AnimalCount,
+	)
+	return s, err
+}
+
+func (r *speciesRepository) List(
+	ctx context.Context,
+	query ports.SpeciesListQuery,
+) (ports.Page[ports.SpeciesDTO], error) {
+
+	var f listFilter
+	if query.ConservationStatus != nil {
+		f.add("s.conservation_status = UPPER(?)", *query.ConservationStatus)
+	}
+	if query.DietType != nil {
+		f.add("s.diet_type = UPPER(?)", *query.DietType)
+	}
+	if query.Search != nil {
+		f.add("(s.common_name ILIKE '%' || ? || '%' OR s.scientific_name ILIKE '%' || ? || '%')", *query.Search)
+	}
+
+	order, err := orderBy(query.Sort, speciesSortColumns, "common_name", "s.id")
+	if err != nil {
+		return ports.Page[ports.SpeciesDTO]{}, err
+	}
+
+	from := ` FROM species s` + f.where()
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*)`+from, f.args...).Scan(&total); err != nil {
+		return ports.Page[ports.SpeciesDTO]{}, err
+	}
+
+	limit, args := f.page(query.ListParams)
+	rows, err := r.db.Query(ctx, speciesSelect+from+order+limit, args...)
+	if err != nil {
+		return ports.Page[ports.SpeciesDTO]{}, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.SpeciesDTO, 0)
+
+	for rows.Next() {
+		s, err := scanSpecies(rows)
+		if err != nil {
+			return ports.Page[ports.SpeciesDTO]{}, err
+		}
+		result = append(result, s)
+	}
+
+	return ports.NewPage(result, total, query.ListParams), rows.Err()
+}
+
+func (r *speciesRepository) FindByID(
+	ctx context.Context,
+	publicID string,
+) (ports.SpeciesDTO, error) {
+
+	s, err := scanSpecies(r.db.QueryRow(ctx,
+		speciesSelect+` FROM species s WHERE s.public_id=$1`,
+		publicID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.SpeciesDTO{}, ports.ErrNotFound
+	}
+
+	return s, err
+}
+
+func (r *speciesRepository) Update(
+	ctx context.Context,
+	input ports.SpeciesInput,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE species
+		SET common_name=$2,
+		    scientific_name=$3,
+		    conservation_status=$4,
+		    diet_type=$5,
+		    min_temperature_c=$6,
+		    max_temperature_c=$7,
+		    feedings_per_day=$8,
+		    care_notes=$9,
+		    updated_at=NOW()
+		WHERE public_id=$1
+	`,
+		input.PublicID,
+		input.CommonName,
+		input.ScientificName,
+		input.ConservationStatus,
+		input.DietType,
+		input.MinTemperatureC,
+		input.MaxTemperatureC,
+		input.FeedingsPerDay,
+		input.CareNotes,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *speciesRepository) Delete(
+	ctx context.Context,
+	publicID string,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	speciesID, err := lockSpecies(ctx, tx, publicID)
+	if err != nil {
+		return err
+	}
+
+	var animals int
+	err = tx.QueryRow(ctx,
+		`SELECT COUNT(*) FROM animals WHERE species_id=$1`,
+		speciesID,
+	).Scan(&animals)
+	if err != nil {
+		return err
+	}
+	if animals > 0 {
+		return fmt.Errorf("%w: %d animals belong to this species", ports.ErrInUse, animals)
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM species WHERE id=$1`, speciesID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *speciesRepository) Merge(
+	ctx context.Context,
+	targetPublicID, duplicatePublicID string,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	targetID, err := lockSpecies(ctx, tx, targetPublicID)
+	if err != nil {
+		return err
+	}
+	duplicateID, err := lockSpecies(ctx, tx, duplicatePublicID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE animals SET species_id=$1 WHERE species_id=$2`,
+		targetID, duplicateID,
+	)
+	if err != nil {
+		return err
+	}
+
+	// the target keeps its own threshold and limits where it has them
+	_, err = tx.Exec(ctx, `
+		INSERT INTO species_weight_thresholds (species_id, max_change_percent, window_days)
+		SELECT $1, max_change_percent, window_days
+		FROM species_weight_thresholds
+		WHERE species_id = $2
+		ON CONFLICT (species_id) DO NOTHING
+	`, targetID, duplicateID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO cage_species_limits (cage_id, species_id, max_count)
+		SELECT cage_id, $1, max_count
+		FROM cage_species_limits
+		WHERE species_id = $2
+		ON CONFLICT (cage_id, species_id) DO NOTHING
+	`, targetID, duplicateID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO species_skills (species_id, skill_id)
+		SELECT $1, skill_id
+		FROM species_skills
+		WHERE species_id = $2
+		ON CONFLICT DO NOTHING
+	`, targetID, duplicateID)
+	if err != nil {
+		return err
+	}
+
+	// rules are stored lower id first; the duplicate's rule with itself or
+	// with the target would become a rule of the target with itself, which
+	// the target keeps as it is
+	_, err = tx.Exec(ctx, `
+		INSERT INTO species_compatibility (species_a_id, species_b_id, rule, notes)
+		SELECT LEAST($1::bigint, other), GREATEST($1::bigint, other), rule, notes
+		FROM (
+			SELECT CASE WHEN species_a_id = $2 THEN species_b_id ELSE species_a_id END AS other,
+			       rule, notes
+			FROM species_compatibility
+			WHERE species_a_id = $2 OR species_b_id = $2
+		) d
+		WHERE other NOT IN ($1, $2)
+		ON CONFLICT (species_a_id, species_b_id) DO NOTHING
+	`, targetID, duplicateID)
+	if err != nil {
+		return err
+	}
+
+	// the duplicate goes first so its names are free for the target
+	var d ports.SpeciesInput
+	err = tx.QueryRow(ctx, `
+		DELETE FROM species WHERE id=$1
+		RETURNING scientific_name, conservation_status, diet_type,
+		          min_temperature_c::float8, max_temperature_c::float8,
+		          feedings_per_day, care_notes
+	`, duplicateID).Scan(
+		&d.ScientificName,
+		&d.ConservationStatus,
+		&d.DietType,
+		&d.MinTemperatureC,
+		&d.MaxTemperatureC,
+		&d.FeedingsPerDay,
+		&d.CareNotes,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE species
+		SET scientific_name=COALESCE(scientific_name, $2),
+		    conservation_status=COALESCE(conservation_status, $3),
+		    diet_type=COALESCE(diet_type, $4),
+		    min_temperature_c=COALESCE(min_temperature_c, $5),
+		    max_temperature_c=COALESCE(max_temperature_c, $6),
+		    feedings_per_day=COALESCE(feedings_per_day, $7),
+		    care_notes=COALESCE(care_notes, $8),
+		    updated_at=NOW()
+		WHERE id=$1
+	`,
+		targetID,
+		d.ScientificName,
+		d.ConservationStatus,
+		d.DietType,
+		d.MinTemperatureC,
+		d.MaxTemperatureC,
+		d.FeedingsPerDay,
+		d.CareNotes,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func lockSpecies(ctx context.Context, tx pgx.Tx, publicID string) (int64, error) {
+	var id int64
+	err := tx.QueryRow(ctx,
+		`SELECT id FROM species WHERE public_id=$1 FOR UPDATE`,
+		publicID,
+	).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ports.ErrNotFound
+	}
+
+	return id, err
+}
+
+// speciesRef resolves the species an animal or a cage limit points at.
+func speciesRef(ctx context.Context, tx pgx.Tx, publicID string) (int64, string, error) {
+	var id int64
+	var name string
+	err := tx.QueryRow(ctx,
+		`SELECT id, common_name FROM species WHERE public_id=$1`,
+		publicID,
+	).Scan(&id, &name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, "", fmt.Errorf("%w: species %s", ports.ErrNotFound, publicID)
+	}
+
+	return id, name, err
+}
@@ -3,8 +3,11 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 	"wit-leisure-park/backend/internal/ports"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -58,10 +61,41 @@ func (r *taskRepository) Create(
 		animalID = &id
 	}
 
-	_, err = tx.Exec(ctx,
+	var templateID *int64
+	if input.TemplatePublicID != nil {
+		var id int64
+		err = tx.QueryRow(ctx,
+			`SELECT id FROM task_templates WHERE public_id=$1`,
+			*input.TemplatePublicID,
+		).Scan(&id)
+		if err != nil {
+			return "", err
+		}
+		templateID = &id
+	}
+
+	var quarantineID *int64
+	if input.QuarantinePublicID != nil {
+		var id int64
+		err = tx.QueryRow(ctx,
+			`SELECT id FROM quarantines WHERE public_id=$1`,
+			*input.QuarantinePublicID,
+		).Scan(&id)
+		if err != nil {
+			return "", err
+		}
+		quarantineID = &id
+	}
+
+	// a template or quarantine generates at most one task per due date
+	var taskID int64
+	var status ports.TaskStatus
+	err = tx.QueryRow(ctx,
 		`INSERT INTO tasks
-		(public_id,title,description,manager_id,zookeeper_id,animal_id,due_date)
-		VALUES ($1,$2,$3,$4,$5,$6,$7)`,
+		(public_id,title,description,manager_id,zookeeper_id,animal_id,due_date,template_id,quarantine_id)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+		ON CONFLICT DO NOTHING
+		RETURNING id, status`,
 		input.PublicID,
 		input.Title,
 		input.Description,
@@ -69,7 +103,20 @@ func (r *taskRepository) Create(
 		zookeeperID,
 		animalID,
 		input.DueDate,
-	)
+		templateID,
+		quarantineID,
+	).Scan(&taskID, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ports.ErrDuplicate
+	}
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO task_status_history (task_id, from_status, to_status, actor_id)
+		VALUES ($1, NULL, $2, $3)
+	`, taskID, status, managerID)
 	if err != nil {
 		return "", err
 	}
@@ -77,11 +124,91 @@ func (r *taskRepository) Create(
 	return input.PublicID, tx.Commit(ctx)
 }
 
+var taskSortColumns = map[string]string{
+	"due_date":  "t.due_date",
+	"title":     "t.title",
+	"status":    "t.status",
+	"zookeeper": "u.username",
+}
+
 func (r *taskRepository) ListByManager(
 	ctx context.Context,
 	managerPublicID string,
-) ([]ports.TaskDTO, error) {
+	query ports.TaskListQuery,
+) (ports.Page[ports.TaskDTO], error) {
+
+	var f listFilter
+	f.add("m.public_id = ?", managerPublicID)
+	return r.list(ctx, f, query)
+}
+
+func (r *taskRepository) ListByZookeeper(
+	ctx context.Context,
+	zookeeperPublicID string,
+	query ports.TaskListQuery,
+) (ports.Page[ports.TaskDTO], error) {
 
+	var f listFilter
+	f.add("u.public_id = ?", zookeeperPublicID)
+	return r.list(ctx, f, query)
+}
+
+func (r *taskRepository) list(
+	ctx context.Context,
+	f listFilter,
+	query ports.TaskListQuery,
+) (ports.Page[ports.TaskDTO], error) {
+
+	if query.Status != nil {
+		f.add("t.status = ?", *query.Status)
+	}
+	if query.ZookeeperPublicID != nil {
+		f.add("u.public_id = ?", *query.ZookeeperPublicID)
+	}
+	if query.AnimalPublicID != nil {
+		f.add("a.public_id = ?", *query.AnimalPublicID)
+	}
+	if query.ZonePublicID != nil {
+		// tasks follow the zone of the animal's cage
+		f.add(`a.cage_id IN (
+			SELECT c.id FROM cages c
+			JOIN areas ar ON ar.id = c.area_id
+			JOIN zones z ON z.id = ar.zone_id
+			WHERE z.public_id = ?)`, *query.ZonePublicID)
+	}
+	if query.ScopeZonePublicIDs != nil {
+		f.add(`(t.animal_id IS NULL OR a.cage_id IN (
+			SELECT c.id FROM cages c
+			JOIN areas ar ON ar.id = c.area_id
+			JOIN zones z ON z.id = ar.zone_id
+			WHERE z.public_id = ANY(?)))`, query.ScopeZonePublicIDs)
+	}
+	if query.DueBefore != nil {
+		f.add("t.due_date < ?", *query.DueBefore)
+	}
+	if query.DueAfter != nil {
+		f.add("t.due_date > ?", *query.DueAfter)
+	}
+
+	order, err := orderBy(query.Sort, taskSortColumns, "due_date", "t.id")
+	if err != nil {
+		return ports.Page[ports.TaskDTO]{}, err
+	}
+
+	from := `
+		FROM tasks t
+		JOIN users u ON u.id = t.zookeeper_id
+		LEFT JOIN animals a ON a.id = t.animal_id
+		LEFT JOIN task_templates tt ON tt.id = t.template_id
+		JOIN users m ON m.id = t.manager_id
+	` + f.where()
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*)`+from, f.args...).Scan(&total); err != nil {
+		return ports.Page[ports.TaskDTO]{}, err
+	}
+
+	limit, args := f.page(query.ListParams)
 	rows, err := r.db.Query(ctx, `
 		SELECT 
 			t.public_id,
@@ -90,16 +217,13 @@ func (r *taskRepository) ListByManager(
 			t.status,
 			t.due_date,
 			u.username,
-			a.name
-		FROM tasks t
-		JOIN users u ON u.id = t.zookeeper_id
-		LEFT JOIN animals a ON a.id = t.animal_id
-		JOIN users m ON m.id = t.manager_id
-		WHERE m.public_id = $1
-	`, managerPublicID)
+			a.name,
+			a.public_id,
+			tt.public_id
+	`+from+order+limit, args...)
 
 	if err != nil {
-		return nil, err
+		return ports.Page[ports.TaskDTO]{}, err
 	}
 	defer rows.Close()
 
@@ -115,42 +239,167 @@ func (r *taskRepository) ListByManager(
 			&t.DueDate,
 			&t.Zookeeper,
 			&t.Animal,
+			&t.AnimalPublicID,
+			&t.TemplatePublicID,
 		); err != nil {
-			return nil, err
+			return ports.Page[ports.TaskDTO]{}, err
 		}
 		result = append(result, t)
 	}
 
-	return result, nil
+	return ports.NewPage(result, total, query.ListParams), rows.Err()
 }
 
-func (r *taskRepository) ListByZookeeper(
+func (r *taskRepository) UpdateStatus(
+	ctx context.Context,
+	change ports.TaskStatusChange,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var taskID int64
+	err = tx.QueryRow(ctx,
+		`UPDATE tasks SET status=$1 WHERE public_id=$2 AND status=$3 RETURNING id`,
+		change.To,
+		change.PublicID,
+		change.From,
+	).Scan(&taskID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrConflict
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO task_status_history (task_id, from_status, to_status, actor_id, note)
+		VALUES ($1, $2, $3, (SELECT id FROM users WHERE public_id=$4), $5)
+	`, taskID, change.From, change.To, change.ActorPublicID, change.Note)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *taskRepository) Delete(
+	ctx context.Context,
+	publicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx,
+		`DELETE FROM tasks WHERE public_id=$1`,
+		publicID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return errors.New("task not found")
+	}
+
+	return nil
+}
+
+func (r *taskRepository) FindOwnership(
+	ctx context.Context,
+	publicID string,
+) (ports.TaskOwnership, error) {
+
+	var o ports.TaskOwnership
+
+	err := r.db.QueryRow(ctx, `
+		SELECT m.public_id, z.public_id, tm.public_id, t.status
+		FROM tasks t
+		JOIN users m ON m.id = t.manager_id
+		JOIN users z ON z.id = t.zookeeper_id
+		LEFT JOIN zookeepers zk ON zk.user_id = t.zookeeper_id
+		LEFT JOIN users tm ON tm.id = zk.manager_id
+		WHERE t.public_id = $1
+	`, publicID).Scan(
+		&o.ManagerPublicID,
+		&o.ZookeeperPublicID,
+		&o.TeamManagerPublicID,
+		&o.Status,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.TaskOwnership{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return ports.TaskOwnership{}, err
+	}
+
+	return o, nil
+}
+
+func (r *taskRepository) ListStatusHistory(
+	ctx context.Context,
+	publicID string,
+) ([]ports.TaskStatusHistoryDTO, error) {
+
+	rows, err := r.db.Query(ctx, `
+		SELECT h.from_status, h.to_status, u.public_id, u.username, h.note, h.changed_at
+		FROM task_status_history h
+		JOIN tasks t ON t.id = h.task_id
+		LEFT JOIN users u ON u.id = h.actor_id
+		WHERE t.public_id = $1
+		ORDER BY h.changed_at, h.id
+	`, publicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []ports.TaskStatusHistoryDTO{}
+
+	for rows.Next() {
+		var h ports.TaskStatusHistoryDTO
+		if err := rows.Scan(
+			&h.FromStatus,
+			&h.ToStatus,
+			&h.ActorPublicID,
+			&h.ActorUsername,
+			&h.Note,
+			&h.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, h)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *taskRepository) ListOpen(
 	ctx context.Context,
 	zookeeperPublicID string,
+	from, to time.Time,
 ) ([]ports.TaskDTO, error) {
 
 	rows, err := r.db.Query(ctx, `
-		SELECT 
-			t.public_id,
-			t.title,
-			t.description,
-			t.status,
-			t.due_date,
-			u.username,
-			a.name
+		SELECT t.public_id, t.title, t.description, t.status, t.due_date, u.username,
+		       a.name, a.public_id, tt.public_id
 		FROM tasks t
 		JOIN users u ON u.id = t.zookeeper_id
 		LEFT JOIN animals a ON a.id = t.animal_id
+		LEFT JOIN task_templates tt ON tt.id = t.template_id
 		WHERE u.public_id = $1
-	`, zookeeperPublicID)
-
+		  AND t.status <> 'DONE'
+		  AND t.due_date BETWEEN $2 AND $3
+		ORDER BY t.due_date, t.id
+	`, zookeeperPublicID, from, to)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	result := []ports.TaskDTO{}
-
 	for rows.Next() {
 		var t ports.TaskDTO
 		if err := rows.Scan(
@@ -161,55 +410,83 @@ func (r *taskRepository) ListByZookeeper(
 			&t.DueDate,
 			&t.Zookeeper,
 			&t.Animal,
+			&t.AnimalPublicID,
+			&t.TemplatePublicID,
 		); err != nil {
 			return nil, err
 		}
 		result = append(result, t)
 	}
 
-	return result, nil
+	return result, rows.Err()
 }
 
-func (r *taskRepository) UpdateStatus(
+func (r *taskRepository) Reassign(
 	ctx context.Context,
-	publicID string,
-	status ports.TaskStatus,
-) error {
-
-	cmd, err := r.db.Exec(ctx,
-		`UPDATE tasks SET status=$1 WHERE public_id=$2`,
-		status,
-		publicID,
-	)
+	publicIDs []string,
+	zookeeperPublicID string,
+	managerPublicID string,
+) (int, error) {
 
+	var zookeeperID int64
+	err := r.db.QueryRow(ctx, `
+		SELECT u.id
+		FROM users u
+		JOIN zookeepers zk ON zk.user_id = u.id
+		JOIN users m ON m.id = zk.manager_id
+		WHERE u.public_id = $1 AND u.role = 'ZOOKEEPER' AND m.public_id = $2
+	`, zookeeperPublicID, managerPublicID).Scan(&zookeeperID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%w: zookeeper %s", ports.ErrNotFound, zookeeperPublicID)
+	}
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	if cmd.RowsAffected() == 0 {
-		return errors.New("task not found")
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE tasks t
+		SET zookeeper_id = $1
+		FROM zookeepers zk
+		JOIN users m ON m.id = zk.manager_id
+		WHERE zk.user_id = t.zookeeper_id
+		  AND m.public_id = $3
+		  AND t.public_id::text = ANY($2)
+		  AND t.status <> 'DONE'
+	`, zookeeperID, publicIDs, managerPublicID)
+	if err != nil {
+		return 0, err
 	}
 
-	return nil
+	return int(cmd.RowsAffected()), nil
 }
 
-func (r *taskRepository) Delete(
+func (r *taskRepository) OpenCounts(
 	ctx context.Context,
-	publicID string,
-) error {
-
-	cmd, err := r.db.Exec(ctx,
-		`DELETE FROM tasks WHERE public_id=$1`,
-		publicID,
-	)
+	zookeeperPublicIDs []string,
+) (map[string]int, error) {
 
+	rows, err := r.db.Query(ctx, `
+		SELECT u.public_id, COUNT(*)
+		FROM tasks t
+		JOIN users u ON u.id = t.zookeeper_id
+		WHERE u.public_id::text = ANY($1)
+		  AND t.status <> 'DONE'
+		GROUP BY u.public_id
+	`, zookeeperPublicIDs)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	if cmd.RowsAffected() == 0 {
-		return errors.New("task not found")
+	result := make(map[string]int, len(zookeeperPublicIDs))
+	for rows.Next() {
+		var publicID string
+		var count int
+		if err := rows.Scan(&publicID, &count); err != nil {
+			return nil, err
+		}
+		result[publicID] = count
 	}
 
-	return nil
+	return result, rows.Err()
 }
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type taskTemplateRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTaskTemplateRepository(db *pgxpool.Pool) ports.TaskTemplateRepository {
+	return &taskTemplateRepository{db: db}
+}
+
+const taskTemplateSelect = `
+	SELECT
+		tt.public_id,
+		tt.title,
+		tt.description,
+		m.public_id,
+		z.public_id,
+		a.public_id,
+		tt.recurrence,
+		tt.starts_on,
+		tt.ends_on,
+		tt.lead_days,
+		tt.active,
+		tt.generated_until
+	FROM task_templates tt
+	JOIN users m ON m.id = tt.manager_id
+	JOIN users z ON z.id = tt.zookeeper_id
+	LEFT JOIN animals a ON a.id = tt.animal_id
+`
+
+func scanTaskTemplate(row pgx.Row) (ports.TaskTemplateDTO, error) {
+	var t ports.TaskTemplateDTO
+	err := row.Scan(
+		&t.PublicID,
+		&t.Title,
+		&t.Description,
+		&t.ManagerPublicID,
+		&t.ZookeeperPublicID,
+		&t.AnimalPublicID,
+		&t.Recurrence,
+		&t.StartsOn,
+		&t.EndsOn,
+		&t.LeadDays,
+		&t.Active,
+		&t.GeneratedUntil,
+	)
+	return t, err
+}
+
+func (r *taskTemplateRepository) Create(
+	ctx context.Context,
+	input ports.TaskTemplateInput,
+) (string, error) {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var managerID int64
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM users WHERE public_id=$1 AND role='MANAGER'`,
+		input.ManagerPublicID,
+	).Scan(&managerID)
+	if err != nil {
+		return "", err
+	}
+
+	zookeeperID, animalID, err := r.resolveAssignment(ctx, tx, input)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO task_templates
+		(public_id,title,description,manager_id,zookeeper_id,animal_id,
+		 recurrence,starts_on,ends_on,lead_days,active)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
+	`,
+		input.PublicID,
+		input.Title,
+		input.Description,
+		managerID,
+		zookeeperID,
+		animalID,
+		input.Recurrence,
+		input.StartsOn,
+		input.EndsOn,
+		input.LeadDays,
+		input.Active,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return input.PublicID, tx.Commit(ctx)
+}
+
+func (r *taskTemplateRepository) ListByManager(
+	ctx context.Context,
+	managerPublicID string,
+	scope []string,
+) ([]ports.TaskTemplateDTO, error) {
+	return r.list(ctx, taskTemplateSelect+`
+		WHERE m.public_id = $1
+		  AND ($2::uuid[] IS NULL OR tt.animal_id IS NULL OR a.cage_id IN (
+			SELECT c.id FROM cages c
+			JOIN areas ar ON ar.id = c.area_id
+			JOIN zones zn ON zn.id = ar.zone_id
+			WHERE zn.public_id = ANY($2)))
+		ORDER BY tt.created_at
+	`, managerPublicID, scope)
+}
+
+func (r *taskTemplateRepository) ListActive(ctx context.Context) ([]ports.TaskTemplateDTO, error) {
+	return r.list(ctx, taskTemplateSelect+`
+		WHERE tt.active
+		ORDER BY tt.id
+	`)
+}
+
+func (r *taskTemplateRepository) list(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]ports.TaskTemplateDTO, error) {
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []ports.TaskTemplateDTO{}
+	for rows.Next() {
+		t, err := scanTaskTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *taskTemplateRepository) FindByID(
+	ctx context.Context,
+	publicID string,
+) (ports.TaskTemplateDTO, error) {
+
+	t, err := scanTaskTemplate(r.db.QueryRow(ctx,
+		taskTemplateSelect+` WHERE tt.public_id = $1`,
+		publicID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.TaskTemplateDTO{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return ports.TaskTemplateDTO{}, err
+	}
+
+	return t, nil
+}
+
+func (r *taskTemplateRepository) Update(
+	ctx context.Context,
+	input ports.TaskTemplateInput,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	zookeeperID, animalID, err := r.resolveAssignment(ctx, tx, input)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := tx.Exec(ctx, `
+		UPDATE task_templates
+		SET title=$1,
+		    description=$2,
+		    zookeeper_id=$3,
+		    animal_id=$4,
+		    recurrence=$5,
+		    starts_on=$6,
+		    ends_on=$7,
+		    lead_days=$8,
+		    active=$9
+		WHERE public_id=$10
+	`,
+		input.Title,
+		input.Description,
+		zookeeperID,
+		animalID,
+		input.Recurrence,
+		input.StartsOn,
+		input.EndsOn,
+		input.LeadDays,
+		input.Active,
+		input.PublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *taskTemplateRepository) Delete(
+	ctx context.Context,
+	publicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx,
+		`DELETE FROM task_templates WHERE public_id=$1`,
+		publicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *taskTemplateRepository) MarkGenerated(
+	ctx context.Context,
+	publicID string,
+	until time.Time,
+) error {
+
+	_, err := r.db.Exec(ctx, `
+		UPDATE task_templates
+		SET generated_until = GREATEST(COALESCE(generated_until, $1), $1)
+		WHERE public_id=$2
+	`, until, publicID)
+
+	return err
+}
+
+func (r *taskTemplateRepository) resolveAssignment(
+	ctx context.Context,
+	tx pgx.Tx,
+	input ports.TaskTemplateInput,
+) (int64, *int64, error) {
+
+	var zookeeperID int64
+	err := tx.QueryRow(ctx,
+		`SELECT id FROM users WHERE public_id=$1 AND role='ZOOKEEPER'`,
+		input.ZookeeperPublicID,
+	).Scan(&zookeeperID)
+	if err != nil {
+		return 0, nil, errors.New("zookeeper not found")
+	}
+
+	if input.AnimalPublicID == nil {
+		return zookeeperID, nil, nil
+	}
+
+	var animalID int64
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM animals WHERE public_id=$1`,
+		*input.AnimalPublicID,
+	).Scan(&animalID)
+	if err != nil {
+		return 0, nil, errors.New("animal not found")
+	}
+
+	return zookeeperID, &animalID, nil
+}
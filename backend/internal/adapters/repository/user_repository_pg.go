@@ -3,10 +3,12 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 	"wit-leisure-park/backend/internal/ports"
 
 	"wit-leisure-park/backend/internal/domain"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -20,7 +22,7 @@ func NewUserRepository(db *pgxpool.Pool) ports.UserRepository {
 
 func (r *userRepository) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
 	query := `
-		SELECT id, public_id, username, password_hash, role
+		SELECT id, public_id, username, password_hash, role, must_change_password, locked_until
 		FROM users
 		WHERE username = $1
 	`
@@ -34,6 +36,8 @@ func (r *userRepository) FindByUsername(ctx context.Context, username string) (*
 		&user.Username,
 		&user.PasswordHash,
 		&user.Role,
+		&user.MustChangePassword,
+		&user.LockedUntil,
 	)
 
 	if err != nil {
@@ -42,3 +46,286 @@ func (r *userRepository) FindByUsername(ctx context.Context, username string) (*
 
 	return &user, nil
 }
+
+func (r *userRepository) FindByPublicID(ctx context.Context, publicID string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.QueryRow(ctx, `
+		SELECT id, public_id, username, password_hash, role, must_change_password, locked_until
+		FROM users
+		WHERE public_id = $1
+	`, publicID).Scan(
+		&user.ID,
+		&user.PublicID,
+		&user.Username,
+		&user.PasswordHash,
+		&user.Role,
+		&user.MustChangePassword,
+		&user.LockedUntil,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ports.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *userRepository) UpdatePassword(
+	ctx context.Context,
+	publicID string,
+	passwordHash string,
+	mustChange bool,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE users
+		SET password_hash = $1,
+		    must_change_password = $2,
+		    password_changed_at = NOW()
+		WHERE public_id = $3
+	`, passwordHash, mustChange, publicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) CreatePasswordReset(
+	ctx context.Context,
+	input ports.PasswordResetInput,
+) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var userID int64
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM users WHERE public_id=$1`,
+		input.UserPublicID,
+	).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	// only the most recently issued token stays valid
+	_, err = tx.Exec(ctx, `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE user_id=$1 AND used_at IS NULL
+	`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO password_reset_tokens (user_id, token_hash, created_by, expires_at)
+		VALUES ($1, $2, (SELECT id FROM users WHERE public_id=$3), $4)
+	`, userID, input.TokenHash, input.CreatedByPublicID, input.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	if input.ForceChange {
+		_, err = tx.Exec(ctx,
+			`UPDATE users SET must_change_password = TRUE WHERE id=$1`,
+			userID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *userRepository) ConsumePasswordReset(
+	ctx context.Context,
+	tokenHash string,
+	passwordHash string,
+) (string, error) {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var userID int64
+	err = tx.QueryRow(ctx, `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE token_hash=$1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id
+	`, tokenHash).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ports.ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var publicID string
+	err = tx.QueryRow(ctx, `
+		UPDATE users
+		SET password_hash = $1,
+		    must_change_password = FALSE,
+		    password_changed_at = NOW()
+		WHERE id = $2
+		RETURNING public_id
+	`, passwordHash, userID).Scan(&publicID)
+	if err != nil {
+		return "", err
+	}
+
+	return publicID, tx.Commit(ctx)
+}
+
+func (r *userRepository) IncrementFailedLogins(
+	ctx context.Context,
+	publicID string,
+) (int, int, error) {
+
+	var failed, lockouts int
+	err := r.db.QueryRow(ctx, `
+		UPDATE users
+		SET failed_login_count = failed_login_count + 1
+		WHERE public_id = $1
+		RETURNING failed_login_count, lockout_count
+	`, publicID).Scan(&failed, &lockouts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, 0, ports.ErrNotFound
+	}
+
+	return failed, lockouts, err
+}
+
+func (r *userRepository) Lock(
+	ctx context.Context,
+	publicID string,
+	until time.Time,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE users
+		SET locked_until = $1,
+		    lockout_count = lockout_count + 1,
+		    failed_login_count = 0
+		WHERE public_id = $2
+	`, until, publicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) ResetFailedLogins(
+	ctx context.Context,
+	publicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE users
+		SET failed_login_count = 0,
+		    lockout_count = 0,
+		    locked_until = NULL
+		WHERE public_id = $1
+	`, publicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) FindLockState(
+	ctx context.Context,
+	publicID string,
+) (ports.UserLockState, error) {
+
+	var l ports.UserLockState
+	err := r.db.QueryRow(ctx, `
+		SELECT public_id, username, role, failed_login_count, lockout_count,
+		       locked_until, COALESCE(locked_until > NOW(), FALSE)
+		FROM users
+		WHERE public_id = $1
+	`, publicID).Scan(
+		&l.PublicID,
+		&l.Username,
+		&l.Role,
+		&l.FailedLoginCount,
+		&l.LockoutCount,
+		&l.LockedUntil,
+		&l.Locked,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.UserLockState{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return ports.UserLockState{}, err
+	}
+
+	return l, nil
+}
+
+func (r *userRepository) ListLocked(
+	ctx context.Context,
+	managerPublicID string,
+) ([]ports.UserLockState, error) {
+
+	rows, err := r.db.Query(ctx, `
+		SELECT public_id, username, role, failed_login_count, lockout_count,
+		       locked_until, TRUE
+		FROM users
+		WHERE locked_until > NOW()
+		  AND id IN (
+			SELECT zk.user_id FROM zookeepers zk
+			JOIN users mu ON mu.id = zk.manager_id
+			WHERE mu.public_id = $1)
+		ORDER BY locked_until DESC
+	`, managerPublicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.UserLockState, 0)
+	for rows.Next() {
+		var l ports.UserLockState
+		if err := rows.Scan(
+			&l.PublicID,
+			&l.Username,
+			&l.Role,
+			&l.FailedLoginCount,
+			&l.LockoutCount,
+			&l.LockedUntil,
+			&l.Locked,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, l)
+	}
+
+	return result, rows.Err()
+}
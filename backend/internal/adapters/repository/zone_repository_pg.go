@@ -0,0 +1,377 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"wit-leisure-park/backend/internal/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type zoneRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewZoneRepository(db *pgxpool.Pool) ports.ZoneRepository {
+	return &zoneRepository{db: db}
+}
+
+// zoneManagerID resolves the responsible manager of a zone; nil clears it.
+func (r *zoneRepository) zoneManagerID(ctx context.Context, publicID *string) (*int64, error) {
+	if publicID == nil {
+		return nil, nil
+	}
+
+	var id int64
+	err := r.db.QueryRow(ctx,
+		`SELECT id FROM users WHERE public_id=$1 AND role='MANAGER'`,
+		*publicID,
+	).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: manager %s", ports.ErrNotFound, *publicID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func (r *zoneRepository) Create(
+	ctx context.Context,
+	input ports.ZoneInput,
+) error {
+
+	managerID, err := r.zoneManagerID(ctx, input.ManagerPublicID)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := r.db.Exec(ctx, `
+		INSERT INTO zones (public_id, code, name, description, manager_id)
+		VALUES ($1,$2,$3,$4,$5)
+		ON CONFLICT (code) DO NOTHING
+	`, input.PublicID, input.Code, input.Name, input.Description, managerID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: zone code %s", ports.ErrDuplicate, input.Code)
+	}
+
+	return nil
+}
+
+const zoneSelect = `
+	SELECT z.public_id, z.code, z.name, z.description, u.public_id, m.name,
+	       (SELECT COUNT(*) FROM areas ar WHERE ar.zone_id = z.id),
+	       (SELECT COUNT(*) FROM cages c JOIN areas ar ON ar.id = c.area_id
+	        WHERE ar.zone_id = z.id)
+	FROM zones z
+	LEFT JOIN users u ON u.id = z.manager_id
+	LEFT JOIN zookeeper_managers m ON m.user_id = z.manager_id
+`
+
+func scanZone(row pgx.Row) (ports.ZoneDTO, error) {
+	var z ports.ZoneDTO
+	err := row.Scan(
+		&z.PublicID,
+		&z.Code,
+		&z.Name,
+		&z.Description,
+		&z.ManagerPublicID,
+		&z.ManagerName,
+		&z.AreaCount,
+		&z.CageCount,
+	)
+	return z, err
+}
+
+func (r *zoneRepository) List(ctx context.Context) ([]ports.ZoneDTO, error) {
+
+	rows, err := r.db.Query(ctx, zoneSelect+` ORDER BY z.code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ports.ZoneDTO, 0)
+	for rows.Next() {
+		z, err := scanZone(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, z)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *zoneRepository) FindByID(
+	ctx context.Context,
+	publicID string,
+) (ports.ZoneDTO, error) {
+
+	z, err := scanZone(r.db.QueryRow(ctx, zoneSelect+` WHERE z.public_id=$1`, publicID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ZoneDTO{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return ports.ZoneDTO{}, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT ar.public_id, ar.name, ar.description,
+		       (SELECT COUNT(*) FROM cages c WHERE c.area_id = ar.id)
+		FROM areas ar
+		JOIN zones z ON z.id = ar.zone_id
+		WHERE z.public_id = $1
+		ORDER BY ar.name
+	`, publicID)
+	if err != nil {
+		return ports.ZoneDTO{}, err
+	}
+	defer rows.Close()
+
+	z.Areas = make([]ports.AreaDTO, 0)
+	for rows.Next() {
+		a := ports.AreaDTO{ZonePublicID: z.PublicID}
+		if err := rows.Scan(&a.PublicID, &a.Name, &a.Description, &a.CageCount); err != nil {
+			return ports.ZoneDTO{}, err
+		}
+		z.Areas = append(z.Areas, a)
+	}
+
+	return z, rows.Err()
+}
+
+func (r *zoneRepository) Update(
+	ctx context.Context,
+	input ports.ZoneInput,
+) error {
+
+	managerID, err := r.zoneManagerID(ctx, input.ManagerPublicID)
+	if err != nil {
+		return err
+	}
+
+	var codeTaken bool
+	err = r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM zones WHERE code=$1 AND public_id<>$2)`,
+		input.Code, input.PublicID,
+	).Scan(&codeTaken)
+	if err != nil {
+		return err
+	}
+	if codeTaken {
+		return fmt.Errorf("%w: zone code %s", ports.ErrDuplicate, input.Code)
+	}
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE zones
+		SET code=$2, name=$3, description=$4, manager_id=$5, updated_at=NOW()
+		WHERE public_id=$1
+	`, input.PublicID, input.Code, input.Name, input.Description, managerID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *zoneRepository) Delete(
+	ctx context.Context,
+	publicID string,
+) error {
+
+	cmd, err := r.db.Exec(ctx, `
+		DELETE FROM zones z
+		WHERE z.public_id=$1
+		  AND NOT EXISTS (SELECT 1 FROM areas ar WHERE ar.zone_id = z.id)
+	`, publicID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		if _, err := r.FindByID(ctx, publicID); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: zone still has areas", ports.ErrInUse)
+	}
+
+	return nil
+}
+
+func (r *zoneRepository) CreateArea(
+	ctx context.Context,
+	input ports.AreaInput,
+) error {
+
+	var zoneID int64
+	err := r.db.QueryRow(ctx,
+		`SELECT id FROM zones WHERE public_id=$1`,
+		input.ZonePublicID,
+	).Scan(&zoneID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	cmd, err := r.db.Exec(ctx, `
+		INSERT INTO areas (public_id, zone_id, name, description)
+		VALUES ($1,$2,$3,$4)
+		ON CONFLICT (zone_id, name) DO NOTHING
+	`, input.PublicID, zoneID, input.Name, input.Description)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: area %s", ports.ErrDuplicate, input.Name)
+	}
+
+	return nil
+}
+
+func (r *zoneRepository) UpdateArea(
+	ctx context.Context,
+	input ports.AreaInput,
+) error {
+
+	var nameTaken bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM areas ar
+			JOIN zones z ON z.id = ar.zone_id
+			WHERE z.public_id = $1 AND ar.name = $2 AND ar.public_id <> $3
+		)
+	`, input.ZonePublicID, input.Name, input.PublicID).Scan(&nameTaken)
+	if err != nil {
+		return err
+	}
+	if nameTaken {
+		return fmt.Errorf("%w: area %s", ports.ErrDuplicate, input.Name)
+	}
+
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE areas ar
+		SET name=$3, description=$4
+		FROM zones z
+		WHERE z.id = ar.zone_id AND z.public_id=$1 AND ar.public_id=$2
+	`, input.ZonePublicID, input.PublicID, input.Name, input.Description)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ports.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *zoneRepository) DeleteArea(
+	ctx context.Context,
+	zonePublicID, areaPublicID string,
+) error {
+
+	var areaID int64
+	var cages int
+	err := r.db.QueryRow(ctx, `
+		SELECT ar.id, (SELECT COUNT(*) FROM cages c WHERE c.area_id = ar.id)
+		FROM areas ar
+		JOIN zones z ON z.id = ar.zone_id
+		WHERE z.public_id = $1 AND ar.public_id = $2
+	`, zonePublicID, areaPublicID).Scan(&areaID, &cages)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if cages > 0 {
+		return fmt.Errorf("%w: %d cages are placed in the area", ports.ErrInUse, cages)
+	}
+
+	_, err = r.db.Exec(ctx, `DELETE FROM areas WHERE id=$1`, areaID)
+	return err
+}
+
+func (r *zoneRepository) ManagedBy(
+	ctx context.Context,
+	managerPublicID string,
+) ([]string, error) {
+
+	rows, err := r.db.Query(ctx, `
+		SELECT z.public_id
+		FROM zones z
+		JOIN users u ON u.id = z.manager_id
+		WHERE u.public_id = $1
+		ORDER BY z.code
+	`, managerPublicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result = append(result, id)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *zoneRepository) AreaZone(
+	ctx context.Context,
+	areaPublicID string,
+) (string, error) {
+
+	var zone string
+	err := r.db.QueryRow(ctx, `
+		SELECT z.public_id
+		FROM areas ar
+		JOIN zones z ON z.id = ar.zone_id
+		WHERE ar.public_id = $1
+	`, areaPublicID).Scan(&zone)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("%w: area %s", ports.ErrNotFound, areaPublicID)
+	}
+
+	return zone, err
+}
+
+func (r *zoneRepository) CageZone(
+	ctx context.Context,
+	cagePublicID string,
+) (*string, error) {
+
+	var zone *string
+	err := r.db.QueryRow(ctx, `
+		SELECT z.public_id
+		FROM cages c
+		LEFT JOIN areas ar ON ar.id = c.area_id
+		LEFT JOIN zones z ON z.id = ar.zone_id
+		WHERE c.public_id = $1
+	`, cagePublicID).Scan(&zone)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ports.ErrNotFound
+	}
+
+	return zone, err
+}
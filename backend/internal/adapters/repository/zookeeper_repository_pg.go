@@ -74,7 +74,43 @@ func (r *zookeeperRepository) Create(
 	return publicID, tx.Commit(ctx)
 }
 
-func (r *zookeeperRepository) List(ctx context.Context) ([]ports.ZookeeperDTO, error) {
+var zookeeperSortColumns = map[string]string{
+	"name":     "z.name",
+	"username": "u.username",
+	"manager":  "zm.name",
+}
+
+func (r *zookeeperRepository) List(
+	ctx context.Context,
+	query ports.ZookeeperListQuery,
+) (ports.Page[ports.ZookeeperDTO], error) {
+
+	f := listFilter{conds: []string{"u.role = 'ZOOKEEPER'"}}
+	if query.ManagerPublicID != nil {
+		f.add("m.public_id = ?", *query.ManagerPublicID)
+	}
+	if query.Search != nil {
+		f.add("(z.name ILIKE '%' || ? || '%' OR u.username ILIKE '%' || ? || '%')", *query.Search)
+	}
+
+	order, err := orderBy(query.Sort, zookeeperSortColumns, "name", "u.id")
+	if err != nil {
+		return ports.Page[ports.ZookeeperDTO]{}, err
+	}
+
+	from := `
+		FROM users u
+		JOIN zookeepers z ON z.user_id = u.id
+		JOIN users m ON z.manager_id = m.id
+		JOIN zookeeper_managers zm ON zm.user_id = m.id
+	` + f.where()
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*)`+from, f.args...).Scan(&total); err != nil {
+		return ports.Page[ports.ZookeeperDTO]{}, err
+	}
+
+	limit, args := f.page(query.ListParams)
 	rows, err := r.db.Query(ctx, `
 		SELECT 
 			u.public_id,
@@ -82,14 +118,9 @@ func (r *zookeeperRepository) List(ctx context.Context) ([]ports.ZookeeperDTO, e
 			z.name,
 			m.public_id,
 			zm.name
-		FROM users u
-		JOIN zookeepers z ON z.user_id = u.id
-		JOIN users m ON z.manager_id = m.id
-		JOIN zookeeper_managers zm ON zm.user_id = m.id
-		WHERE u.role = 'ZOOKEEPER'
-	`)
+	`+from+order+limit, args...)
 	if err != nil {
-		return nil, err
+		return ports.Page[ports.ZookeeperDTO]{}, err
 	}
 	defer rows.Close()
 
@@ -103,12 +134,12 @@ func (r *zookeeperRepository) List(ctx context.Context) ([]ports.ZookeeperDTO, e
 			&z.ManagerID,
 			&z.ManagerName,
 		); err != nil {
-			return nil, err
+			return ports.Page[ports.ZookeeperDTO]{}, err
 		}
 		result = append(result, z)
 	}
 
-	return result, nil
+	return ports.NewPage(result, total, query.ListParams), rows.Err()
 }
 
 func (r *zookeeperRepository) FindByID(
@@ -145,6 +176,26 @@ func (r *zookeeperRepository) FindByID(
 	return z, nil
 }
 
+// InTeam reports whether the zookeeper reports to the manager.
+func (r *zookeeperRepository) InTeam(
+	ctx context.Context,
+	publicID string,
+	managerPublicID string,
+) (bool, error) {
+
+	var exists bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1
+			FROM zookeepers z
+			JOIN users u ON u.id = z.user_id
+			JOIN users m ON m.id = z.manager_id
+			WHERE u.public_id = $1 AND u.role = 'ZOOKEEPER' AND m.public_id = $2
+		)
+	`, publicID, managerPublicID).Scan(&exists)
+	return exists, err
+}
+
 func (r *zookeeperRepository) Update(
 	ctx context.Context,
 	publicID string,
@@ -0,0 +1,13 @@
+package application
+
+import "wit-leisure-park/backend/internal/domain"
+
+// Actor identifies the authenticated user a service call is made on behalf of.
+type Actor struct {
+	PublicID string
+	Role     domain.Role
+}
+
+func (a Actor) IsManager() bool {
+	return a.Role == domain.RoleManager
+}
@@ -2,30 +2,45 @@ package application
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
+	"wit-leisure-park/backend/internal/domain/animal"
 	"wit-leisure-park/backend/internal/infrastructure/id"
 
 	"wit-leisure-park/backend/internal/ports"
 )
 
 type AnimalService struct {
-	repo  ports.AnimalRepository
-	idGen *id.UUIDGenerator
+	repo   ports.AnimalRepository
+	compat *CompatibilityService
+	zones  *ZoneService
+	idGen  *id.UUIDGenerator
 }
 
 func NewAnimalService(
 	repo ports.AnimalRepository,
+	compat *CompatibilityService,
+	zones *ZoneService,
 	idGen *id.UUIDGenerator,
 ) *AnimalService {
-	return &AnimalService{repo: repo, idGen: idGen}
+	return &AnimalService{repo: repo, compat: compat, zones: zones, idGen: idGen}
 }
 
+// Create places a new animal in a cage. overrideReason lets a manager
+// approve sharing the cage with species that require approval.
 func (s *AnimalService) Create(
 	ctx context.Context,
-	name, species, cagePublicID string,
+	actor Actor,
+	name, speciesPublicID, cagePublicID string,
 	dateOfBirth *time.Time,
+	overrideReason *string,
 ) (ports.AnimalDTO, error) {
 
+	if err := s.zones.CheckCage(ctx, actor, cagePublicID); err != nil {
+		return ports.AnimalDTO{}, err
+	}
+
 	publicID, err := s.idGen.NewID()
 	if err != nil {
 		return ports.AnimalDTO{}, err
@@ -35,45 +50,158 @@ func (s *AnimalService) Create(
 		ctx,
 		publicID,
 		name,
-		species,
+		speciesPublicID,
 		cagePublicID,
 		dateOfBirth,
+		s.compat.Guard(actor, overrideReason),
 	)
 	if err != nil {
 		return ports.AnimalDTO{}, err
 	}
 
-	return ports.AnimalDTO{
-		PublicID:    animalID,
-		Name:        name,
-		Species:     species,
-		CageID:      cagePublicID,
-		DateOfBirth: dateOfBirth,
-	}, nil
+	return s.repo.FindByID(ctx, animalID)
 }
 
-func (s *AnimalService) List(ctx context.Context) ([]ports.AnimalDTO, error) {
-	return s.repo.List(ctx)
+func (s *AnimalService) List(
+	ctx context.Context,
+	actor Actor,
+	query ports.AnimalListQuery,
+) (ports.Page[ports.AnimalDTO], error) {
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return ports.Page[ports.AnimalDTO]{}, err
+	}
+	query.ScopeZonePublicIDs = scope
+
+	return s.repo.List(ctx, query)
 }
 
+// FindByID hides animals outside the actor's zones as not found.
 func (s *AnimalService) FindByID(
 	ctx context.Context,
+	actor Actor,
 	publicID string,
 ) (ports.AnimalDTO, error) {
-	return s.repo.FindByID(ctx, publicID)
+
+	a, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return ports.AnimalDTO{}, err
+	}
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return ports.AnimalDTO{}, err
+	}
+	if !inScope(scope, a.ZonePublicID) {
+		return ports.AnimalDTO{}, ports.ErrNotFound
+	}
+
+	return a, nil
 }
 
+// Update edits an animal. Compatibility is only checked when the animal
+// changes cage or species, so existing approved placements stay editable.
 func (s *AnimalService) Update(
 	ctx context.Context,
-	publicID, name, species, cagePublicID string,
+	actor Actor,
+	publicID, name, speciesPublicID, cagePublicID string,
 	dateOfBirth *time.Time,
+	overrideReason *string,
 ) error {
-	return s.repo.Update(ctx, publicID, name, species, cagePublicID, dateOfBirth)
+
+	current, err := s.FindByID(ctx, actor, publicID)
+	if err != nil {
+		return err
+	}
+
+	if current.CageID != cagePublicID {
+		if err := s.zones.CheckCage(ctx, actor, cagePublicID); err != nil {
+			return err
+		}
+	}
+
+	var guard ports.PlacementGuard
+	if current.CageID != cagePublicID || current.SpeciesPublicID != speciesPublicID {
+		guard = s.compat.Guard(actor, overrideReason)
+	}
+
+	return s.repo.Update(ctx, publicID, name, speciesPublicID, cagePublicID, dateOfBirth, guard)
+}
+
+// ChangeStatus moves the animal through its lifecycle. Leaving the park
+// ends its cage stay; coming back reclaims a slot in the cage it left.
+// A nil effectiveAt means now.
+func (s *AnimalService) ChangeStatus(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	to ports.AnimalStatus,
+	reason string,
+	effectiveAt *time.Time,
+	overrideReason *string,
+) (ports.AnimalDTO, error) {
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ports.AnimalDTO{}, fmt.Errorf("%w: reason is required", ErrInvalidStatusChange)
+	}
+	if effectiveAt != nil && effectiveAt.After(time.Now().Add(5*time.Minute)) {
+		return ports.AnimalDTO{}, fmt.Errorf("%w: effective_at must not be in the future", ErrInvalidStatusChange)
+	}
+
+	current, err := s.FindByID(ctx, actor, publicID)
+	if err != nil {
+		return ports.AnimalDTO{}, err
+	}
+
+	if err := animal.ValidateTransition(current.Status, to); err != nil {
+		return ports.AnimalDTO{}, err
+	}
+
+	// an animal coming back joins whoever lives in its cage now
+	var guard ports.PlacementGuard
+	if !current.Status.InPark() && to.InPark() {
+		guard = s.compat.Guard(actor, overrideReason)
+	}
+
+	err = s.repo.ChangeStatus(ctx, ports.AnimalStatusChange{
+		PublicID:      publicID,
+		From:          current.Status,
+		To:            to,
+		Reason:        reason,
+		EffectiveAt:   effectiveAt,
+		ActorPublicID: actor.PublicID,
+	}, guard)
+	if err != nil {
+		return ports.AnimalDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, publicID)
+}
+
+func (s *AnimalService) StatusHistory(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) ([]ports.AnimalStatusHistoryDTO, error) {
+
+	if _, err := s.FindByID(ctx, actor, publicID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListStatusHistory(ctx, publicID)
 }
 
 func (s *AnimalService) Delete(
 	ctx context.Context,
+	actor Actor,
 	publicID string,
 ) error {
+
+	if _, err := s.FindByID(ctx, actor, publicID); err != nil {
+		return err
+	}
+
 	return s.repo.Delete(ctx, publicID)
 }
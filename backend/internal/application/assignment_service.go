@@ -0,0 +1,282 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/domain/leave"
+	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
+)
+
+// Points of the assignment score. Every candidate starts at zero; the
+// highest score wins and ties go to the lighter workload.
+const (
+	scoreOnShift      = 30
+	scoreCageShift    = 20
+	scoreZoneShift    = 10
+	scorePendingLeave = -10
+	scorePerOpenTask  = -5
+)
+
+// AssignmentCandidate is one zookeeper of the team with the score they
+// got and why. Ineligible candidates are on leave or miss a certification.
+type AssignmentCandidate struct {
+	ZookeeperPublicID string   `json:"zookeeper_public_id"`
+	Zookeeper         string   `json:"zookeeper"`
+	Name              string   `json:"name"`
+	Eligible          bool     `json:"eligible"`
+	Score             int      `json:"score"`
+	OpenTasks         int      `json:"open_tasks"`
+	Reasons           []string `json:"reasons"`
+}
+
+// Assignment is the zookeeper chosen for a task and the ranking of the
+// whole team, best first.
+type Assignment struct {
+	ZookeeperPublicID string                `json:"zookeeper_public_id"`
+	Zookeeper         string                `json:"zookeeper"`
+	Score             int                   `json:"score"`
+	Reasons           []string              `json:"reasons"`
+	Candidates        []AssignmentCandidate `json:"candidates"`
+}
+
+// AssignmentService picks the zookeeper of a manager's team best placed to
+// take a task on its due date.
+type AssignmentService struct {
+	tasks      ports.TaskRepository
+	animals    *AnimalService
+	zookeepers *ZookeeperService
+	shifts     *ShiftService
+	leave      *LeaveService
+	skills     *SkillService
+}
+
+func NewAssignmentService(
+	tasks ports.TaskRepository,
+	animals *AnimalService,
+	zookeepers *ZookeeperService,
+	shifts *ShiftService,
+	leave *LeaveService,
+	skills *SkillService,
+) *AssignmentService {
+	return &AssignmentService{
+		tasks:      tasks,
+		animals:    animals,
+		zookeepers: zookeepers,
+		shifts:     shifts,
+		leave:      leave,
+		skills:     skills,
+	}
+}
+
+// Choose ranks the manager's zookeepers for a task due on the day. Those
+// on approved leave or without the certifications the animal's species
+// needs are left out. The others score for being on shift that day, more
+// so at the animal's cage or in its zone, and lose points for open tasks
+// and pending leave. The winner is checked against the team once more so a
+// task is never handed to someone else's zookeeper.
+func (s *AssignmentService) Choose(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID *string,
+	day time.Time,
+) (Assignment, error) {
+
+	date := day.Format(utils.DateLayout)
+
+	team, err := s.leave.Availability(ctx, &day, &actor.PublicID)
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	var animal *ports.AnimalDTO
+	var required []ports.SkillDTO
+	if animalPublicID != nil {
+		a, err := s.animals.FindByID(ctx, actor, *animalPublicID)
+		if err != nil {
+			return Assignment{}, err
+		}
+		animal = &a
+
+		required, err = s.skills.SpeciesSkills(ctx, a.SpeciesPublicID)
+		if err != nil {
+			return Assignment{}, err
+		}
+	}
+
+	ids := make([]string, len(team))
+	for i, z := range team {
+		ids[i] = z.ZookeeperPublicID
+	}
+	openTasks, err := s.tasks.OpenCounts(ctx, ids)
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	shifts, err := s.shifts.OnDay(ctx, day, &actor.PublicID)
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	pending, err := s.leave.List(ctx, actor, ports.LeaveQuery{
+		ManagerPublicID: &actor.PublicID,
+		Statuses:        []ports.LeaveStatus{leave.StatusPending},
+		From:            day,
+		To:              day,
+	})
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	candidates := make([]AssignmentCandidate, 0, len(team))
+	for _, z := range team {
+		c := AssignmentCandidate{
+			ZookeeperPublicID: z.ZookeeperPublicID,
+			Zookeeper:         z.Zookeeper,
+			Name:              z.Name,
+			Eligible:          true,
+			OpenTasks:         openTasks[z.ZookeeperPublicID],
+			Reasons:           make([]string, 0),
+		}
+
+		if !z.Available {
+			c.Eligible = false
+			c.Reasons = append(c.Reasons, fmt.Sprintf(
+				"on %s leave until %s",
+				strings.ToLower(string(*z.LeaveType)), z.LeaveEndsOn.Format(utils.DateLayout),
+			))
+		}
+
+		err := s.skills.Check(ctx, z.ZookeeperPublicID, animalPublicID, nil, day)
+		var notCertified *CertificationError
+		switch {
+		case errors.As(err, &notCertified):
+			c.Eligible = false
+			codes := make([]string, len(notCertified.Missing))
+			for i, m := range notCertified.Missing {
+				codes[i] = m.Code
+			}
+			c.Reasons = append(c.Reasons, "not certified for "+strings.Join(codes, ", "))
+		case err != nil:
+			return Assignment{}, err
+		case len(required) > 0:
+			codes := make([]string, len(required))
+			for i, r := range required {
+				codes[i] = r.Code
+			}
+			c.Reasons = append(c.Reasons, "certified for "+strings.Join(codes, ", "))
+		}
+
+		if !c.Eligible {
+			candidates = append(candidates, c)
+			continue
+		}
+
+		scoreShifts(&c, shifts, animal, date)
+
+		if c.OpenTasks > 0 {
+			c.Score += scorePerOpenTask * c.OpenTasks
+			c.Reasons = append(c.Reasons, fmt.Sprintf(
+				"%d open tasks (%+d)", c.OpenTasks, scorePerOpenTask*c.OpenTasks,
+			))
+		} else {
+			c.Reasons = append(c.Reasons, "no open tasks")
+		}
+
+		if slices.ContainsFunc(pending, func(l ports.LeaveDTO) bool {
+			return l.ZookeeperPublicID == z.ZookeeperPublicID
+		}) {
+			c.Score += scorePendingLeave
+			c.Reasons = append(c.Reasons, fmt.Sprintf(
+				"has leave pending on %s (%+d)", date, scorePendingLeave,
+			))
+		}
+
+		candidates = append(candidates, c)
+	}
+
+	slices.SortStableFunc(candidates, func(a, b AssignmentCandidate) int {
+		if a.Eligible != b.Eligible {
+			if a.Eligible {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(a.OpenTasks, b.OpenTasks),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	if len(candidates) == 0 || !candidates[0].Eligible {
+		return Assignment{}, &NoAssigneeError{Candidates: candidates}
+	}
+
+	best := candidates[0]
+	if err := s.zookeepers.CheckTeam(ctx, actor, best.ZookeeperPublicID); err != nil {
+		return Assignment{}, err
+	}
+
+	return Assignment{
+		ZookeeperPublicID: best.ZookeeperPublicID,
+		Zookeeper:         best.Zookeeper,
+		Score:             best.Score,
+		Reasons:           best.Reasons,
+		Candidates:        candidates,
+	}, nil
+}
+
+// scoreShifts adds the points for being on shift on the day and for a
+// shift at the animal's cage or in its zone. Only the best shift counts.
+func scoreShifts(
+	c *AssignmentCandidate,
+	shifts []ports.ShiftDTO,
+	animal *ports.AnimalDTO,
+	date string,
+) {
+
+	onShift := false
+	affinity, reason := 0, ""
+
+	for _, sh := range shifts {
+		if sh.ZookeeperPublicID != c.ZookeeperPublicID {
+			continue
+		}
+		onShift = true
+
+		if animal == nil {
+			continue
+		}
+		switch {
+		case sh.CagePublicID != nil && *sh.CagePublicID == animal.CageID:
+			if affinity < scoreCageShift {
+				affinity = scoreCageShift
+				reason = fmt.Sprintf("on shift at the animal's cage %s (%+d)", *sh.Cage, scoreCageShift)
+			}
+		case sh.ZonePublicID != nil && animal.ZonePublicID != nil && *sh.ZonePublicID == *animal.ZonePublicID:
+			if affinity < scoreZoneShift {
+				affinity = scoreZoneShift
+				reason = fmt.Sprintf("on shift in the animal's zone %s (%+d)", *sh.Zone, scoreZoneShift)
+			}
+		}
+	}
+
+	if !onShift {
+		c.Reasons = append(c.Reasons, "no shift on "+date)
+		return
+	}
+
+	c.Score += scoreOnShift
+	c.Reasons = append(c.Reasons, fmt.Sprintf("on shift on %s (%+d)", date, scoreOnShift))
+
+	if affinity > 0 {
+		c.Score += affinity
+		c.Reasons = append(c.Reasons, reason)
+	}
+}
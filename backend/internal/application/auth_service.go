@@ -4,28 +4,84 @@ import (
 	"context"
 	"errors"
 	"time"
+	"wit-leisure-park/backend/internal/domain"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/infrastructure/token"
 	"wit-leisure-park/backend/internal/ports"
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	tokenTypeAccess     = "access"
+	tokenTypeMFAPending = "mfa_pending"
+)
+
+type AuthOptions struct {
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	MFAPendingTTL   time.Duration
+}
+
+type AuthTokens struct {
+	AccessToken        string
+	RefreshToken       string
+	ExpiresIn          int64
+	MustChangePassword bool
+}
+
+// LoginResult carries either the issued tokens or, for accounts with MFA
+// enabled, a short-lived token that has to be exchanged via VerifyMFA.
+type LoginResult struct {
+	Tokens       *AuthTokens
+	MFAToken     string
+	MFAExpiresIn int64
+}
+
 type AuthService struct {
-	userRepo  ports.UserRepository
-	jwtSecret string
+	userRepo    ports.UserRepository
+	sessionRepo ports.SessionRepository
+	lockout     *LockoutService
+	mfa         *MFAService
+	idGen       *id.UUIDGenerator
+	opts        AuthOptions
 }
 
-func NewAuthService(repo ports.UserRepository, secret string) *AuthService {
+func NewAuthService(
+	repo ports.UserRepository,
+	sessionRepo ports.SessionRepository,
+	lockout *LockoutService,
+	mfa *MFAService,
+	idGen *id.UUIDGenerator,
+	opts AuthOptions,
+) *AuthService {
 	return &AuthService{
-		userRepo:  repo,
-		jwtSecret: secret,
+		userRepo:    repo,
+		sessionRepo: sessionRepo,
+		lockout:     lockout,
+		mfa:         mfa,
+		idGen:       idGen,
+		opts:        opts,
 	}
 }
 
-func (s *AuthService) Login(ctx context.Context, username, password string) (string, error) {
+func (s *AuthService) Login(
+	ctx context.Context,
+	username, password, clientIP string,
+) (LoginResult, error) {
+
+	if err := s.lockout.CheckIP(ctx, clientIP); err != nil {
+		return LoginResult{}, err
+	}
+
 	user, err := s.userRepo.FindByUsername(ctx, username)
 	if err != nil {
-		return "", errors.New("invalid credentials")
+		if err := s.lockout.RecordFailure(ctx, username, clientIP, nil); err != nil {
+			return LoginResult{}, err
+		}
+		return LoginResult{}, ErrInvalidCredentials
 	}
 
 	err = bcrypt.CompareHashAndPassword(
@@ -33,21 +89,276 @@ func (s *AuthService) Login(ctx context.Context, username, password string) (str
 		[]byte(password),
 	)
 	if err != nil {
-		return "", errors.New("invalid credentials")
+		// a wrong password never reveals the lock, and while the account
+		// is locked it only counts against the client IP
+		counted := user
+		if s.lockout.CheckUser(user) != nil {
+			counted = nil
+		}
+		if err := s.lockout.RecordFailure(ctx, username, clientIP, counted); err != nil {
+			return LoginResult{}, err
+		}
+		return LoginResult{}, ErrInvalidCredentials
 	}
 
-	claims := jwt.MapClaims{
-		"sub":  user.PublicID,
-		"role": user.Role,
-		"exp":  time.Now().Add(time.Hour * 1).Unix(),
+	if err := s.lockout.CheckUser(user); err != nil {
+		return LoginResult{}, err
+	}
+
+	if err := s.lockout.RecordSuccess(ctx, username, clientIP, user); err != nil {
+		return LoginResult{}, err
+	}
+
+	mfaEnabled, err := s.mfa.IsEnabled(ctx, user.PublicID)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	if mfaEnabled {
+		mfaToken, err := s.signMFAPendingToken(user.PublicID)
+		if err != nil {
+			return LoginResult{}, err
+		}
+
+		return LoginResult{
+			MFAToken:     mfaToken,
+			MFAExpiresIn: int64(s.opts.MFAPendingTTL.Seconds()),
+		}, nil
+	}
+
+	tokens, err := s.startSession(ctx, user.PublicID, user.Role, user.MustChangePassword)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	return LoginResult{Tokens: &tokens}, nil
+}
+
+// VerifyMFA completes a two-step login by exchanging the mfa pending token
+// and a TOTP or recovery code for a regular token pair.
+func (s *AuthService) VerifyMFA(
+	ctx context.Context,
+	mfaToken, code, clientIP string,
+) (AuthTokens, error) {
+
+	userPublicID, err := s.parseMFAPendingToken(mfaToken)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	if err := s.lockout.CheckIP(ctx, clientIP); err != nil {
+		return AuthTokens{}, err
+	}
+
+	user, err := s.userRepo.FindByPublicID(ctx, userPublicID)
+	if errors.Is(err, ports.ErrNotFound) {
+		return AuthTokens{}, ErrInvalidMFAToken
+	}
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	if err := s.lockout.CheckUser(user); err != nil {
+		return AuthTokens{}, err
+	}
+
+	err = s.mfa.Verify(ctx, user.PublicID, code)
+	if errors.Is(err, ErrInvalidMFACode) {
+		if err := s.lockout.RecordFailure(ctx, user.Username, clientIP, user); err != nil {
+			return AuthTokens{}, err
+		}
+		return AuthTokens{}, ErrInvalidMFACode
+	}
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	return s.startSession(ctx, user.PublicID, user.Role, user.MustChangePassword)
+}
+
+// Refresh exchanges a refresh token for a new token pair. The presented
+// token is consumed; presenting it a second time revokes the session.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (AuthTokens, error) {
+	oldHash := token.Hash(refreshToken)
+
+	rec, err := s.sessionRepo.FindRefreshToken(ctx, oldHash)
+	if errors.Is(err, ports.ErrNotFound) {
+		return AuthTokens{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	if rec.SessionRevokedAt != nil {
+		return AuthTokens{}, ErrInvalidRefreshToken
+	}
+
+	if rec.UsedAt != nil {
+		return AuthTokens{}, s.revokeReused(ctx, rec.SessionPublicID)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if time.Now().After(rec.ExpiresAt) {
+		return AuthTokens{}, ErrInvalidRefreshToken
+	}
 
-	signedToken, err := token.SignedString([]byte(s.jwtSecret))
+	newToken, err := token.Generate()
 	if err != nil {
-		return "", err
+		return AuthTokens{}, err
+	}
+
+	rotated, err := s.sessionRepo.Rotate(
+		ctx,
+		oldHash,
+		token.Hash(newToken),
+		time.Now().Add(s.opts.RefreshTokenTTL),
+	)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+	if !rotated {
+		return AuthTokens{}, s.revokeReused(ctx, rec.SessionPublicID)
+	}
+
+	accessToken, err := s.signAccessToken(
+		rec.UserPublicID,
+		rec.Role,
+		rec.SessionPublicID,
+		rec.MustChangePassword,
+	)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	return AuthTokens{
+		AccessToken:        accessToken,
+		RefreshToken:       newToken,
+		ExpiresIn:          int64(s.opts.AccessTokenTTL.Seconds()),
+		MustChangePassword: rec.MustChangePassword,
+	}, nil
+}
+
+// Logout revokes the session the refresh token belongs to. Unknown tokens
+// are ignored so logout stays idempotent.
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	rec, err := s.sessionRepo.FindRefreshToken(ctx, token.Hash(refreshToken))
+	if errors.Is(err, ports.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.sessionRepo.Revoke(ctx, rec.SessionPublicID)
+}
+
+func (s *AuthService) IsSessionActive(ctx context.Context, sessionPublicID string) (bool, error) {
+	return s.sessionRepo.IsActive(ctx, sessionPublicID)
+}
+
+func (s *AuthService) startSession(
+	ctx context.Context,
+	userPublicID string,
+	role domain.Role,
+	mustChangePassword bool,
+) (AuthTokens, error) {
+
+	sessionID, err := s.idGen.NewID()
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	refreshToken, err := token.Generate()
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	err = s.sessionRepo.Create(ctx, ports.SessionCreateInput{
+		PublicID:         sessionID,
+		UserPublicID:     userPublicID,
+		RefreshTokenHash: token.Hash(refreshToken),
+		ExpiresAt:        time.Now().Add(s.opts.RefreshTokenTTL),
+	})
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	accessToken, err := s.signAccessToken(userPublicID, role, sessionID, mustChangePassword)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	return AuthTokens{
+		AccessToken:        accessToken,
+		RefreshToken:       refreshToken,
+		ExpiresIn:          int64(s.opts.AccessTokenTTL.Seconds()),
+		MustChangePassword: mustChangePassword,
+	}, nil
+}
+
+func (s *AuthService) signAccessToken(
+	userPublicID string,
+	role domain.Role,
+	sessionID string,
+	mustChangePassword bool,
+) (string, error) {
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":  userPublicID,
+		"role": role,
+		"sid":  sessionID,
+		"typ":  tokenTypeAccess,
+		"iat":  now.Unix(),
+		"exp":  now.Add(s.opts.AccessTokenTTL).Unix(),
+	}
+	if mustChangePassword {
+		claims["mcp"] = true
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return t.SignedString([]byte(s.opts.JWTSecret))
+}
+
+func (s *AuthService) signMFAPendingToken(userPublicID string) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub": userPublicID,
+		"typ": tokenTypeMFAPending,
+		"iat": now.Unix(),
+		"exp": now.Add(s.opts.MFAPendingTTL).Unix(),
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return t.SignedString([]byte(s.opts.JWTSecret))
+}
+
+func (s *AuthService) parseMFAPendingToken(mfaToken string) (string, error) {
+	t, err := jwt.Parse(mfaToken, func(t *jwt.Token) (interface{}, error) {
+		return []byte(s.opts.JWTSecret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !t.Valid {
+		return "", ErrInvalidMFAToken
+	}
+
+	claims := t.Claims.(jwt.MapClaims)
+	if claims["typ"] != tokenTypeMFAPending {
+		return "", ErrInvalidMFAToken
+	}
+
+	sub, ok := claims["sub"].(string)
+	if !ok || sub == "" {
+		return "", ErrInvalidMFAToken
+	}
+
+	return sub, nil
+}
+
+func (s *AuthService) revokeReused(ctx context.Context, sessionPublicID string) error {
+	if err := s.sessionRepo.Revoke(ctx, sessionPublicID); err != nil {
+		return err
 	}
 
-	return signedToken, nil
+	return ErrRefreshTokenReused
 }
@@ -0,0 +1,699 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/domain/animal"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+const (
+	defaultPedigreeGenerations = 4
+	maxPedigreeGenerations     = 10
+	// generations looked at when a pairing is set up
+	pairingGenerations = 6
+)
+
+// BreedingService keeps studbook data, pairings, pregnancies and births.
+// Zone-scoped managers only work with animals in their zones; pairings,
+// pregnancies and births follow the zone of the dam.
+type BreedingService struct {
+	repo    ports.BreedingRepository
+	animals *AnimalService
+	zones   *ZoneService
+	compat  *CompatibilityService
+	idGen   *id.UUIDGenerator
+}
+
+func NewBreedingService(
+	repo ports.BreedingRepository,
+	animals *AnimalService,
+	zones *ZoneService,
+	compat *CompatibilityService,
+	idGen *id.UUIDGenerator,
+) *BreedingService {
+	return &BreedingService{
+		repo:    repo,
+		animals: animals,
+		zones:   zones,
+		compat:  compat,
+		idGen:   idGen,
+	}
+}
+
+func parseSex(value string) (ports.AnimalSex, error) {
+	sex := ports.AnimalSex(strings.ToUpper(strings.TrimSpace(value)))
+	switch sex {
+	case "":
+		return ports.SexUnknown, nil
+	case ports.SexMale, ports.SexFemale, ports.SexUnknown:
+		return sex, nil
+	default:
+		return "", invalidBreeding("sex must be MALE, FEMALE or UNKNOWN")
+	}
+}
+
+// parent loads a dam or sire and checks it can be a parent of an animal of
+// the species.
+func (s *BreedingService) parent(
+	ctx context.Context,
+	actor Actor,
+	role string,
+	publicID string,
+	speciesPublicID string,
+) (ports.AnimalDTO, error) {
+
+	a, err := s.animals.FindByID(ctx, actor, publicID)
+	if err != nil {
+		return ports.AnimalDTO{}, fmt.Errorf("%s: %w", role, err)
+	}
+	if speciesPublicID != "" && a.SpeciesPublicID != speciesPublicID {
+		return ports.AnimalDTO{}, invalidBreeding(role + " must be of the same species")
+	}
+
+	switch {
+	case role == "dam" && a.Sex == ports.SexMale:
+		return ports.AnimalDTO{}, invalidBreeding("dam must not be male")
+	case role == "sire" && a.Sex == ports.SexFemale:
+		return ports.AnimalDTO{}, invalidBreeding("sire must not be female")
+	}
+
+	return a, nil
+}
+
+// SetStudbook records the sex, parents and identifiers of an animal.
+func (s *BreedingService) SetStudbook(
+	ctx context.Context,
+	actor Actor,
+	input ports.StudbookInput,
+	sex string,
+) (ports.AnimalDTO, error) {
+
+	var err error
+	if input.Sex, err = parseSex(sex); err != nil {
+		return ports.AnimalDTO{}, err
+	}
+	input.DamPublicID = trimmedOrNil(input.DamPublicID)
+	input.SirePublicID = trimmedOrNil(input.SirePublicID)
+	input.MicrochipID = trimmedOrNil(input.MicrochipID)
+	input.StudbookID = trimmedOrNil(input.StudbookID)
+
+	current, err := s.animals.FindByID(ctx, actor, input.AnimalPublicID)
+	if err != nil {
+		return ports.AnimalDTO{}, err
+	}
+
+	if input.DamPublicID != nil {
+		if *input.DamPublicID == input.AnimalPublicID {
+			return ports.AnimalDTO{}, invalidBreeding("an animal cannot be its own dam")
+		}
+		if _, err := s.parent(ctx, actor, "dam", *input.DamPublicID, current.SpeciesPublicID); err != nil {
+			return ports.AnimalDTO{}, err
+		}
+	}
+	if input.SirePublicID != nil {
+		if *input.SirePublicID == input.AnimalPublicID {
+			return ports.AnimalDTO{}, invalidBreeding("an animal cannot be its own sire")
+		}
+		if _, err := s.parent(ctx, actor, "sire", *input.SirePublicID, current.SpeciesPublicID); err != nil {
+			return ports.AnimalDTO{}, err
+		}
+	}
+	if input.DamPublicID != nil && input.SirePublicID != nil && *input.DamPublicID == *input.SirePublicID {
+		return ports.AnimalDTO{}, invalidBreeding("dam and sire must be different animals")
+	}
+
+	if err := s.repo.SetStudbook(ctx, input); err != nil {
+		return ports.AnimalDTO{}, err
+	}
+
+	return s.animals.FindByID(ctx, actor, input.AnimalPublicID)
+}
+
+func pedigreeGenerations(generations int) (int, error) {
+	if generations == 0 {
+		return defaultPedigreeGenerations, nil
+	}
+	if generations < 1 || generations > maxPedigreeGenerations {
+		return 0, invalidBreeding(fmt.Sprintf("generations must be between 1 and %d", maxPedigreeGenerations))
+	}
+
+	return generations, nil
+}
+
+// loadPedigree returns the ancestry of the animals up to the given
+// generations, indexed by public id.
+func (s *BreedingService) loadPedigree(
+	ctx context.Context,
+	publicIDs []string,
+	generations int,
+) (map[string]ports.PedigreeEntry, animal.Pedigree, error) {
+
+	entries, err := s.repo.Ancestors(ctx, publicIDs, generations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[string]ports.PedigreeEntry, len(entries))
+	pedigree := make(animal.Pedigree, len(entries))
+	for _, e := range entries {
+		byID[e.PublicID] = e
+
+		var parents animal.Parents
+		if e.DamPublicID != nil {
+			parents.Dam = *e.DamPublicID
+		}
+		if e.SirePublicID != nil {
+			parents.Sire = *e.SirePublicID
+		}
+		pedigree[e.PublicID] = parents
+	}
+
+	for _, publicID := range publicIDs {
+		if _, ok := byID[publicID]; !ok {
+			return nil, nil, fmt.Errorf("%w: animal %s", ports.ErrNotFound, publicID)
+		}
+	}
+
+	return byID, pedigree, nil
+}
+
+// pedigreeTree builds the ancestry tree of an animal down to depth
+// generations of parents.
+func pedigreeTree(entries map[string]ports.PedigreeEntry, publicID string, depth int) *ports.PedigreeNode {
+	e, ok := entries[publicID]
+	if !ok {
+		return nil
+	}
+
+	node := &ports.PedigreeNode{
+		PublicID:    e.PublicID,
+		Name:        e.Name,
+		Sex:         e.Sex,
+		DateOfBirth: e.DateOfBirth,
+		StudbookID:  e.StudbookID,
+		Status:      e.Status,
+	}
+	if depth == 0 {
+		return node
+	}
+
+	if e.DamPublicID != nil {
+		node.Dam = pedigreeTree(entries, *e.DamPublicID, depth-1)
+	}
+	if e.SirePublicID != nil {
+		node.Sire = pedigreeTree(entries, *e.SirePublicID, depth-1)
+	}
+
+	return node
+}
+
+// Pedigree returns the ancestry tree of an animal and its inbreeding
+// coefficient, computed from the ancestors within the generations shown.
+func (s *BreedingService) Pedigree(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	generations int,
+) (ports.PedigreeDTO, error) {
+
+	generations, err := pedigreeGenerations(generations)
+	if err != nil {
+		return ports.PedigreeDTO{}, err
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, publicID); err != nil {
+		return ports.PedigreeDTO{}, err
+	}
+
+	entries, pedigree, err := s.loadPedigree(ctx, []string{publicID}, generations)
+	if err != nil {
+		return ports.PedigreeDTO{}, err
+	}
+
+	return ports.PedigreeDTO{
+		Generations:           generations,
+		InbreedingCoefficient: pedigree.Inbreeding(publicID),
+		Animal:                *pedigreeTree(entries, publicID, generations),
+	}, nil
+}
+
+// AssessPairing computes the inbreeding coefficient the offspring of a
+// proposed pairing would have, with both parents' ancestry.
+func (s *BreedingService) AssessPairing(
+	ctx context.Context,
+	actor Actor,
+	damPublicID, sirePublicID string,
+	generations int,
+) (ports.PairingAssessmentDTO, error) {
+
+	generations, err := pedigreeGenerations(generations)
+	if err != nil {
+		return ports.PairingAssessmentDTO{}, err
+	}
+
+	if err := s.checkPair(ctx, actor, damPublicID, sirePublicID); err != nil {
+		return ports.PairingAssessmentDTO{}, err
+	}
+
+	entries, pedigree, err := s.loadPedigree(ctx, []string{damPublicID, sirePublicID}, generations)
+	if err != nil {
+		return ports.PairingAssessmentDTO{}, err
+	}
+
+	common := make([]ports.PedigreeEntry, 0)
+	for _, publicID := range pedigree.CommonAncestors(damPublicID, sirePublicID) {
+		common = append(common, entries[publicID])
+	}
+
+	return ports.PairingAssessmentDTO{
+		Generations:           generations,
+		InbreedingCoefficient: pedigree.PairingInbreeding(damPublicID, sirePublicID),
+		CommonAncestors:       common,
+		Dam:                   *pedigreeTree(entries, damPublicID, generations),
+		Sire:                  *pedigreeTree(entries, sirePublicID, generations),
+	}, nil
+}
+
+// checkPair validates that the dam and sire can breed: two different
+// animals of the same species and compatible sexes.
+func (s *BreedingService) checkPair(ctx context.Context, actor Actor, damPublicID, sirePublicID string) error {
+	if damPublicID == "" || sirePublicID == "" {
+		return invalidBreeding("dam_public_id and sire_public_id are required")
+	}
+	if damPublicID == sirePublicID {
+		return invalidBreeding("dam and sire must be different animals")
+	}
+
+	dam, err := s.parent(ctx, actor, "dam", damPublicID, "")
+	if err != nil {
+		return err
+	}
+	_, err = s.parent(ctx, actor, "sire", sirePublicID, dam.SpeciesPublicID)
+
+	return err
+}
+
+func (s *BreedingService) CreatePairing(
+	ctx context.Context,
+	actor Actor,
+	input ports.PairingInput,
+) (ports.PairingDTO, error) {
+
+	if err := s.checkPair(ctx, actor, input.DamPublicID, input.SirePublicID); err != nil {
+		return ports.PairingDTO{}, err
+	}
+
+	for _, publicID := range []string{input.DamPublicID, input.SirePublicID} {
+		a, err := s.animals.FindByID(ctx, actor, publicID)
+		if err != nil {
+			return ports.PairingDTO{}, err
+		}
+		if !a.Status.Active() {
+			return ports.PairingDTO{}, invalidBreeding(a.Name + " is " + strings.ToLower(string(a.Status)))
+		}
+	}
+
+	if input.PairedOn.IsZero() {
+		input.PairedOn = today()
+	}
+
+	_, pedigree, err := s.loadPedigree(ctx, []string{input.DamPublicID, input.SirePublicID}, pairingGenerations)
+	if err != nil {
+		return ports.PairingDTO{}, err
+	}
+	input.Inbreeding = pedigree.PairingInbreeding(input.DamPublicID, input.SirePublicID)
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.PairingDTO{}, err
+	}
+	input.PublicID = publicID
+	input.Notes = trimmedOrNil(input.Notes)
+	input.CreatedByPublicID = actor.PublicID
+
+	if err := s.repo.CreatePairing(ctx, input); err != nil {
+		return ports.PairingDTO{}, err
+	}
+
+	return s.repo.FindPairing(ctx, publicID)
+}
+
+func (s *BreedingService) ListPairings(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID *string,
+) ([]ports.PairingDTO, error) {
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListPairings(ctx, animalPublicID, scope)
+}
+
+func (s *BreedingService) FindPairing(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) (ports.PairingDTO, error) {
+
+	p, err := s.repo.FindPairing(ctx, publicID)
+	if err != nil {
+		return ports.PairingDTO{}, err
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, p.DamPublicID); err != nil {
+		return ports.PairingDTO{}, err
+	}
+
+	return p, nil
+}
+
+func (s *BreedingService) EndPairing(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	separatedOn *time.Time,
+) (ports.PairingDTO, error) {
+
+	current, err := s.FindPairing(ctx, actor, publicID)
+	if err != nil {
+		return ports.PairingDTO{}, err
+	}
+	if current.SeparatedOn != nil {
+		return ports.PairingDTO{}, invalidBreeding("pairing has already ended")
+	}
+
+	date := today()
+	if separatedOn != nil {
+		date = *separatedOn
+	}
+	if date.Before(current.PairedOn) {
+		return ports.PairingDTO{}, invalidBreeding("separated_on must not be before paired_on")
+	}
+
+	if err := s.repo.EndPairing(ctx, publicID, date); err != nil {
+		return ports.PairingDTO{}, err
+	}
+
+	return s.repo.FindPairing(ctx, publicID)
+}
+
+// CreatePregnancy records a pregnancy of the dam. The sire defaults to the
+// one of the pairing when a pairing is given.
+func (s *BreedingService) CreatePregnancy(
+	ctx context.Context,
+	actor Actor,
+	input ports.PregnancyInput,
+) (ports.PregnancyDTO, error) {
+
+	if input.DamPublicID == "" {
+		return ports.PregnancyDTO{}, invalidBreeding("dam_public_id is required")
+	}
+
+	dam, err := s.parent(ctx, actor, "dam", input.DamPublicID, "")
+	if err != nil {
+		return ports.PregnancyDTO{}, err
+	}
+	if !dam.Status.Active() {
+		return ports.PregnancyDTO{}, invalidBreeding(dam.Name + " is " + strings.ToLower(string(dam.Status)))
+	}
+
+	if input.PairingPublicID != nil {
+		pairing, err := s.repo.FindPairing(ctx, *input.PairingPublicID)
+		if err != nil {
+			return ports.PregnancyDTO{}, fmt.Errorf("pairing: %w", err)
+		}
+		if pairing.DamPublicID != input.DamPublicID {
+			return ports.PregnancyDTO{}, invalidBreeding("pairing belongs to another dam")
+		}
+		if input.SirePublicID == nil {
+			input.SirePublicID = &pairing.SirePublicID
+		}
+	}
+	if input.SirePublicID != nil {
+		if _, err := s.parent(ctx, actor, "sire", *input.SirePublicID, dam.SpeciesPublicID); err != nil {
+			return ports.PregnancyDTO{}, err
+		}
+	}
+
+	if input.DetectedOn.IsZero() {
+		input.DetectedOn = today()
+	}
+	if input.ExpectedDueOn != nil && input.ExpectedDueOn.Before(input.DetectedOn) {
+		return ports.PregnancyDTO{}, invalidBreeding("expected_due_on must not be before detected_on")
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.PregnancyDTO{}, err
+	}
+	input.PublicID = publicID
+	input.Notes = trimmedOrNil(input.Notes)
+	input.RecordedByPublicID = actor.PublicID
+
+	if err := s.repo.CreatePregnancy(ctx, input); err != nil {
+		return ports.PregnancyDTO{}, err
+	}
+
+	return s.repo.FindPregnancy(ctx, publicID)
+}
+
+func (s *BreedingService) ListPregnancies(
+	ctx context.Context,
+	actor Actor,
+	status *string,
+) ([]ports.PregnancyDTO, error) {
+
+	var filter *ports.PregnancyStatus
+	if status != nil {
+		st := ports.PregnancyStatus(strings.ToUpper(*status))
+		switch st {
+		case ports.PregnancyOngoing, ports.PregnancyBorn, ports.PregnancyLost:
+		default:
+			return nil, invalidBreeding("status must be ONGOING, BORN or LOST")
+		}
+		filter = &st
+	}
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListPregnancies(ctx, filter, scope)
+}
+
+func (s *BreedingService) FindPregnancy(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) (ports.PregnancyDTO, error) {
+
+	p, err := s.repo.FindPregnancy(ctx, publicID)
+	if err != nil {
+		return ports.PregnancyDTO{}, err
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, p.DamPublicID); err != nil {
+		return ports.PregnancyDTO{}, err
+	}
+
+	return p, nil
+}
+
+// MarkPregnancyLost ends an ongoing pregnancy without a birth.
+func (s *BreedingService) MarkPregnancyLost(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	endedOn *time.Time,
+	notes *string,
+) (ports.PregnancyDTO, error) {
+
+	current, err := s.FindPregnancy(ctx, actor, publicID)
+	if err != nil {
+		return ports.PregnancyDTO{}, err
+	}
+	if current.Status != ports.PregnancyOngoing {
+		return ports.PregnancyDTO{}, invalidBreeding("pregnancy is already " + strings.ToLower(string(current.Status)))
+	}
+
+	date := today()
+	if endedOn != nil {
+		date = *endedOn
+	}
+	if date.Before(current.DetectedOn) {
+		return ports.PregnancyDTO{}, invalidBreeding("ended_on must not be before detected_on")
+	}
+
+	err = s.repo.EndPregnancy(ctx, publicID, ports.PregnancyLost, date, trimmedOrNil(notes))
+	if err != nil {
+		return ports.PregnancyDTO{}, err
+	}
+
+	return s.repo.FindPregnancy(ctx, publicID)
+}
+
+// OffspringRequest is a newborn to register with a birth.
+type OffspringRequest struct {
+	Name        string
+	Sex         string
+	MicrochipID *string
+	StudbookID  *string
+}
+
+// RecordBirth registers a birth and creates an animal for every live
+// newborn, with the dam and sire as parents. Given a pregnancy, the sire
+// defaults to the one of the pregnancy and the pregnancy ends as BORN.
+func (s *BreedingService) RecordBirth(
+	ctx context.Context,
+	actor Actor,
+	input ports.BirthInput,
+	offspring []OffspringRequest,
+	overrideReason *string,
+) (ports.BirthDTO, error) {
+
+	if input.DamPublicID == "" {
+		return ports.BirthDTO{}, invalidBreeding("dam_public_id is required")
+	}
+	if input.StillbornCount < 0 {
+		return ports.BirthDTO{}, invalidBreeding("stillborn_count must not be negative")
+	}
+	if len(offspring) == 0 && input.StillbornCount == 0 {
+		return ports.BirthDTO{}, invalidBreeding("a birth needs offspring or a stillborn_count")
+	}
+
+	if input.BornOn.IsZero() {
+		input.BornOn = today()
+	}
+	if input.BornOn.After(today()) {
+		return ports.BirthDTO{}, invalidBreeding("born_on must not be in the future")
+	}
+
+	dam, err := s.parent(ctx, actor, "dam", input.DamPublicID, "")
+	if err != nil {
+		return ports.BirthDTO{}, err
+	}
+
+	if input.PregnancyPublicID != nil {
+		pregnancy, err := s.FindPregnancy(ctx, actor, *input.PregnancyPublicID)
+		if err != nil {
+			return ports.BirthDTO{}, fmt.Errorf("pregnancy: %w", err)
+		}
+		if pregnancy.DamPublicID != input.DamPublicID {
+			return ports.BirthDTO{}, invalidBreeding("pregnancy belongs to another dam")
+		}
+		if pregnancy.Status != ports.PregnancyOngoing {
+			return ports.BirthDTO{}, invalidBreeding("pregnancy is already " + strings.ToLower(string(pregnancy.Status)))
+		}
+		if input.SirePublicID == nil {
+			input.SirePublicID = pregnancy.SirePublicID
+		}
+	}
+	if input.SirePublicID != nil {
+		if _, err := s.parent(ctx, actor, "sire", *input.SirePublicID, dam.SpeciesPublicID); err != nil {
+			return ports.BirthDTO{}, err
+		}
+	}
+
+	// newborns placed away from their dam go through the compatibility rules
+	var guard ports.PlacementGuard
+	if input.CagePublicID != nil && *input.CagePublicID != dam.CageID {
+		if err := s.zones.CheckCage(ctx, actor, *input.CagePublicID); err != nil {
+			return ports.BirthDTO{}, err
+		}
+		guard = s.compat.Guard(actor, overrideReason)
+	}
+
+	identifiers := make(map[string]bool)
+	for _, o := range offspring {
+		name := strings.TrimSpace(o.Name)
+		if name == "" {
+			return ports.BirthDTO{}, invalidBreeding("every newborn needs a name")
+		}
+		sex, err := parseSex(o.Sex)
+		if err != nil {
+			return ports.BirthDTO{}, err
+		}
+
+		microchipID := trimmedOrNil(o.MicrochipID)
+		studbookID := trimmedOrNil(o.StudbookID)
+		for _, v := range []*string{microchipID, studbookID} {
+			if v == nil {
+				continue
+			}
+			if identifiers[*v] {
+				return ports.BirthDTO{}, invalidBreeding("identifier " + *v + " is used twice")
+			}
+			identifiers[*v] = true
+		}
+
+		publicID, err := s.idGen.NewID()
+		if err != nil {
+			return ports.BirthDTO{}, err
+		}
+		input.Offspring = append(input.Offspring, ports.OffspringInput{
+			PublicID:    publicID,
+			Name:        name,
+			Sex:         sex,
+			MicrochipID: microchipID,
+			StudbookID:  studbookID,
+		})
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.BirthDTO{}, err
+	}
+	input.PublicID = publicID
+	input.Notes = trimmedOrNil(input.Notes)
+	input.RecordedByPublicID = actor.PublicID
+
+	if err := s.repo.RecordBirth(ctx, input, guard); err != nil {
+		return ports.BirthDTO{}, err
+	}
+
+	return s.repo.FindBirth(ctx, publicID)
+}
+
+func (s *BreedingService) ListBirths(
+	ctx context.Context,
+	actor Actor,
+	damPublicID *string,
+) ([]ports.BirthDTO, error) {
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListBirths(ctx, damPublicID, scope)
+}
+
+func (s *BreedingService) FindBirth(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) (ports.BirthDTO, error) {
+
+	b, err := s.repo.FindBirth(ctx, publicID)
+	if err != nil {
+		return ports.BirthDTO{}, err
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, b.DamPublicID); err != nil {
+		return ports.BirthDTO{}, err
+	}
+
+	return b, nil
+}
+
+func invalidBreeding(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidBreeding, reason)
+}
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
 	"wit-leisure-park/backend/internal/infrastructure/id"
 
 	"wit-leisure-park/backend/internal/ports"
@@ -10,23 +11,33 @@ import (
 
 type CageService struct {
 	repo  ports.CageRepository
+	zones *ZoneService
 	idGen *id.UUIDGenerator
 }
 
 func NewCageService(
 	repo ports.CageRepository,
+	zones *ZoneService,
 	idGen *id.UUIDGenerator,
 ) *CageService {
-	return &CageService{repo: repo, idGen: idGen}
+	return &CageService{repo: repo, zones: zones, idGen: idGen}
 }
 
 func (s *CageService) Create(
 	ctx context.Context,
-	code string,
-	location string,
+	actor Actor,
+	input ports.CageInput,
 ) (ports.CageDTO, error) {
 
-	exists, err := s.repo.CodeExists(ctx, code)
+	if err := validateCage(input); err != nil {
+		return ports.CageDTO{}, err
+	}
+
+	if err := s.zones.CheckArea(ctx, actor, input.AreaPublicID); err != nil {
+		return ports.CageDTO{}, err
+	}
+
+	exists, err := s.repo.CodeExists(ctx, input.Code)
 	if err != nil {
 		return ports.CageDTO{}, err
 	}
@@ -38,49 +49,130 @@ func (s *CageService) Create(
 	if err != nil {
 		return ports.CageDTO{}, err
 	}
+	input.PublicID = publicID
 
-	id, err := s.repo.Create(ctx, publicID, code, location)
+	id, err := s.repo.Create(ctx, input)
 	if err != nil {
 		return ports.CageDTO{}, err
 	}
 
-	return ports.CageDTO{
-		PublicID: id,
-		Code:     code,
-		Location: location,
-	}, nil
+	return s.repo.FindByID(ctx, id)
 }
 
-func (s *CageService) List(ctx context.Context) ([]ports.CageDTO, error) {
-	return s.repo.List(ctx)
+func (s *CageService) List(
+	ctx context.Context,
+	actor Actor,
+	query ports.CageListQuery,
+) (ports.Page[ports.CageDTO], error) {
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return ports.Page[ports.CageDTO]{}, err
+	}
+	query.ScopeZonePublicIDs = scope
+
+	return s.repo.List(ctx, query)
 }
 
+// FindByID hides cages outside the actor's zones as not found.
 func (s *CageService) FindByID(
 	ctx context.Context,
+	actor Actor,
 	publicID string,
 ) (ports.CageDTO, error) {
-	return s.repo.FindByID(ctx, publicID)
+
+	cage, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return ports.CageDTO{}, err
+	}
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return ports.CageDTO{}, err
+	}
+	if !inScope(scope, cage.ZonePublicID) {
+		return ports.CageDTO{}, ports.ErrNotFound
+	}
+
+	return cage, nil
 }
 
 func (s *CageService) Update(
 	ctx context.Context,
-	publicID, code, location string,
+	actor Actor,
+	input ports.CageInput,
 ) error {
 
-	exists, err := s.repo.CodeExists(ctx, code)
+	if err := validateCage(input); err != nil {
+		return err
+	}
+
+	current, err := s.FindByID(ctx, actor, input.PublicID)
 	if err != nil {
 		return err
 	}
-	if exists {
-		return errors.New("cage code already exists")
+
+	if err := s.zones.CheckArea(ctx, actor, input.AreaPublicID); err != nil {
+		return err
+	}
+
+	if current.Code != input.Code {
+		exists, err := s.repo.CodeExists(ctx, input.Code)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.New("cage code already exists")
+		}
 	}
 
-	return s.repo.Update(ctx, publicID, code, location)
+	return s.repo.Update(ctx, input)
 }
 
 func (s *CageService) Delete(
 	ctx context.Context,
+	actor Actor,
 	publicID string,
 ) error {
+
+	if _, err := s.FindByID(ctx, actor, publicID); err != nil {
+		return err
+	}
+
 	return s.repo.Delete(ctx, publicID)
 }
+
+func (s *CageService) Occupancy(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) (ports.CageOccupancyDTO, error) {
+
+	if _, err := s.FindByID(ctx, actor, publicID); err != nil {
+		return ports.CageOccupancyDTO{}, err
+	}
+
+	return s.repo.Occupancy(ctx, publicID)
+}
+
+func validateCage(input ports.CageInput) error {
+	if input.Capacity != nil && *input.Capacity < 1 {
+		return errors.New("capacity must be positive")
+	}
+
+	seen := make(map[string]bool, len(input.SpeciesLimits))
+	for _, l := range input.SpeciesLimits {
+		if l.SpeciesPublicID == "" {
+			return errors.New("species limit needs a species_public_id")
+		}
+		if l.MaxCount < 1 {
+			return errors.New("species limit max_count must be positive")
+		}
+		if seen[l.SpeciesPublicID] {
+			return fmt.Errorf("duplicate species limit for %s", l.SpeciesPublicID)
+		}
+		seen[l.SpeciesPublicID] = true
+	}
+
+	return nil
+}
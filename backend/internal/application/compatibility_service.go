@@ -0,0 +1,102 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+type CompatibilityService struct {
+	repo  ports.CompatibilityRepository
+	zones *ZoneService
+}
+
+func NewCompatibilityService(repo ports.CompatibilityRepository, zones *ZoneService) *CompatibilityService {
+	return &CompatibilityService{repo: repo, zones: zones}
+}
+
+func (s *CompatibilityService) ListRules(
+	ctx context.Context,
+	speciesPublicID *string,
+) ([]ports.CompatibilityRuleDTO, error) {
+	return s.repo.ListRules(ctx, speciesPublicID)
+}
+
+func (s *CompatibilityService) SetRule(
+	ctx context.Context,
+	rule ports.CompatibilityRuleDTO,
+) (ports.CompatibilityRuleDTO, error) {
+
+	switch rule.Rule {
+	case ports.CompatibilityAllowed,
+		ports.CompatibilityForbidden,
+		ports.CompatibilityRequiresApproval:
+	default:
+		return ports.CompatibilityRuleDTO{}, invalidCompatibility("rule must be ALLOWED, FORBIDDEN or REQUIRES_APPROVAL")
+	}
+	rule.Notes = trimmedOrNil(rule.Notes)
+
+	return rule, s.repo.UpsertRule(ctx, rule)
+}
+
+func (s *CompatibilityService) DeleteRule(
+	ctx context.Context,
+	speciesAPublicID, speciesBPublicID string,
+) error {
+	return s.repo.DeleteRule(ctx, speciesAPublicID, speciesBPublicID)
+}
+
+// ListOverrides only shows zone-scoped managers the overrides of cages in
+// their zones.
+func (s *CompatibilityService) ListOverrides(
+	ctx context.Context,
+	actor Actor,
+	cagePublicID *string,
+) ([]ports.CompatibilityOverrideDTO, error) {
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListOverrides(ctx, cagePublicID, scope)
+}
+
+// Guard returns the compatibility check for a placement made by the actor.
+// Forbidden pairs are always refused. Pairs that require approval pass only
+// when a manager gives an override reason, which is then recorded with the
+// placement. The repository runs the check once the cage is locked, so two
+// placements into the same cage cannot both pass on a stale view of it.
+func (s *CompatibilityService) Guard(actor Actor, overrideReason *string) ports.PlacementGuard {
+	return func(conflicts []ports.CompatibilityConflict) (*ports.CompatibilityOverrideInput, error) {
+		if len(conflicts) == 0 {
+			return nil, nil
+		}
+
+		approvable := true
+		for _, c := range conflicts {
+			if c.Rule == ports.CompatibilityForbidden {
+				approvable = false
+			}
+		}
+
+		approved := overrideReason != nil && strings.TrimSpace(*overrideReason) != ""
+		if !approvable || !approved {
+			return nil, &IncompatibleError{Conflicts: conflicts, Approvable: approvable}
+		}
+		if !actor.IsManager() {
+			return nil, ErrForbidden
+		}
+
+		return &ports.CompatibilityOverrideInput{
+			ApprovedByPublicID: actor.PublicID,
+			Reason:             strings.TrimSpace(*overrideReason),
+			Conflicts:          conflicts,
+		}, nil
+	}
+}
+
+func invalidCompatibility(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidCompatibility, reason)
+}
@@ -0,0 +1,124 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/domain/shift"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+var (
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrInvalidRefreshToken  = errors.New("invalid refresh token")
+	ErrRefreshTokenReused   = errors.New("refresh token reuse detected, session revoked")
+	ErrInvalidResetToken    = errors.New("invalid or expired reset token")
+	ErrWeakPassword         = errors.New("password must be at least 8 characters")
+	ErrInvalidMFAToken      = errors.New("invalid or expired mfa token")
+	ErrInvalidMFACode       = errors.New("invalid mfa code")
+	ErrMFANotEnrolled       = errors.New("mfa is not enrolled")
+	ErrMFAAlreadyEnabled    = errors.New("mfa is already enabled")
+	ErrForbidden            = errors.New("forbidden")
+	ErrTaskNotFound         = errors.New("task not found")
+	ErrTemplateTitle        = errors.New("title is required")
+	ErrInvalidLeadDays      = errors.New("lead_days must be between 0 and 90")
+	ErrInvalidTemplateDays  = errors.New("ends_on must not be before starts_on")
+	ErrInvalidMedicalRecord = errors.New("invalid medical record")
+	ErrInvalidFeeding       = errors.New("invalid feeding data")
+	ErrInvalidMeasurement   = errors.New("invalid measurement")
+	ErrInvalidTransfer      = errors.New("invalid transfer")
+	ErrInvalidSpecies       = errors.New("invalid species")
+	ErrSpeciesExists        = errors.New("species name already exists")
+	ErrInvalidCompatibility = errors.New("invalid compatibility rule")
+	ErrInvalidStatusChange  = errors.New("invalid status change")
+	ErrInvalidQuarantine    = errors.New("invalid quarantine")
+	ErrInvalidBreeding      = errors.New("invalid breeding record")
+	ErrInvalidSensorData    = errors.New("invalid sensor data")
+	ErrInvalidDeviceKey     = errors.New("invalid device key")
+	ErrInvalidZone          = errors.New("invalid zone")
+	ErrInvalidShift         = errors.New("invalid shift")
+	ErrShiftConflict        = errors.New("shift conflicts with other shifts")
+	ErrInvalidLeave         = errors.New("invalid leave request")
+	ErrZookeeperUnavailable = errors.New("zookeeper is on leave")
+	ErrInvalidCertification = errors.New("invalid skill or certification")
+	ErrNotCertified         = errors.New("zookeeper is not certified for this work")
+	ErrNoAssignee           = errors.New("no zookeeper of the team can take the task")
+	ErrAutoAssignDueDate    = errors.New("auto_assign needs a due_date")
+	ErrIncompatibleSpecies  = errors.New("species are not compatible")
+)
+
+// LockedError is returned when a login is refused because of too many
+// failed attempts.
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, try again in %s", e.RetryAfter.Round(time.Second))
+}
+
+// IncompatibleError is returned when an animal would share a cage with
+// animals its species may not live with. Approvable is set when every
+// conflict can be overridden by a manager.
+type IncompatibleError struct {
+	Conflicts  []ports.CompatibilityConflict
+	Approvable bool
+}
+
+func (e *IncompatibleError) Error() string {
+	if e.Approvable {
+		return fmt.Sprintf("%s: %d animals in the cage need a manager approval", ErrIncompatibleSpecies, len(e.Conflicts))
+	}
+	return fmt.Sprintf("%s: %d animals in the cage conflict", ErrIncompatibleSpecies, len(e.Conflicts))
+}
+
+func (e *IncompatibleError) Unwrap() error {
+	return ErrIncompatibleSpecies
+}
+
+// ShiftConflictError is returned when a shift overlaps another shift of the
+// zookeeper or leaves too little rest before or after it.
+type ShiftConflictError struct {
+	Conflicts []shift.Conflict
+}
+
+func (e *ShiftConflictError) Error() string {
+	return fmt.Sprintf("%s: %d shifts are in the way", ErrShiftConflict, len(e.Conflicts))
+}
+
+func (e *ShiftConflictError) Unwrap() error {
+	return ErrShiftConflict
+}
+
+// CertificationError is returned when a zookeeper is assigned work that
+// needs skills they hold no valid certification for.
+type CertificationError struct {
+	Missing []ports.MissingSkill
+}
+
+func (e *CertificationError) Error() string {
+	codes := make([]string, len(e.Missing))
+	for i, m := range e.Missing {
+		codes[i] = m.Code
+	}
+	return fmt.Sprintf("%s: missing %s", ErrNotCertified, strings.Join(codes, ", "))
+}
+
+func (e *CertificationError) Unwrap() error {
+	return ErrNotCertified
+}
+
+// NoAssigneeError is returned when automatic assignment finds nobody in the
+// manager's team who may take the task. Candidates explain why.
+type NoAssigneeError struct {
+	Candidates []AssignmentCandidate
+}
+
+func (e *NoAssigneeError) Error() string {
+	return fmt.Sprintf("%s: %d zookeepers considered", ErrNoAssignee, len(e.Candidates))
+}
+
+func (e *NoAssigneeError) Unwrap() error {
+	return ErrNoAssignee
+}
@@ -0,0 +1,286 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+const (
+	maxFeedingsPerDay    = 24
+	defaultFeedingWindow = 7 * 24 * time.Hour
+)
+
+// FeedingService keeps diet plans and feeding logs. Zone-scoped managers
+// only see and change those of animals in their zones.
+type FeedingService struct {
+	repo    ports.FeedingRepository
+	animals *AnimalService
+	zones   *ZoneService
+	idGen   *id.UUIDGenerator
+}
+
+func NewFeedingService(
+	repo ports.FeedingRepository,
+	animals *AnimalService,
+	zones *ZoneService,
+	idGen *id.UUIDGenerator,
+) *FeedingService {
+	return &FeedingService{repo: repo, animals: animals, zones: zones, idGen: idGen}
+}
+
+func (s *FeedingService) CreateDietPlan(
+	ctx context.Context,
+	actor Actor,
+	input ports.DietPlanInput,
+) (ports.DietPlanDTO, error) {
+
+	if err := validateDietPlan(&input); err != nil {
+		return ports.DietPlanDTO{}, err
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, input.AnimalPublicID); err != nil {
+		return ports.DietPlanDTO{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.DietPlanDTO{}, err
+	}
+	input.PublicID = publicID
+	input.CreatedByPublicID = actor.PublicID
+
+	if err := s.repo.CreateDietPlan(ctx, input); err != nil {
+		return ports.DietPlanDTO{}, err
+	}
+
+	return dietPlanDTO(input), nil
+}
+
+func (s *FeedingService) ListDietPlans(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID string,
+) ([]ports.DietPlanDTO, error) {
+
+	if _, err := s.animals.FindByID(ctx, actor, animalPublicID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListDietPlans(ctx, animalPublicID)
+}
+
+func (s *FeedingService) UpdateDietPlan(
+	ctx context.Context,
+	actor Actor,
+	input ports.DietPlanInput,
+) (ports.DietPlanDTO, error) {
+
+	if _, err := s.planOf(ctx, actor, input.AnimalPublicID, input.PublicID); err != nil {
+		return ports.DietPlanDTO{}, err
+	}
+
+	if err := validateDietPlan(&input); err != nil {
+		return ports.DietPlanDTO{}, err
+	}
+
+	if err := s.repo.UpdateDietPlan(ctx, input); err != nil {
+		return ports.DietPlanDTO{}, err
+	}
+
+	return dietPlanDTO(input), nil
+}
+
+func (s *FeedingService) DeleteDietPlan(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID, planPublicID string,
+) error {
+
+	if _, err := s.planOf(ctx, actor, animalPublicID, planPublicID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteDietPlan(ctx, planPublicID)
+}
+
+// LogFeeding records what an animal was given and refused. Food item and
+// unit default to those of the referenced plan.
+func (s *FeedingService) LogFeeding(
+	ctx context.Context,
+	actor Actor,
+	input ports.FeedingLogInput,
+) (ports.FeedingLogDTO, error) {
+
+	if input.DietPlanPublicID != nil {
+		plan, err := s.planOf(ctx, actor, input.AnimalPublicID, *input.DietPlanPublicID)
+		if err != nil {
+			return ports.FeedingLogDTO{}, err
+		}
+		if strings.TrimSpace(input.FoodItem) == "" {
+			input.FoodItem = plan.FoodItem
+		}
+		if strings.TrimSpace(input.Unit) == "" {
+			input.Unit = plan.Unit
+		}
+	}
+
+	input.FoodItem = strings.TrimSpace(input.FoodItem)
+	input.Unit = strings.TrimSpace(input.Unit)
+	if input.FoodItem == "" || input.Unit == "" {
+		return ports.FeedingLogDTO{}, invalidFeeding("food_item and unit are required")
+	}
+	if input.QuantityGiven < 0 || input.QuantityRefused < 0 {
+		return ports.FeedingLogDTO{}, invalidFeeding("quantities must not be negative")
+	}
+	if input.QuantityRefused > input.QuantityGiven {
+		return ports.FeedingLogDTO{}, invalidFeeding("quantity_refused must not exceed quantity_given")
+	}
+
+	now := time.Now()
+	if input.FedAt.IsZero() {
+		input.FedAt = now
+	}
+	if input.FedAt.After(now.Add(5 * time.Minute)) {
+		return ports.FeedingLogDTO{}, invalidFeeding("fed_at must not be in the future")
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, input.AnimalPublicID); err != nil {
+		return ports.FeedingLogDTO{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.FeedingLogDTO{}, err
+	}
+	input.PublicID = publicID
+	input.FedByPublicID = actor.PublicID
+
+	if err := s.repo.CreateFeedingLog(ctx, input); err != nil {
+		return ports.FeedingLogDTO{}, err
+	}
+
+	return ports.FeedingLogDTO{
+		PublicID:         input.PublicID,
+		DietPlanPublicID: input.DietPlanPublicID,
+		FedAt:            input.FedAt,
+		FoodItem:         input.FoodItem,
+		QuantityGiven:    input.QuantityGiven,
+		QuantityRefused:  input.QuantityRefused,
+		Unit:             input.Unit,
+		Notes:            input.Notes,
+		FedBy:            &input.FedByPublicID,
+	}, nil
+}
+
+// ListFeedingLogs defaults to the last seven days.
+func (s *FeedingService) ListFeedingLogs(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID string,
+	from, to *time.Time,
+) ([]ports.FeedingLogDTO, error) {
+
+	if _, err := s.animals.FindByID(ctx, actor, animalPublicID); err != nil {
+		return nil, err
+	}
+
+	end := time.Now()
+	if to != nil {
+		end = to.AddDate(0, 0, 1)
+	}
+
+	start := end.Add(-defaultFeedingWindow)
+	if from != nil {
+		start = *from
+	}
+
+	return s.repo.ListFeedingLogs(ctx, animalPublicID, start, end)
+}
+
+// MissedFeedings lists the plans short of feedings on the given day,
+// today by default. For today that includes feedings still to come.
+func (s *FeedingService) MissedFeedings(
+	ctx context.Context,
+	actor Actor,
+	day *time.Time,
+) ([]ports.MissedFeedingDTO, error) {
+
+	date := today()
+	if day != nil {
+		date = *day
+	}
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListMissedFeedings(ctx, date, scope)
+}
+
+// planOf loads a plan of the animal, if the actor can see the animal.
+func (s *FeedingService) planOf(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID, planPublicID string,
+) (ports.DietPlanDTO, error) {
+
+	if _, err := s.animals.FindByID(ctx, actor, animalPublicID); err != nil {
+		return ports.DietPlanDTO{}, err
+	}
+
+	plan, err := s.repo.FindDietPlan(ctx, planPublicID)
+	if err != nil {
+		return ports.DietPlanDTO{}, err
+	}
+
+	if plan.AnimalPublicID != animalPublicID {
+		return ports.DietPlanDTO{}, ports.ErrNotFound
+	}
+
+	return plan, nil
+}
+
+func validateDietPlan(input *ports.DietPlanInput) error {
+	input.FoodItem = strings.TrimSpace(input.FoodItem)
+	input.Unit = strings.TrimSpace(input.Unit)
+	if input.FoodItem == "" || input.Unit == "" {
+		return invalidFeeding("food_item and unit are required")
+	}
+	if input.Quantity <= 0 {
+		return invalidFeeding("quantity must be positive")
+	}
+	if input.TimesPerDay < 1 || input.TimesPerDay > maxFeedingsPerDay {
+		return invalidFeeding("times_per_day must be between 1 and 24")
+	}
+	if input.StartsOn.IsZero() {
+		input.StartsOn = today()
+	}
+	if input.EndsOn != nil && input.EndsOn.Before(input.StartsOn) {
+		return invalidFeeding("ends_on must not be before starts_on")
+	}
+
+	return nil
+}
+
+func dietPlanDTO(input ports.DietPlanInput) ports.DietPlanDTO {
+	return ports.DietPlanDTO{
+		PublicID:       input.PublicID,
+		AnimalPublicID: input.AnimalPublicID,
+		FoodItem:       input.FoodItem,
+		Quantity:       input.Quantity,
+		Unit:           input.Unit,
+		TimesPerDay:    input.TimesPerDay,
+		Notes:          input.Notes,
+		StartsOn:       input.StartsOn,
+		EndsOn:         input.EndsOn,
+	}
+}
+
+func invalidFeeding(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidFeeding, reason)
+}
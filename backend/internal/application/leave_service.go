@@ -0,0 +1,374 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/domain/leave"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
+)
+
+type LeaveRequest struct {
+	Type     string
+	StartsOn *time.Time
+	EndsOn   *time.Time
+	Reason   *string
+}
+
+// ApprovedLeave is approved leave with the zookeeper's open tasks due
+// during it, so the manager can decide whether to reassign them.
+type ApprovedLeave struct {
+	ports.LeaveDTO
+	OpenTasks []ports.TaskDTO `json:"open_tasks"`
+}
+
+type ReassignResult struct {
+	ZookeeperPublicID string   `json:"zookeeper_public_id"`
+	Reassigned        int      `json:"reassigned"`
+	TaskPublicIDs     []string `json:"task_public_ids"`
+}
+
+type LeaveService struct {
+	repo       ports.LeaveRepository
+	tasks      ports.TaskRepository
+	zookeepers *ZookeeperService
+	shifts     *ShiftService
+	skills     *SkillService
+	idGen      *id.UUIDGenerator
+}
+
+func NewLeaveService(
+	repo ports.LeaveRepository,
+	tasks ports.TaskRepository,
+	zookeepers *ZookeeperService,
+	shifts *ShiftService,
+	skills *SkillService,
+	idGen *id.UUIDGenerator,
+) *LeaveService {
+	return &LeaveService{
+		repo:       repo,
+		tasks:      tasks,
+		zookeepers: zookeepers,
+		shifts:     shifts,
+		skills:     skills,
+		idGen:      idGen,
+	}
+}
+
+// Submit files a leave request for the calling zookeeper. It may not
+// overlap leave that is pending or approved.
+func (s *LeaveService) Submit(
+	ctx context.Context,
+	actor Actor,
+	req LeaveRequest,
+) (ports.LeaveDTO, error) {
+
+	leaveType := ports.LeaveType(strings.ToUpper(strings.TrimSpace(req.Type)))
+	if !leaveType.Valid() {
+		return ports.LeaveDTO{}, invalidLeave("type must be ANNUAL, SICK, TRAINING, PERSONAL or OTHER")
+	}
+	if req.StartsOn == nil || req.EndsOn == nil {
+		return ports.LeaveDTO{}, invalidLeave("starts_on and ends_on are required")
+	}
+	if req.EndsOn.Before(*req.StartsOn) {
+		return ports.LeaveDTO{}, invalidLeave("ends_on must not be before starts_on")
+	}
+
+	overlapping, err := s.repo.List(ctx, ports.LeaveQuery{
+		ZookeeperPublicID: &actor.PublicID,
+		Statuses:          []ports.LeaveStatus{leave.StatusPending, leave.StatusApproved},
+		From:              *req.StartsOn,
+		To:                *req.EndsOn,
+	})
+	if err != nil {
+		return ports.LeaveDTO{}, err
+	}
+	if len(overlapping) > 0 {
+		return ports.LeaveDTO{}, fmt.Errorf("%w: leave from %s to %s is already %s",
+			ports.ErrDuplicate,
+			overlapping[0].StartsOn.Format(utils.DateLayout),
+			overlapping[0].EndsOn.Format(utils.DateLayout),
+			strings.ToLower(string(overlapping[0].Status)),
+		)
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.LeaveDTO{}, err
+	}
+
+	err = s.repo.Create(ctx, ports.LeaveInput{
+		PublicID:          publicID,
+		ZookeeperPublicID: actor.PublicID,
+		Type:              leaveType,
+		StartsOn:          *req.StartsOn,
+		EndsOn:            *req.EndsOn,
+		Reason:            trimmedOrNil(req.Reason),
+	})
+	if err != nil {
+		return ports.LeaveDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, publicID)
+}
+
+// List shows zookeepers their own requests only.
+func (s *LeaveService) List(
+	ctx context.Context,
+	actor Actor,
+	query ports.LeaveQuery,
+) ([]ports.LeaveDTO, error) {
+
+	if !actor.IsManager() {
+		query.ZookeeperPublicID = &actor.PublicID
+		query.ManagerPublicID = nil
+	}
+
+	return s.repo.List(ctx, query)
+}
+
+func (s *LeaveService) FindByID(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) (ports.LeaveDTO, error) {
+
+	l, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return ports.LeaveDTO{}, err
+	}
+
+	if !actor.IsManager() && l.ZookeeperPublicID != actor.PublicID {
+		return ports.LeaveDTO{}, ErrForbidden
+	}
+
+	return l, nil
+}
+
+// Approve grants the leave and returns the zookeeper's open tasks due
+// during it.
+func (s *LeaveService) Approve(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	note *string,
+) (ApprovedLeave, error) {
+
+	l, err := s.decide(ctx, actor, publicID, leave.StatusApproved, note)
+	if err != nil {
+		return ApprovedLeave{}, err
+	}
+
+	open, err := s.tasks.ListOpen(ctx, l.ZookeeperPublicID, l.StartsOn, l.EndsOn)
+	if err != nil {
+		return ApprovedLeave{}, err
+	}
+
+	return ApprovedLeave{LeaveDTO: l, OpenTasks: open}, nil
+}
+
+func (s *LeaveService) Reject(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	note *string,
+) (ports.LeaveDTO, error) {
+	return s.decide(ctx, actor, publicID, leave.StatusRejected, note)
+}
+
+// Cancel withdraws a pending or approved request. Zookeepers can only
+// cancel their own.
+func (s *LeaveService) Cancel(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	note *string,
+) (ports.LeaveDTO, error) {
+
+	if _, err := s.FindByID(ctx, actor, publicID); err != nil {
+		return ports.LeaveDTO{}, err
+	}
+
+	return s.decide(ctx, actor, publicID, leave.StatusCancelled, note)
+}
+
+// ReassignTasks hands the open tasks due during approved leave to another
+// zookeeper of the manager's team, who must not be on leave on any of those
+// days and must hold the skills every task needs.
+func (s *LeaveService) ReassignTasks(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	zookeeperPublicID string,
+) (ReassignResult, error) {
+
+	l, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return ReassignResult{}, err
+	}
+	if l.Status != leave.StatusApproved {
+		return ReassignResult{}, invalidLeave("only the tasks of approved leave can be reassigned")
+	}
+	if zookeeperPublicID == "" {
+		return ReassignResult{}, invalidLeave("zookeeper_public_id is required")
+	}
+	if zookeeperPublicID == l.ZookeeperPublicID {
+		return ReassignResult{}, invalidLeave("tasks must go to another zookeeper")
+	}
+	if err := s.zookeepers.CheckTeam(ctx, actor, l.ZookeeperPublicID); err != nil {
+		return ReassignResult{}, err
+	}
+	if err := s.zookeepers.CheckTeam(ctx, actor, zookeeperPublicID); err != nil {
+		return ReassignResult{}, err
+	}
+
+	away, err := s.repo.List(ctx, ports.LeaveQuery{
+		ZookeeperPublicID: &zookeeperPublicID,
+		Statuses:          []ports.LeaveStatus{leave.StatusApproved},
+		From:              l.StartsOn,
+		To:                l.EndsOn,
+	})
+	if err != nil {
+		return ReassignResult{}, err
+	}
+	if len(away) > 0 {
+		return ReassignResult{}, fmt.Errorf("%w: %s is on leave from %s to %s",
+			ErrZookeeperUnavailable,
+			away[0].Zookeeper,
+			away[0].StartsOn.Format(utils.DateLayout),
+			away[0].EndsOn.Format(utils.DateLayout),
+		)
+	}
+
+	open, err := s.tasks.ListOpen(ctx, l.ZookeeperPublicID, l.StartsOn, l.EndsOn)
+	if err != nil {
+		return ReassignResult{}, err
+	}
+
+	ids := make([]string, 0, len(open))
+	for _, t := range open {
+		day := l.StartsOn
+		if t.DueDate != nil {
+			day = *t.DueDate
+		}
+		if err := s.skills.Check(ctx, zookeeperPublicID, t.AnimalPublicID, t.TemplatePublicID, day); err != nil {
+			return ReassignResult{}, err
+		}
+		ids = append(ids, t.PublicID)
+	}
+
+	result := ReassignResult{ZookeeperPublicID: zookeeperPublicID, TaskPublicIDs: ids}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	result.Reassigned, err = s.tasks.Reassign(ctx, ids, zookeeperPublicID, actor.PublicID)
+	return result, err
+}
+
+// Availability lists the zookeepers, of one team when managerPublicID is
+// set, with whether they are on leave or on shift on the day. A nil day
+// means today.
+func (s *LeaveService) Availability(
+	ctx context.Context,
+	day *time.Time,
+	managerPublicID *string,
+) ([]ports.AvailabilityDTO, error) {
+
+	now := time.Now().UTC()
+	on := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if day != nil {
+		on = *day
+	}
+
+	result, err := s.repo.Availability(ctx, on, managerPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	onDuty, err := s.shifts.Rostered(ctx, on)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		result[i].OnShift = onDuty[result[i].ZookeeperPublicID]
+	}
+
+	return result, nil
+}
+
+// On returns the zookeeper's pending and approved leave that includes the
+// day.
+func (s *LeaveService) On(
+	ctx context.Context,
+	zookeeperPublicID string,
+	day time.Time,
+) ([]ports.LeaveDTO, error) {
+	return s.repo.List(ctx, ports.LeaveQuery{
+		ZookeeperPublicID: &zookeeperPublicID,
+		Statuses:          []ports.LeaveStatus{leave.StatusPending, leave.StatusApproved},
+		From:              day,
+		To:                day,
+	})
+}
+
+// Away returns the zookeeper's approved leave that includes the day, or
+// nil. Approved leave blocks tasks due that day; pending leave does not.
+func (s *LeaveService) Away(
+	ctx context.Context,
+	zookeeperPublicID string,
+	day time.Time,
+) (*ports.LeaveDTO, error) {
+
+	away, err := s.On(ctx, zookeeperPublicID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range away {
+		if l.Status == leave.StatusApproved {
+			return &l, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *LeaveService) decide(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	to ports.LeaveStatus,
+	note *string,
+) (ports.LeaveDTO, error) {
+
+	current, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return ports.LeaveDTO{}, err
+	}
+
+	if err := leave.ValidateTransition(current.Status, to); err != nil {
+		return ports.LeaveDTO{}, err
+	}
+
+	err = s.repo.Decide(ctx, ports.LeaveDecision{
+		PublicID:      publicID,
+		From:          current.Status,
+		To:            to,
+		ActorPublicID: actor.PublicID,
+		Note:          trimmedOrNil(note),
+	})
+	if err != nil {
+		return ports.LeaveDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, publicID)
+}
+
+func invalidLeave(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidLeave, reason)
+}
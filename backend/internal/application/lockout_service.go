@@ -0,0 +1,161 @@
+package application
+
+import (
+	"context"
+	"time"
+	"wit-leisure-park/backend/internal/domain"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+type LockoutOptions struct {
+	MaxFailedAttempts   int
+	IPMaxFailedAttempts int
+	AttemptWindow       time.Duration
+	LockoutBase         time.Duration
+	LockoutMax          time.Duration
+}
+
+// LockoutService throttles password guessing: per client IP over a sliding
+// window, and per account with an exponentially growing lockout. Managers
+// only see and unlock the accounts of their own team.
+type LockoutService struct {
+	userRepo    ports.UserRepository
+	attemptRepo ports.LoginAttemptRepository
+	zookeepers  *ZookeeperService
+	opts        LockoutOptions
+}
+
+func NewLockoutService(
+	userRepo ports.UserRepository,
+	attemptRepo ports.LoginAttemptRepository,
+	zookeepers *ZookeeperService,
+	opts LockoutOptions,
+) *LockoutService {
+	return &LockoutService{
+		userRepo:    userRepo,
+		attemptRepo: attemptRepo,
+		zookeepers:  zookeepers,
+		opts:        opts,
+	}
+}
+
+// CheckIP rejects clients that failed too often within the attempt window.
+func (s *LockoutService) CheckIP(ctx context.Context, ipAddress string) error {
+	failures, err := s.attemptRepo.CountFailuresByIP(
+		ctx,
+		ipAddress,
+		time.Now().Add(-s.opts.AttemptWindow),
+	)
+	if err != nil {
+		return err
+	}
+
+	if failures >= s.opts.IPMaxFailedAttempts {
+		return &LockedError{RetryAfter: s.opts.AttemptWindow}
+	}
+
+	return nil
+}
+
+// CheckUser rejects login attempts for accounts that are currently locked.
+func (s *LockoutService) CheckUser(user *domain.User) error {
+	now := time.Now()
+	if user.IsLocked(now) {
+		return &LockedError{RetryAfter: user.LockedUntil.Sub(now)}
+	}
+
+	return nil
+}
+
+// RecordFailure stores a failed attempt and locks the account once the
+// threshold is reached. user is nil when the username does not exist.
+func (s *LockoutService) RecordFailure(
+	ctx context.Context,
+	username, ipAddress string,
+	user *domain.User,
+) error {
+
+	if err := s.attemptRepo.Record(ctx, truncate(username, 50), ipAddress, false); err != nil {
+		return err
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	failed, lockouts, err := s.userRepo.IncrementFailedLogins(ctx, user.PublicID)
+	if err != nil {
+		return err
+	}
+
+	if failed < s.opts.MaxFailedAttempts {
+		return nil
+	}
+
+	return s.userRepo.Lock(ctx, user.PublicID, time.Now().Add(s.lockoutDuration(lockouts)))
+}
+
+func (s *LockoutService) RecordSuccess(
+	ctx context.Context,
+	username, ipAddress string,
+	user *domain.User,
+) error {
+
+	if err := s.attemptRepo.Record(ctx, truncate(username, 50), ipAddress, true); err != nil {
+		return err
+	}
+
+	return s.userRepo.ResetFailedLogins(ctx, user.PublicID)
+}
+
+func (s *LockoutService) LockState(
+	ctx context.Context,
+	actor Actor,
+	userPublicID string,
+) (ports.UserLockState, error) {
+
+	if err := s.zookeepers.CheckTeam(ctx, actor, userPublicID); err != nil {
+		return ports.UserLockState{}, err
+	}
+
+	return s.userRepo.FindLockState(ctx, userPublicID)
+}
+
+func (s *LockoutService) ListLocked(
+	ctx context.Context,
+	actor Actor,
+) ([]ports.UserLockState, error) {
+	return s.userRepo.ListLocked(ctx, actor.PublicID)
+}
+
+func (s *LockoutService) Unlock(
+	ctx context.Context,
+	actor Actor,
+	userPublicID string,
+) error {
+
+	if err := s.zookeepers.CheckTeam(ctx, actor, userPublicID); err != nil {
+		return err
+	}
+
+	return s.userRepo.ResetFailedLogins(ctx, userPublicID)
+}
+
+// lockoutDuration doubles the base duration for every previous lockout,
+// capped at the configured maximum.
+func (s *LockoutService) lockoutDuration(previousLockouts int) time.Duration {
+	d := s.opts.LockoutBase
+	for i := 0; i < previousLockouts && d < s.opts.LockoutMax; i++ {
+		d *= 2
+	}
+
+	return min(d, s.opts.LockoutMax)
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+
+	return value[:max]
+}
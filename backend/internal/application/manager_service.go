@@ -68,8 +68,11 @@ func (s *ManagerService) Create(
 	}, nil
 }
 
-func (s *ManagerService) List(ctx context.Context) ([]ports.ManagerDTO, error) {
-	return s.repo.ListManagers(ctx)
+func (s *ManagerService) List(
+	ctx context.Context,
+	query ports.ManagerListQuery,
+) (ports.Page[ports.ManagerDTO], error) {
+	return s.repo.ListManagers(ctx, query)
 }
 
 func (s *ManagerService) FindByID(
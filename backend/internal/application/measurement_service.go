@@ -0,0 +1,326 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+const (
+	MetricWeight        = "weight"
+	MetricLength        = "length"
+	MetricBodyCondition = "body_condition"
+)
+
+type MeasurementOptions struct {
+	// defaults for species without their own threshold
+	MaxChangePercent float64
+	WindowDays       int
+}
+
+type MeasurementPoint struct {
+	MeasuredAt time.Time `json:"measured_at"`
+	Value      float64   `json:"value"`
+}
+
+// MeasurementTrend is the series of one metric with the change between
+// the first and the last point. The change fields are omitted for fewer
+// than two points.
+type MeasurementTrend struct {
+	Metric        string             `json:"metric"`
+	Series        []MeasurementPoint `json:"series"`
+	First         *float64           `json:"first,omitempty"`
+	Last          *float64           `json:"last,omitempty"`
+	Change        *float64           `json:"change,omitempty"`
+	ChangePercent *float64           `json:"change_percent,omitempty"`
+}
+
+type WeightAlert struct {
+	AnimalPublicID   string    `json:"animal_public_id"`
+	AnimalName       string    `json:"animal_name"`
+	SpeciesPublicID  string    `json:"species_public_id"`
+	Species          string    `json:"species"`
+	FromKg           float64   `json:"from_kg"`
+	ToKg             float64   `json:"to_kg"`
+	FromMeasuredAt   time.Time `json:"from_measured_at"`
+	ToMeasuredAt     time.Time `json:"to_measured_at"`
+	ChangePercent    float64   `json:"change_percent"`
+	ThresholdPercent float64   `json:"threshold_percent"`
+	WindowDays       int       `json:"window_days"`
+}
+
+// MeasurementService keeps body measurements of animals. Zone-scoped
+// managers only see and record those of animals in their zones; species
+// thresholds apply park-wide.
+type MeasurementService struct {
+	repo    ports.MeasurementRepository
+	animals *AnimalService
+	zones   *ZoneService
+	idGen   *id.UUIDGenerator
+	opts    MeasurementOptions
+}
+
+func NewMeasurementService(
+	repo ports.MeasurementRepository,
+	animals *AnimalService,
+	zones *ZoneService,
+	idGen *id.UUIDGenerator,
+	opts MeasurementOptions,
+) *MeasurementService {
+	return &MeasurementService{repo: repo, animals: animals, zones: zones, idGen: idGen, opts: opts}
+}
+
+func (s *MeasurementService) Record(
+	ctx context.Context,
+	actor Actor,
+	input ports.MeasurementInput,
+) (ports.MeasurementDTO, error) {
+
+	if input.WeightKg == nil && input.LengthCm == nil && input.BodyConditionScore == nil {
+		return ports.MeasurementDTO{}, invalidMeasurement("at least one of weight_kg, length_cm or body_condition_score is required")
+	}
+	if (input.WeightKg != nil && *input.WeightKg <= 0) || (input.LengthCm != nil && *input.LengthCm <= 0) {
+		return ports.MeasurementDTO{}, invalidMeasurement("weight_kg and length_cm must be positive")
+	}
+	if input.BodyConditionScore != nil && (*input.BodyConditionScore < 1 || *input.BodyConditionScore > 9) {
+		return ports.MeasurementDTO{}, invalidMeasurement("body_condition_score must be between 1 and 9")
+	}
+
+	now := time.Now()
+	if input.MeasuredAt.IsZero() {
+		input.MeasuredAt = now
+	}
+	if input.MeasuredAt.After(now.Add(5 * time.Minute)) {
+		return ports.MeasurementDTO{}, invalidMeasurement("measured_at must not be in the future")
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, input.AnimalPublicID); err != nil {
+		return ports.MeasurementDTO{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.MeasurementDTO{}, err
+	}
+	input.PublicID = publicID
+	input.RecordedByPublicID = actor.PublicID
+
+	if err := s.repo.Create(ctx, input); err != nil {
+		return ports.MeasurementDTO{}, err
+	}
+
+	return ports.MeasurementDTO{
+		PublicID:           input.PublicID,
+		MeasuredAt:         input.MeasuredAt,
+		WeightKg:           input.WeightKg,
+		LengthCm:           input.LengthCm,
+		BodyConditionScore: input.BodyConditionScore,
+		Notes:              input.Notes,
+		RecordedBy:         &input.RecordedByPublicID,
+	}, nil
+}
+
+// List returns the raw measurements, by default those of the last year.
+func (s *MeasurementService) List(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID string,
+	from, to *time.Time,
+) ([]ports.MeasurementDTO, error) {
+
+	if _, err := s.animals.FindByID(ctx, actor, animalPublicID); err != nil {
+		return nil, err
+	}
+
+	start, end := measurementRange(from, to)
+	return s.repo.ListByAnimal(ctx, animalPublicID, start, end)
+}
+
+func (s *MeasurementService) Trend(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID string,
+	metric string,
+	from, to *time.Time,
+) (MeasurementTrend, error) {
+
+	if metric == "" {
+		metric = MetricWeight
+	}
+
+	var value func(ports.MeasurementDTO) *float64
+	switch metric {
+	case MetricWeight:
+		value = func(m ports.MeasurementDTO) *float64 { return m.WeightKg }
+	case MetricLength:
+		value = func(m ports.MeasurementDTO) *float64 { return m.LengthCm }
+	case MetricBodyCondition:
+		value = func(m ports.MeasurementDTO) *float64 { return m.BodyConditionScore }
+	default:
+		return MeasurementTrend{}, invalidMeasurement("metric must be weight, length or body_condition")
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, animalPublicID); err != nil {
+		return MeasurementTrend{}, err
+	}
+
+	start, end := measurementRange(from, to)
+	measurements, err := s.repo.ListByAnimal(ctx, animalPublicID, start, end)
+	if err != nil {
+		return MeasurementTrend{}, err
+	}
+
+	trend := MeasurementTrend{
+		Metric: metric,
+		Series: make([]MeasurementPoint, 0, len(measurements)),
+	}
+	for _, m := range measurements {
+		if v := value(m); v != nil {
+			trend.Series = append(trend.Series, MeasurementPoint{MeasuredAt: m.MeasuredAt, Value: *v})
+		}
+	}
+
+	if n := len(trend.Series); n > 0 {
+		first, last := trend.Series[0].Value, trend.Series[n-1].Value
+		trend.First, trend.Last = &first, &last
+
+		if n > 1 {
+			change := round2(last - first)
+			percent := percentChange(first, last)
+			trend.Change, trend.ChangePercent = &change, &percent
+		}
+	}
+
+	return trend, nil
+}
+
+// WeightAlerts compares each animal's latest weight with the earliest one
+// inside its species' window before it, and reports changes beyond the
+// species threshold in either direction.
+func (s *MeasurementService) WeightAlerts(ctx context.Context, actor Actor) ([]WeightAlert, error) {
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	thresholds, err := s.repo.ListThresholds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bySpecies := make(map[string]ports.WeightThresholdDTO, len(thresholds))
+	maxWindow := s.opts.WindowDays
+	for _, t := range thresholds {
+		bySpecies[t.SpeciesPublicID] = t
+		maxWindow = max(maxWindow, t.WindowDays)
+	}
+
+	samples, err := s.repo.ListWeightsSince(ctx, time.Now().AddDate(0, 0, -maxWindow), scope)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]WeightAlert, 0)
+
+	for start := 0; start < len(samples); {
+		end := start
+		for end < len(samples) && samples[end].AnimalPublicID == samples[start].AnimalPublicID {
+			end++
+		}
+		series := samples[start:end]
+		start = end
+
+		threshold, ok := bySpecies[series[0].SpeciesPublicID]
+		if !ok {
+			threshold = ports.WeightThresholdDTO{
+				MaxChangePercent: s.opts.MaxChangePercent,
+				WindowDays:       s.opts.WindowDays,
+			}
+		}
+
+		latest := series[len(series)-1]
+		windowStart := latest.MeasuredAt.AddDate(0, 0, -threshold.WindowDays)
+
+		for _, baseline := range series[:len(series)-1] {
+			if baseline.MeasuredAt.Before(windowStart) {
+				continue
+			}
+
+			change := percentChange(baseline.WeightKg, latest.WeightKg)
+			if math.Abs(change) > threshold.MaxChangePercent {
+				result = append(result, WeightAlert{
+					AnimalPublicID:   latest.AnimalPublicID,
+					AnimalName:       latest.AnimalName,
+					SpeciesPublicID:  latest.SpeciesPublicID,
+					Species:          latest.Species,
+					FromKg:           baseline.WeightKg,
+					ToKg:             latest.WeightKg,
+					FromMeasuredAt:   baseline.MeasuredAt,
+					ToMeasuredAt:     latest.MeasuredAt,
+					ChangePercent:    change,
+					ThresholdPercent: threshold.MaxChangePercent,
+					WindowDays:       threshold.WindowDays,
+				})
+			}
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func (s *MeasurementService) ListThresholds(ctx context.Context) ([]ports.WeightThresholdDTO, error) {
+	return s.repo.ListThresholds(ctx)
+}
+
+func (s *MeasurementService) SetThreshold(
+	ctx context.Context,
+	threshold ports.WeightThresholdDTO,
+) (ports.WeightThresholdDTO, error) {
+
+	if threshold.SpeciesPublicID == "" {
+		return ports.WeightThresholdDTO{}, invalidMeasurement("species_public_id is required")
+	}
+	if threshold.MaxChangePercent <= 0 || threshold.MaxChangePercent >= 1000 {
+		return ports.WeightThresholdDTO{}, invalidMeasurement("max_change_percent must be between 0 and 1000")
+	}
+	if threshold.WindowDays < 1 || threshold.WindowDays > 366 {
+		return ports.WeightThresholdDTO{}, invalidMeasurement("window_days must be between 1 and 366")
+	}
+
+	return threshold, s.repo.UpsertThreshold(ctx, threshold)
+}
+
+func (s *MeasurementService) DeleteThreshold(ctx context.Context, speciesPublicID string) error {
+	return s.repo.DeleteThreshold(ctx, speciesPublicID)
+}
+
+func measurementRange(from, to *time.Time) (time.Time, time.Time) {
+	end := time.Now()
+	if to != nil {
+		end = to.AddDate(0, 0, 1)
+	}
+
+	start := end.AddDate(-1, 0, 0)
+	if from != nil {
+		start = *from
+	}
+
+	return start, end
+}
+
+func percentChange(from, to float64) float64 {
+	return round2((to - from) / from * 100)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func invalidMeasurement(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidMeasurement, reason)
+}
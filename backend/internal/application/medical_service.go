@@ -0,0 +1,371 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+const (
+	MedicalVisit        = "VISIT"
+	MedicalDiagnosis    = "DIAGNOSIS"
+	MedicalPrescription = "PRESCRIPTION"
+	MedicalVaccination  = "VACCINATION"
+)
+
+var diagnosisSeverities = map[string]bool{
+	"MILD":     true,
+	"MODERATE": true,
+	"SEVERE":   true,
+	"CRITICAL": true,
+}
+
+// MedicalTimelineEntry is one record in an animal's medical history. Type
+// tells which of the record fields is set.
+type MedicalTimelineEntry struct {
+	Type         string                 `json:"type"`
+	Date         time.Time              `json:"date"`
+	Visit        *ports.VetVisitDTO     `json:"visit,omitempty"`
+	Diagnosis    *ports.DiagnosisDTO    `json:"diagnosis,omitempty"`
+	Prescription *ports.PrescriptionDTO `json:"prescription,omitempty"`
+	Vaccination  *ports.VaccinationDTO  `json:"vaccination,omitempty"`
+}
+
+// MedicalService keeps the medical records of animals. Zone-scoped managers
+// only see and record those of animals in their zones.
+type MedicalService struct {
+	repo    ports.MedicalRepository
+	animals *AnimalService
+	zones   *ZoneService
+	idGen   *id.UUIDGenerator
+}
+
+func NewMedicalService(
+	repo ports.MedicalRepository,
+	animals *AnimalService,
+	zones *ZoneService,
+	idGen *id.UUIDGenerator,
+) *MedicalService {
+	return &MedicalService{repo: repo, animals: animals, zones: zones, idGen: idGen}
+}
+
+func (s *MedicalService) RecordVisit(
+	ctx context.Context,
+	actor Actor,
+	input ports.VetVisitInput,
+) (ports.VetVisitDTO, error) {
+
+	input.VetName = strings.TrimSpace(input.VetName)
+	if input.VetName == "" {
+		return ports.VetVisitDTO{}, invalidMedicalRecord("vet_name is required")
+	}
+	if input.VisitedAt.IsZero() {
+		input.VisitedAt = time.Now()
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, input.AnimalPublicID); err != nil {
+		return ports.VetVisitDTO{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.VetVisitDTO{}, err
+	}
+	input.PublicID = publicID
+	input.RecordedByPublicID = actor.PublicID
+
+	if err := s.repo.CreateVisit(ctx, input); err != nil {
+		return ports.VetVisitDTO{}, err
+	}
+
+	return ports.VetVisitDTO{
+		PublicID:   input.PublicID,
+		VisitedAt:  input.VisitedAt,
+		VetName:    input.VetName,
+		Reason:     input.Reason,
+		Notes:      input.Notes,
+		RecordedBy: &input.RecordedByPublicID,
+	}, nil
+}
+
+func (s *MedicalService) RecordDiagnosis(
+	ctx context.Context,
+	actor Actor,
+	input ports.DiagnosisInput,
+) (ports.DiagnosisDTO, error) {
+
+	input.Condition = strings.TrimSpace(input.Condition)
+	if input.Condition == "" {
+		return ports.DiagnosisDTO{}, invalidMedicalRecord("condition is required")
+	}
+
+	input.Severity = strings.ToUpper(strings.TrimSpace(input.Severity))
+	if input.Severity == "" {
+		input.Severity = "MODERATE"
+	}
+	if !diagnosisSeverities[input.Severity] {
+		return ports.DiagnosisDTO{}, invalidMedicalRecord("severity must be MILD, MODERATE, SEVERE or CRITICAL")
+	}
+
+	if input.DiagnosedOn.IsZero() {
+		input.DiagnosedOn = today()
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, input.AnimalPublicID); err != nil {
+		return ports.DiagnosisDTO{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.DiagnosisDTO{}, err
+	}
+	input.PublicID = publicID
+	input.RecordedByPublicID = actor.PublicID
+
+	if err := s.repo.CreateDiagnosis(ctx, input); err != nil {
+		return ports.DiagnosisDTO{}, err
+	}
+
+	return ports.DiagnosisDTO{
+		PublicID:      input.PublicID,
+		VisitPublicID: input.VisitPublicID,
+		Condition:     input.Condition,
+		Severity:      input.Severity,
+		Notes:         input.Notes,
+		DiagnosedOn:   input.DiagnosedOn,
+		RecordedBy:    &input.RecordedByPublicID,
+	}, nil
+}
+
+func (s *MedicalService) ResolveDiagnosis(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID, diagnosisPublicID string,
+	resolvedOn *time.Time,
+) error {
+
+	if _, err := s.animals.FindByID(ctx, actor, animalPublicID); err != nil {
+		return err
+	}
+
+	date := today()
+	if resolvedOn != nil {
+		date = *resolvedOn
+	}
+
+	return s.repo.ResolveDiagnosis(ctx, animalPublicID, diagnosisPublicID, date)
+}
+
+func (s *MedicalService) RecordPrescription(
+	ctx context.Context,
+	actor Actor,
+	input ports.PrescriptionInput,
+) (ports.PrescriptionDTO, error) {
+
+	input.Medication = strings.TrimSpace(input.Medication)
+	input.Dosage = strings.TrimSpace(input.Dosage)
+	if input.Medication == "" || input.Dosage == "" {
+		return ports.PrescriptionDTO{}, invalidMedicalRecord("medication and dosage are required")
+	}
+	if input.IntervalHours <= 0 {
+		return ports.PrescriptionDTO{}, invalidMedicalRecord("interval_hours must be positive")
+	}
+	if input.StartsOn.IsZero() {
+		input.StartsOn = today()
+	}
+	if input.EndsOn != nil && input.EndsOn.Before(input.StartsOn) {
+		return ports.PrescriptionDTO{}, invalidMedicalRecord("ends_on must not be before starts_on")
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, input.AnimalPublicID); err != nil {
+		return ports.PrescriptionDTO{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.PrescriptionDTO{}, err
+	}
+	input.PublicID = publicID
+	input.RecordedByPublicID = actor.PublicID
+
+	if err := s.repo.CreatePrescription(ctx, input); err != nil {
+		return ports.PrescriptionDTO{}, err
+	}
+
+	return ports.PrescriptionDTO{
+		PublicID:          input.PublicID,
+		VisitPublicID:     input.VisitPublicID,
+		DiagnosisPublicID: input.DiagnosisPublicID,
+		Medication:        input.Medication,
+		Dosage:            input.Dosage,
+		Route:             input.Route,
+		IntervalHours:     input.IntervalHours,
+		StartsOn:          input.StartsOn,
+		EndsOn:            input.EndsOn,
+		Instructions:      input.Instructions,
+		RecordedBy:        &input.RecordedByPublicID,
+	}, nil
+}
+
+func (s *MedicalService) RecordVaccination(
+	ctx context.Context,
+	actor Actor,
+	input ports.VaccinationInput,
+) (ports.VaccinationDTO, error) {
+
+	input.Vaccine = strings.TrimSpace(input.Vaccine)
+	if input.Vaccine == "" {
+		return ports.VaccinationDTO{}, invalidMedicalRecord("vaccine is required")
+	}
+	if input.AdministeredOn.IsZero() {
+		input.AdministeredOn = today()
+	}
+	if input.DueOn != nil && !input.DueOn.After(input.AdministeredOn) {
+		return ports.VaccinationDTO{}, invalidMedicalRecord("due_on must be after administered_on")
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, input.AnimalPublicID); err != nil {
+		return ports.VaccinationDTO{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.VaccinationDTO{}, err
+	}
+	input.PublicID = publicID
+	input.RecordedByPublicID = actor.PublicID
+
+	if err := s.repo.CreateVaccination(ctx, input); err != nil {
+		return ports.VaccinationDTO{}, err
+	}
+
+	return ports.VaccinationDTO{
+		PublicID:       input.PublicID,
+		VisitPublicID:  input.VisitPublicID,
+		Vaccine:        input.Vaccine,
+		AdministeredOn: input.AdministeredOn,
+		DueOn:          input.DueOn,
+		BatchNumber:    input.BatchNumber,
+		Notes:          input.Notes,
+		RecordedBy:     &input.RecordedByPublicID,
+	}, nil
+}
+
+// Timeline merges every medical record of the animal, newest first. An
+// empty types filter returns all record types.
+func (s *MedicalService) Timeline(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID string,
+	types []string,
+) ([]MedicalTimelineEntry, error) {
+
+	if _, err := s.animals.FindByID(ctx, actor, animalPublicID); err != nil {
+		return nil, err
+	}
+
+	want := func(t string) bool {
+		if len(types) == 0 {
+			return true
+		}
+		for _, v := range types {
+			if strings.EqualFold(v, t) {
+				return true
+			}
+		}
+		return false
+	}
+
+	result := make([]MedicalTimelineEntry, 0)
+
+	if want(MedicalVisit) {
+		visits, err := s.repo.ListVisits(ctx, animalPublicID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range visits {
+			result = append(result, MedicalTimelineEntry{
+				Type:  MedicalVisit,
+				Date:  visits[i].VisitedAt,
+				Visit: &visits[i],
+			})
+		}
+	}
+
+	if want(MedicalDiagnosis) {
+		diagnoses, err := s.repo.ListDiagnoses(ctx, animalPublicID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range diagnoses {
+			result = append(result, MedicalTimelineEntry{
+				Type:      MedicalDiagnosis,
+				Date:      diagnoses[i].DiagnosedOn,
+				Diagnosis: &diagnoses[i],
+			})
+		}
+	}
+
+	if want(MedicalPrescription) {
+		prescriptions, err := s.repo.ListPrescriptions(ctx, animalPublicID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range prescriptions {
+			result = append(result, MedicalTimelineEntry{
+				Type:         MedicalPrescription,
+				Date:         prescriptions[i].StartsOn,
+				Prescription: &prescriptions[i],
+			})
+		}
+	}
+
+	if want(MedicalVaccination) {
+		vaccinations, err := s.repo.ListVaccinations(ctx, animalPublicID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range vaccinations {
+			result = append(result, MedicalTimelineEntry{
+				Type:        MedicalVaccination,
+				Date:        vaccinations[i].AdministeredOn,
+				Vaccination: &vaccinations[i],
+			})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Date.After(result[j].Date)
+	})
+
+	return result, nil
+}
+
+// VaccinationsDue lists vaccinations falling due within the given number of
+// days, including overdue ones.
+func (s *MedicalService) VaccinationsDue(
+	ctx context.Context,
+	actor Actor,
+	withinDays int,
+) ([]ports.VaccinationDueDTO, error) {
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListVaccinationsDue(ctx, today().AddDate(0, 0, withinDays), scope)
+}
+
+func invalidMedicalRecord(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidMedicalRecord, reason)
+}
+
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
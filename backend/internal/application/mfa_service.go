@@ -0,0 +1,211 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/infrastructure/token"
+	"wit-leisure-park/backend/internal/infrastructure/totp"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+const recoveryCodeCount = 10
+
+type MFAEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type MFAService struct {
+	mfaRepo  ports.MFARepository
+	userRepo ports.UserRepository
+	issuer   string
+}
+
+func NewMFAService(
+	mfaRepo ports.MFARepository,
+	userRepo ports.UserRepository,
+	issuer string,
+) *MFAService {
+	return &MFAService{
+		mfaRepo:  mfaRepo,
+		userRepo: userRepo,
+		issuer:   issuer,
+	}
+}
+
+// Enroll generates a new secret. It only takes effect after Confirm, so an
+// abandoned enrollment never locks the user out.
+func (s *MFAService) Enroll(ctx context.Context, userPublicID string) (MFAEnrollment, error) {
+	enabled, err := s.IsEnabled(ctx, userPublicID)
+	if err != nil {
+		return MFAEnrollment{}, err
+	}
+	if enabled {
+		return MFAEnrollment{}, ErrMFAAlreadyEnabled
+	}
+
+	user, err := s.userRepo.FindByPublicID(ctx, userPublicID)
+	if err != nil {
+		return MFAEnrollment{}, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return MFAEnrollment{}, err
+	}
+
+	if err := s.mfaRepo.SaveSecret(ctx, userPublicID, secret); err != nil {
+		return MFAEnrollment{}, err
+	}
+
+	return MFAEnrollment{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(s.issuer, user.Username, secret),
+	}, nil
+}
+
+// Confirm enables MFA once the user proves the authenticator works and
+// returns the recovery codes. They are shown exactly once.
+func (s *MFAService) Confirm(
+	ctx context.Context,
+	userPublicID string,
+	code string,
+) ([]string, error) {
+
+	cfg, err := s.mfaRepo.FindByUser(ctx, userPublicID)
+	if errors.Is(err, ports.ErrNotFound) {
+		return nil, ErrMFANotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	if err := s.verifyTOTP(ctx, userPublicID, cfg.Secret, code); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		c, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, c)
+		hashes = append(hashes, token.Hash(normalizeRecoveryCode(c)))
+	}
+
+	if err := s.mfaRepo.Enable(ctx, userPublicID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *MFAService) Disable(
+	ctx context.Context,
+	userPublicID string,
+	code string,
+) error {
+
+	if err := s.Verify(ctx, userPublicID, code); err != nil {
+		return err
+	}
+
+	return s.mfaRepo.Disable(ctx, userPublicID)
+}
+
+func (s *MFAService) IsEnabled(ctx context.Context, userPublicID string) (bool, error) {
+	cfg, err := s.mfaRepo.FindByUser(ctx, userPublicID)
+	if errors.Is(err, ports.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return cfg.Enabled, nil
+}
+
+// Verify accepts either a current TOTP code or an unused recovery code.
+func (s *MFAService) Verify(
+	ctx context.Context,
+	userPublicID string,
+	code string,
+) error {
+
+	cfg, err := s.mfaRepo.FindByUser(ctx, userPublicID)
+	if errors.Is(err, ports.ErrNotFound) {
+		return ErrMFANotEnrolled
+	}
+	if err != nil {
+		return err
+	}
+	if !cfg.Enabled {
+		return ErrMFANotEnrolled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == 6 {
+		return s.verifyTOTP(ctx, userPublicID, cfg.Secret, code)
+	}
+
+	ok, err := s.mfaRepo.ConsumeRecoveryCode(
+		ctx,
+		userPublicID,
+		token.Hash(normalizeRecoveryCode(code)),
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+func (s *MFAService) verifyTOTP(
+	ctx context.Context,
+	userPublicID, secret, code string,
+) error {
+
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	// a code is accepted only once, even within its validity window
+	fresh, err := s.mfaRepo.MarkStepUsed(ctx, userPublicID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+func newRecoveryCode() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	c := strings.ToLower(base32.StdEncoding.EncodeToString(buf))
+	return c[:4] + "-" + c[4:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
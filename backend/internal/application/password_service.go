@@ -0,0 +1,157 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+	"wit-leisure-park/backend/internal/infrastructure/token"
+	"wit-leisure-park/backend/internal/ports"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const minPasswordLength = 8
+
+type PasswordReset struct {
+	ResetToken string    `json:"reset_token"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type PasswordService struct {
+	userRepo    ports.UserRepository
+	sessionRepo ports.SessionRepository
+	zookeepers  *ZookeeperService
+	resetTTL    time.Duration
+}
+
+func NewPasswordService(
+	userRepo ports.UserRepository,
+	sessionRepo ports.SessionRepository,
+	zookeepers *ZookeeperService,
+	resetTTL time.Duration,
+) *PasswordService {
+	return &PasswordService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		zookeepers:  zookeepers,
+		resetTTL:    resetTTL,
+	}
+}
+
+// Change updates the caller's own password after verifying the current one.
+// All sessions of the user are revoked, so every device has to log in again.
+func (s *PasswordService) Change(
+	ctx context.Context,
+	userPublicID string,
+	currentPassword string,
+	newPassword string,
+) error {
+
+	user, err := s.userRepo.FindByPublicID(ctx, userPublicID)
+	if err != nil {
+		return err
+	}
+
+	err = bcrypt.CompareHashAndPassword(
+		[]byte(user.PasswordHash),
+		[]byte(currentPassword),
+	)
+	if err != nil {
+		return ErrInvalidCredentials
+	}
+
+	if currentPassword == newPassword {
+		return errors.New("new password must differ from the current password")
+	}
+
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, userPublicID, hash, false); err != nil {
+		return err
+	}
+
+	return s.sessionRepo.RevokeAllForUser(ctx, userPublicID)
+}
+
+// IssueReset creates a one-time reset token for a zookeeper of the
+// manager's team. When forceChange is set the user also has to change the
+// password at next login and is logged out everywhere.
+func (s *PasswordService) IssueReset(
+	ctx context.Context,
+	actor Actor,
+	targetPublicID string,
+	forceChange bool,
+) (PasswordReset, error) {
+
+	if err := s.zookeepers.CheckTeam(ctx, actor, targetPublicID); err != nil {
+		return PasswordReset{}, err
+	}
+
+	resetToken, err := token.Generate()
+	if err != nil {
+		return PasswordReset{}, err
+	}
+
+	expiresAt := time.Now().Add(s.resetTTL)
+
+	err = s.userRepo.CreatePasswordReset(ctx, ports.PasswordResetInput{
+		UserPublicID:      targetPublicID,
+		CreatedByPublicID: actor.PublicID,
+		TokenHash:         token.Hash(resetToken),
+		ExpiresAt:         expiresAt,
+		ForceChange:       forceChange,
+	})
+	if err != nil {
+		return PasswordReset{}, err
+	}
+
+	if forceChange {
+		if err := s.sessionRepo.RevokeAllForUser(ctx, targetPublicID); err != nil {
+			return PasswordReset{}, err
+		}
+	}
+
+	return PasswordReset{
+		ResetToken: resetToken,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+// Reset redeems a reset token and sets a new password.
+func (s *PasswordService) Reset(
+	ctx context.Context,
+	resetToken string,
+	newPassword string,
+) error {
+
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	userPublicID, err := s.userRepo.ConsumePasswordReset(ctx, token.Hash(resetToken), hash)
+	if errors.Is(err, ports.ErrNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.sessionRepo.RevokeAllForUser(ctx, userPublicID)
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", ErrWeakPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"wit-leisure-park/backend/internal/domain"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+// permissionCacheTTL bounds how long a permission change made directly in
+// the database takes to reach running servers. Changes made through the
+// API invalidate the cache immediately.
+const permissionCacheTTL = 30 * time.Second
+
+type PermissionService struct {
+	repo ports.PermissionRepository
+
+	mu       sync.RWMutex
+	byRole   map[domain.Role]map[domain.Permission]struct{}
+	loadedAt time.Time
+}
+
+func NewPermissionService(repo ports.PermissionRepository) *PermissionService {
+	return &PermissionService{repo: repo}
+}
+
+func (s *PermissionService) HasPermission(
+	ctx context.Context,
+	role string,
+	permission domain.Permission,
+) (bool, error) {
+
+	byRole, err := s.load(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := byRole[domain.Role(role)][permission]
+	return ok, nil
+}
+
+func (s *PermissionService) ListPermissions(ctx context.Context) ([]ports.PermissionDTO, error) {
+	return s.repo.ListPermissions(ctx)
+}
+
+func (s *PermissionService) ListRoles(ctx context.Context) ([]ports.RolePermissionsDTO, error) {
+	return s.repo.ListRolePermissions(ctx)
+}
+
+func (s *PermissionService) SetRolePermissions(
+	ctx context.Context,
+	role domain.Role,
+	permissions []domain.Permission,
+) error {
+
+	if role != domain.RoleManager && role != domain.RoleZookeeper {
+		return fmt.Errorf("unknown role %q", role)
+	}
+
+	known, err := s.repo.ListPermissions(ctx)
+	if err != nil {
+		return err
+	}
+
+	valid := make(map[domain.Permission]struct{}, len(known))
+	for _, p := range known {
+		valid[p.Code] = struct{}{}
+	}
+
+	unique := make([]domain.Permission, 0, len(permissions))
+	seen := make(map[domain.Permission]struct{}, len(permissions))
+	for _, p := range permissions {
+		if _, ok := valid[p]; !ok {
+			return fmt.Errorf("unknown permission %q", p)
+		}
+		if _, dup := seen[p]; dup {
+			continue
+		}
+		seen[p] = struct{}{}
+		unique = append(unique, p)
+	}
+
+	// without this nobody could ever grant permissions again
+	if role == domain.RoleManager {
+		if _, ok := seen[domain.PermRolesManage]; !ok {
+			return fmt.Errorf("%s cannot be removed from %s", domain.PermRolesManage, domain.RoleManager)
+		}
+	}
+
+	if err := s.repo.ReplaceRolePermissions(ctx, role, unique); err != nil {
+		return err
+	}
+
+	s.invalidate()
+	return nil
+}
+
+func (s *PermissionService) load(ctx context.Context) (map[domain.Role]map[domain.Permission]struct{}, error) {
+	s.mu.RLock()
+	if s.byRole != nil && time.Since(s.loadedAt) < permissionCacheTTL {
+		byRole := s.byRole
+		s.mu.RUnlock()
+		return byRole, nil
+	}
+	s.mu.RUnlock()
+
+	roles, err := s.repo.ListRolePermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byRole := make(map[domain.Role]map[domain.Permission]struct{}, len(roles))
+	for _, r := range roles {
+		set := make(map[domain.Permission]struct{}, len(r.Permissions))
+		for _, p := range r.Permissions {
+			set[p] = struct{}{}
+		}
+		byRole[r.Role] = set
+	}
+
+	s.mu.Lock()
+	s.byRole = byRole
+	s.loadedAt = time.Now()
+	s.mu.Unlock()
+
+	return byRole, nil
+}
+
+func (s *PermissionService) invalidate() {
+	s.mu.Lock()
+	s.byRole = nil
+	s.mu.Unlock()
+}
@@ -0,0 +1,309 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/domain/animal"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+// QuarantineService runs quarantines. Zone-scoped managers only see and
+// run those held in cages of their zones.
+type QuarantineService struct {
+	repo    ports.QuarantineRepository
+	animals *AnimalService
+	zones   *ZoneService
+	compat  *CompatibilityService
+	idGen   *id.UUIDGenerator
+}
+
+func NewQuarantineService(
+	repo ports.QuarantineRepository,
+	animals *AnimalService,
+	zones *ZoneService,
+	compat *CompatibilityService,
+	idGen *id.UUIDGenerator,
+) *QuarantineService {
+	return &QuarantineService{
+		repo:    repo,
+		animals: animals,
+		zones:   zones,
+		compat:  compat,
+		idGen:   idGen,
+	}
+}
+
+// OpenQuarantineInput describes a new quarantine. Checks are the names of
+// the clearance checks that must pass before the animal is released.
+type OpenQuarantineInput struct {
+	AnimalPublicID    string
+	CagePublicID      string
+	Reason            string
+	VetName           string
+	ZookeeperPublicID string
+	StartedOn         *time.Time
+	ExpectedEndOn     *time.Time
+	Checks            []string
+	OverrideReason    *string
+}
+
+// Open quarantines an animal in the given cage. New arrivals coming back
+// from loan and animals in the park can be quarantined; the acting manager
+// owns the daily check tasks.
+func (s *QuarantineService) Open(
+	ctx context.Context,
+	actor Actor,
+	input OpenQuarantineInput,
+) (ports.QuarantineDTO, error) {
+
+	if !actor.IsManager() {
+		return ports.QuarantineDTO{}, ErrForbidden
+	}
+
+	input.Reason = strings.TrimSpace(input.Reason)
+	input.VetName = strings.TrimSpace(input.VetName)
+	switch {
+	case input.Reason == "":
+		return ports.QuarantineDTO{}, invalidQuarantine("reason is required")
+	case input.VetName == "":
+		return ports.QuarantineDTO{}, invalidQuarantine("vet_name is required")
+	case input.CagePublicID == "":
+		return ports.QuarantineDTO{}, invalidQuarantine("cage_public_id is required")
+	case input.ZookeeperPublicID == "":
+		return ports.QuarantineDTO{}, invalidQuarantine("zookeeper_public_id is required")
+	case input.ExpectedEndOn == nil:
+		return ports.QuarantineDTO{}, invalidQuarantine("expected_end_on is required")
+	}
+
+	startedOn := today()
+	if input.StartedOn != nil {
+		startedOn = *input.StartedOn
+	}
+	if input.ExpectedEndOn.Before(startedOn) {
+		return ports.QuarantineDTO{}, invalidQuarantine("expected_end_on must not be before started_on")
+	}
+
+	checks, err := s.newChecks(input.Checks)
+	if err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+
+	current, err := s.animals.FindByID(ctx, actor, input.AnimalPublicID)
+	if err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+	if err := s.zones.CheckCage(ctx, actor, input.CagePublicID); err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+	if current.Status != animal.StatusQuarantined {
+		if err := animal.ValidateTransition(current.Status, animal.StatusQuarantined); err != nil {
+			return ports.QuarantineDTO{}, err
+		}
+	}
+
+	var guard ports.PlacementGuard
+	if current.CageID != input.CagePublicID || !current.Status.InPark() {
+		guard = s.compat.Guard(actor, input.OverrideReason)
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+
+	err = s.repo.Open(ctx, ports.QuarantineInput{
+		PublicID:          publicID,
+		AnimalPublicID:    input.AnimalPublicID,
+		CagePublicID:      input.CagePublicID,
+		Reason:            input.Reason,
+		VetName:           input.VetName,
+		ManagerPublicID:   actor.PublicID,
+		ZookeeperPublicID: input.ZookeeperPublicID,
+		StartedOn:         startedOn,
+		ExpectedEndOn:     *input.ExpectedEndOn,
+		Checks:            checks,
+	}, current.Status, guard)
+	if err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, publicID)
+}
+
+func (s *QuarantineService) newChecks(names []string) ([]ports.QuarantineCheckDTO, error) {
+	checks := make([]ports.QuarantineCheckDTO, 0, len(names))
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, invalidQuarantine("check names must not be empty")
+		}
+		if seen[strings.ToLower(name)] {
+			return nil, invalidQuarantine("duplicate check " + name)
+		}
+		seen[strings.ToLower(name)] = true
+
+		publicID, err := s.idGen.NewID()
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, ports.QuarantineCheckDTO{PublicID: publicID, Name: name})
+	}
+
+	return checks, nil
+}
+
+func (s *QuarantineService) List(
+	ctx context.Context,
+	actor Actor,
+	status *string,
+) ([]ports.QuarantineDTO, error) {
+
+	var filter *ports.QuarantineStatus
+	if status != nil {
+		st := ports.QuarantineStatus(strings.ToUpper(*status))
+		switch st {
+		case ports.QuarantineOpen, ports.QuarantineCleared, ports.QuarantineClosed:
+		default:
+			return nil, invalidQuarantine("status must be OPEN, CLEARED or CLOSED")
+		}
+		filter = &st
+	}
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.List(ctx, filter, scope)
+}
+
+func (s *QuarantineService) ListByAnimal(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID string,
+) ([]ports.QuarantineDTO, error) {
+
+	if _, err := s.animals.FindByID(ctx, actor, animalPublicID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListByAnimal(ctx, animalPublicID)
+}
+
+func (s *QuarantineService) FindByID(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) (ports.QuarantineDTO, error) {
+
+	result, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+
+	if err := s.zones.CheckCageVisible(ctx, actor, result.CagePublicID); err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+
+	return result, nil
+}
+
+// Update changes the vet, the assigned zookeeper or the expected end of an
+// open quarantine. Daily check tasks already created keep their assignee.
+func (s *QuarantineService) Update(
+	ctx context.Context,
+	actor Actor,
+	input ports.QuarantineUpdateInput,
+) (ports.QuarantineDTO, error) {
+
+	input.VetName = strings.TrimSpace(input.VetName)
+	if input.VetName == "" {
+		return ports.QuarantineDTO{}, invalidQuarantine("vet_name is required")
+	}
+	if input.ZookeeperPublicID == "" {
+		return ports.QuarantineDTO{}, invalidQuarantine("zookeeper_public_id is required")
+	}
+
+	current, err := s.FindByID(ctx, actor, input.PublicID)
+	if err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+	if current.Status != ports.QuarantineOpen {
+		return ports.QuarantineDTO{}, invalidQuarantine("quarantine is already " + strings.ToLower(string(current.Status)))
+	}
+	if input.ExpectedEndOn.IsZero() {
+		input.ExpectedEndOn = current.ExpectedEndOn
+	}
+	if input.ExpectedEndOn.Before(current.StartedOn) {
+		return ports.QuarantineDTO{}, invalidQuarantine("expected_end_on must not be before started_on")
+	}
+
+	if err := s.repo.Update(ctx, input); err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, input.PublicID)
+}
+
+func (s *QuarantineService) PassCheck(
+	ctx context.Context,
+	actor Actor,
+	quarantinePublicID, checkPublicID string,
+	notes *string,
+) (ports.QuarantineDTO, error) {
+
+	if _, err := s.FindByID(ctx, actor, quarantinePublicID); err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+
+	err := s.repo.PassCheck(ctx, quarantinePublicID, checkPublicID, actor.PublicID, trimmedOrNil(notes))
+	if err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, quarantinePublicID)
+}
+
+// Clear releases the animal once every clearance check has passed. It
+// becomes ACTIVE again and may then be transferred out of the quarantine
+// cage.
+func (s *QuarantineService) Clear(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	notes *string,
+) (ports.QuarantineDTO, error) {
+
+	current, err := s.FindByID(ctx, actor, publicID)
+	if err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+	if current.Status != ports.QuarantineOpen {
+		return ports.QuarantineDTO{}, invalidQuarantine("quarantine is already " + strings.ToLower(string(current.Status)))
+	}
+
+	var pending []string
+	for _, c := range current.Checks {
+		if c.PassedAt == nil {
+			pending = append(pending, c.Name)
+		}
+	}
+	if len(pending) > 0 {
+		return ports.QuarantineDTO{}, fmt.Errorf("%w: checks not passed: %s", ports.ErrQuarantined, strings.Join(pending, ", "))
+	}
+
+	if err := s.repo.Clear(ctx, publicID, actor.PublicID, trimmedOrNil(notes)); err != nil {
+		return ports.QuarantineDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, publicID)
+}
+
+func invalidQuarantine(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidQuarantine, reason)
+}
@@ -0,0 +1,586 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/infrastructure/token"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+const (
+	// MaxReadingBatch caps the readings a device can send in one request.
+	MaxReadingBatch = 1000
+
+	defaultEnvironmentRange = 24 * time.Hour
+	maxEnvironmentRange     = 31 * 24 * time.Hour
+)
+
+// RegisteredSensor is a sensor with the device key it authenticates with.
+// The key is only returned when the sensor is registered or its key is
+// rotated.
+type RegisteredSensor struct {
+	ports.SensorDTO
+	DeviceKey string `json:"device_key"`
+}
+
+type SensorRequest struct {
+	CagePublicID string
+	Name         string
+	SerialNumber *string
+	Active       *bool
+}
+
+type ReadingRequest struct {
+	Metric     string
+	Value      float64
+	RecordedAt *time.Time
+}
+
+type IngestResult struct {
+	Accepted       int `json:"accepted"`
+	Duplicates     int `json:"duplicates"`
+	AlertsOpened   int `json:"alerts_opened"`
+	AlertsResolved int `json:"alerts_resolved"`
+}
+
+// SensorService manages cage sensors, their thresholds and alerts.
+// Zone-scoped managers only see and change those of cages in their zones.
+type SensorService struct {
+	repo  ports.SensorRepository
+	zones *ZoneService
+	idGen *id.UUIDGenerator
+}
+
+func NewSensorService(
+	repo ports.SensorRepository,
+	zones *ZoneService,
+	idGen *id.UUIDGenerator,
+) *SensorService {
+	return &SensorService{repo: repo, zones: zones, idGen: idGen}
+}
+
+func (s *SensorService) Register(
+	ctx context.Context,
+	actor Actor,
+	req SensorRequest,
+) (RegisteredSensor, error) {
+
+	input, err := sensorInput(req)
+	if err != nil {
+		return RegisteredSensor{}, err
+	}
+
+	if err := s.zones.CheckCage(ctx, actor, input.CagePublicID); err != nil {
+		return RegisteredSensor{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return RegisteredSensor{}, err
+	}
+	input.PublicID = publicID
+
+	key, err := token.Generate()
+	if err != nil {
+		return RegisteredSensor{}, err
+	}
+	input.DeviceKeyHash = token.Hash(key)
+
+	if err := s.repo.Create(ctx, input); err != nil {
+		return RegisteredSensor{}, err
+	}
+
+	sensor, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return RegisteredSensor{}, err
+	}
+
+	return RegisteredSensor{SensorDTO: sensor, DeviceKey: key}, nil
+}
+
+func (s *SensorService) List(ctx context.Context, actor Actor, cagePublicID *string) ([]ports.SensorDTO, error) {
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.List(ctx, cagePublicID, scope)
+}
+
+// FindByID hides sensors of cages outside the actor's zones as not found.
+func (s *SensorService) FindByID(ctx context.Context, actor Actor, publicID string) (ports.SensorDTO, error) {
+	sensor, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return ports.SensorDTO{}, err
+	}
+
+	if err := s.zones.CheckCageVisible(ctx, actor, sensor.CagePublicID); err != nil {
+		return ports.SensorDTO{}, err
+	}
+
+	return sensor, nil
+}
+
+// Update changes the sensor's details. Moving it to another cage only
+// affects readings taken from then on.
+func (s *SensorService) Update(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	req SensorRequest,
+) (ports.SensorDTO, error) {
+
+	if _, err := s.FindByID(ctx, actor, publicID); err != nil {
+		return ports.SensorDTO{}, err
+	}
+
+	input, err := sensorInput(req)
+	if err != nil {
+		return ports.SensorDTO{}, err
+	}
+	input.PublicID = publicID
+
+	if err := s.zones.CheckCage(ctx, actor, input.CagePublicID); err != nil {
+		return ports.SensorDTO{}, err
+	}
+
+	if err := s.repo.Update(ctx, input); err != nil {
+		return ports.SensorDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, publicID)
+}
+
+// RotateKey issues a new device key; the old one stops working at once.
+func (s *SensorService) RotateKey(ctx context.Context, actor Actor, publicID string) (RegisteredSensor, error) {
+
+	if _, err := s.FindByID(ctx, actor, publicID); err != nil {
+		return RegisteredSensor{}, err
+	}
+
+	key, err := token.Generate()
+	if err != nil {
+		return RegisteredSensor{}, err
+	}
+
+	if err := s.repo.RotateKey(ctx, publicID, token.Hash(key)); err != nil {
+		return RegisteredSensor{}, err
+	}
+
+	sensor, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return RegisteredSensor{}, err
+	}
+
+	return RegisteredSensor{SensorDTO: sensor, DeviceKey: key}, nil
+}
+
+func (s *SensorService) Delete(ctx context.Context, actor Actor, publicID string) error {
+	if _, err := s.FindByID(ctx, actor, publicID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, publicID)
+}
+
+// Ingest stores a batch of readings sent by the device the key belongs to
+// and evaluates them against the thresholds of the sensor's cage.
+// Readings already stored are skipped, so devices can safely resend a
+// batch.
+func (s *SensorService) Ingest(
+	ctx context.Context,
+	deviceKey string,
+	readings []ReadingRequest,
+) (IngestResult, error) {
+
+	if deviceKey == "" {
+		return IngestResult{}, ErrInvalidDeviceKey
+	}
+	sensor, err := s.repo.FindByKeyHash(ctx, token.Hash(deviceKey))
+	if errors.Is(err, ports.ErrNotFound) || (err == nil && !sensor.Active) {
+		return IngestResult{}, ErrInvalidDeviceKey
+	}
+	if err != nil {
+		return IngestResult{}, err
+	}
+
+	if len(readings) == 0 {
+		return IngestResult{}, invalidSensorData("readings are required")
+	}
+	if len(readings) > MaxReadingBatch {
+		return IngestResult{}, invalidSensorData(fmt.Sprintf("at most %d readings per batch", MaxReadingBatch))
+	}
+
+	now := time.Now()
+	inputs := make([]ports.ReadingInput, 0, len(readings))
+	for i, r := range readings {
+		metric := ports.EnvironmentMetric(strings.ToUpper(strings.TrimSpace(r.Metric)))
+		if !metric.Valid() {
+			return IngestResult{}, invalidSensorData(fmt.Sprintf("reading %d: metric must be TEMPERATURE or HUMIDITY", i))
+		}
+		if !metric.Plausible(r.Value) {
+			return IngestResult{}, invalidSensorData(fmt.Sprintf("reading %d: %g is not a plausible %s", i, r.Value, strings.ToLower(string(metric))))
+		}
+
+		recordedAt := now
+		if r.RecordedAt != nil {
+			recordedAt = *r.RecordedAt
+		}
+		if recordedAt.After(now.Add(5 * time.Minute)) {
+			return IngestResult{}, invalidSensorData(fmt.Sprintf("reading %d: recorded_at must not be in the future", i))
+		}
+
+		inputs = append(inputs, ports.ReadingInput{
+			Metric:     metric,
+			Value:      r.Value,
+			RecordedAt: recordedAt,
+		})
+	}
+
+	saved, err := s.repo.SaveReadings(ctx, sensor.PublicID, inputs)
+	if err != nil {
+		return IngestResult{}, err
+	}
+
+	result := IngestResult{
+		Accepted:   len(saved),
+		Duplicates: len(inputs) - len(saved),
+	}
+
+	byMetric := make(map[ports.EnvironmentMetric][]ports.ReadingInput)
+	for _, r := range saved {
+		byMetric[r.Metric] = append(byMetric[r.Metric], r)
+	}
+	for metric, metricReadings := range byMetric {
+		slices.SortStableFunc(metricReadings, func(a, b ports.ReadingInput) int {
+			return a.RecordedAt.Compare(b.RecordedAt)
+		})
+		if err := s.evaluate(ctx, sensor, metric, metricReadings, &result); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// evaluate walks the new readings of one metric in time order, opening an
+// alert when the cage leaves its range and resolving it with the first
+// reading back in range. Readings older than the last evaluated one are
+// stored but do not change alerts.
+func (s *SensorService) evaluate(
+	ctx context.Context,
+	sensor ports.SensorDTO,
+	metric ports.EnvironmentMetric,
+	readings []ports.ReadingInput,
+	result *IngestResult,
+) error {
+
+	threshold, err := s.repo.FindThreshold(ctx, sensor.CagePublicID, metric)
+	if errors.Is(err, ports.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var open *ports.EnvironmentAlertDTO
+	var evaluatedUntil time.Time
+
+	latest, err := s.repo.LatestAlert(ctx, sensor.CagePublicID, metric)
+	switch {
+	case errors.Is(err, ports.ErrNotFound):
+		// first alert of the cage and metric
+	case err != nil:
+		return err
+	case latest.ResolvedAt == nil:
+		open = &latest
+		evaluatedUntil = latest.LastReadingAt
+	default:
+		evaluatedUntil = *latest.ResolvedAt
+	}
+
+	changed := false
+	for _, r := range readings {
+		if r.RecordedAt.Before(evaluatedUntil) {
+			continue
+		}
+		evaluatedUntil = r.RecordedAt
+
+		breach := threshold.Threshold().Check(r.Value)
+
+		// a reading back in range, or on the other side of it, ends the alert
+		if open != nil && open.Kind != breach {
+			at := r.RecordedAt
+			open.ResolvedAt = &at
+			if err := s.repo.UpdateAlert(ctx, *open); err != nil {
+				return err
+			}
+			result.AlertsResolved++
+			open = nil
+			changed = false
+		}
+
+		if breach == "" {
+			continue
+		}
+
+		if open != nil {
+			open.LastReadingAt = r.RecordedAt
+			open.LastValue = r.Value
+			if breach.Worse(r.Value, open.PeakValue) {
+				open.PeakValue = r.Value
+			}
+			changed = true
+			continue
+		}
+
+		alert, opened, err := s.openAlert(ctx, sensor, threshold, breach, r)
+		if err != nil {
+			return err
+		}
+		if opened {
+			result.AlertsOpened++
+		}
+		open = &alert
+		changed = !opened
+	}
+
+	if open != nil && changed {
+		return s.repo.UpdateAlert(ctx, *open)
+	}
+
+	return nil
+}
+
+// openAlert opens an alert for the reading. When another batch opened one
+// for the cage in the meantime, that alert is returned instead, with
+// opened false.
+func (s *SensorService) openAlert(
+	ctx context.Context,
+	sensor ports.SensorDTO,
+	threshold ports.EnvironmentThresholdDTO,
+	breach ports.AlertKind,
+	r ports.ReadingInput,
+) (ports.EnvironmentAlertDTO, bool, error) {
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.EnvironmentAlertDTO{}, false, err
+	}
+
+	alert := ports.EnvironmentAlertDTO{
+		PublicID:       publicID,
+		CagePublicID:   sensor.CagePublicID,
+		CageCode:       sensor.CageCode,
+		SensorPublicID: &sensor.PublicID,
+		Metric:         r.Metric,
+		Kind:           breach,
+		MinValue:       threshold.MinValue,
+		MaxValue:       threshold.MaxValue,
+		StartedAt:      r.RecordedAt,
+		LastReadingAt:  r.RecordedAt,
+		LastValue:      r.Value,
+		PeakValue:      r.Value,
+	}
+
+	err = s.repo.OpenAlert(ctx, alert)
+	if errors.Is(err, ports.ErrDuplicate) {
+		existing, err := s.repo.LatestAlert(ctx, sensor.CagePublicID, r.Metric)
+		return existing, false, err
+	}
+	if err != nil {
+		return ports.EnvironmentAlertDTO{}, false, err
+	}
+
+	return alert, true, nil
+}
+
+// Aggregates returns the hourly min, max and average of the cage's
+// readings, by default over the last 24 hours. from and to are dates; to
+// is inclusive.
+func (s *SensorService) Aggregates(
+	ctx context.Context,
+	actor Actor,
+	cagePublicID string,
+	metric, sensorPublicID *string,
+	from, to *time.Time,
+) ([]ports.EnvironmentAggregateDTO, error) {
+
+	if err := s.zones.CheckCageVisible(ctx, actor, cagePublicID); err != nil {
+		return nil, err
+	}
+
+	query := ports.EnvironmentQuery{
+		CagePublicID:   cagePublicID,
+		SensorPublicID: sensorPublicID,
+		To:             time.Now(),
+	}
+	if to != nil {
+		query.To = to.AddDate(0, 0, 1)
+	}
+	query.From = query.To.Add(-defaultEnvironmentRange)
+	if from != nil {
+		query.From = *from
+	}
+	if !query.From.Before(query.To) {
+		return nil, invalidSensorData("from must not be after to")
+	}
+	if query.To.Sub(query.From) > maxEnvironmentRange {
+		return nil, invalidSensorData("the range must not exceed 31 days")
+	}
+
+	if metric != nil {
+		m, err := parseMetric(*metric)
+		if err != nil {
+			return nil, err
+		}
+		query.Metric = &m
+	}
+
+	return s.repo.HourlyAggregates(ctx, query)
+}
+
+func (s *SensorService) ListThresholds(ctx context.Context, actor Actor, cagePublicID string) ([]ports.EnvironmentThresholdDTO, error) {
+	if err := s.zones.CheckCageVisible(ctx, actor, cagePublicID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListThresholds(ctx, cagePublicID)
+}
+
+// SetThreshold replaces the range of one metric for a cage. The new range
+// applies from the next reading on.
+func (s *SensorService) SetThreshold(
+	ctx context.Context,
+	actor Actor,
+	cagePublicID, metric string,
+	minValue, maxValue *float64,
+) (ports.EnvironmentThresholdDTO, error) {
+
+	m, err := parseMetric(metric)
+	if err != nil {
+		return ports.EnvironmentThresholdDTO{}, err
+	}
+	if minValue == nil && maxValue == nil {
+		return ports.EnvironmentThresholdDTO{}, invalidSensorData("min_value or max_value is required")
+	}
+	if minValue != nil && maxValue != nil && *minValue >= *maxValue {
+		return ports.EnvironmentThresholdDTO{}, invalidSensorData("min_value must be below max_value")
+	}
+
+	if err := s.zones.CheckCage(ctx, actor, cagePublicID); err != nil {
+		return ports.EnvironmentThresholdDTO{}, err
+	}
+
+	threshold := ports.EnvironmentThresholdDTO{Metric: m, MinValue: minValue, MaxValue: maxValue}
+	if err := s.repo.UpsertThreshold(ctx, cagePublicID, threshold); err != nil {
+		return ports.EnvironmentThresholdDTO{}, err
+	}
+
+	return threshold, nil
+}
+
+func (s *SensorService) DeleteThreshold(ctx context.Context, actor Actor, cagePublicID, metric string) error {
+	m, err := parseMetric(metric)
+	if err != nil {
+		return err
+	}
+	if err := s.zones.CheckCage(ctx, actor, cagePublicID); err != nil {
+		return err
+	}
+	return s.repo.DeleteThreshold(ctx, cagePublicID, m)
+}
+
+// ListAlerts returns alerts newest first. status is "open", "resolved" or
+// empty for all.
+func (s *SensorService) ListAlerts(
+	ctx context.Context,
+	actor Actor,
+	cagePublicID *string,
+	status string,
+) ([]ports.EnvironmentAlertDTO, error) {
+
+	query := ports.EnvironmentAlertQuery{CagePublicID: cagePublicID}
+	switch strings.ToLower(status) {
+	case "":
+	case "open":
+		open := true
+		query.Open = &open
+	case "resolved":
+		open := false
+		query.Open = &open
+	default:
+		return nil, invalidSensorData("status must be open or resolved")
+	}
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+	query.ScopeZonePublicIDs = scope
+
+	return s.repo.ListAlerts(ctx, query)
+}
+
+func (s *SensorService) AcknowledgeAlert(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) (ports.EnvironmentAlertDTO, error) {
+
+	alert, err := s.repo.FindAlert(ctx, publicID)
+	if err != nil {
+		return ports.EnvironmentAlertDTO{}, err
+	}
+	if err := s.zones.CheckCageVisible(ctx, actor, alert.CagePublicID); err != nil {
+		return ports.EnvironmentAlertDTO{}, err
+	}
+	if alert.AcknowledgedAt != nil {
+		return ports.EnvironmentAlertDTO{}, invalidSensorData("alert is already acknowledged")
+	}
+
+	if err := s.repo.AcknowledgeAlert(ctx, publicID, actor.PublicID); err != nil {
+		return ports.EnvironmentAlertDTO{}, err
+	}
+
+	return s.repo.FindAlert(ctx, publicID)
+}
+
+func sensorInput(req SensorRequest) (ports.SensorInput, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return ports.SensorInput{}, invalidSensorData("name is required")
+	}
+	if strings.TrimSpace(req.CagePublicID) == "" {
+		return ports.SensorInput{}, invalidSensorData("cage_public_id is required")
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return ports.SensorInput{
+		CagePublicID: req.CagePublicID,
+		Name:         name,
+		SerialNumber: trimmedOrNil(req.SerialNumber),
+		Active:       active,
+	}, nil
+}
+
+func parseMetric(value string) (ports.EnvironmentMetric, error) {
+	metric := ports.EnvironmentMetric(strings.ToUpper(strings.TrimSpace(value)))
+	if !metric.Valid() {
+		return "", invalidSensorData("metric must be TEMPERATURE or HUMIDITY")
+	}
+	return metric, nil
+}
+
+func invalidSensorData(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidSensorData, reason)
+}
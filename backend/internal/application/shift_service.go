@@ -0,0 +1,386 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	"wit-leisure-park/backend/internal/domain/shift"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
+)
+
+const maxShiftRange = 31 * 24 * time.Hour
+
+type ShiftRequest struct {
+	ZookeeperPublicID string
+	ZonePublicID      *string
+	CagePublicID      *string
+	StartsAt          time.Time
+	EndsAt            time.Time
+	Notes             *string
+}
+
+// Roster is one week of shifts, grouped by zookeeper and day.
+type Roster struct {
+	WeekStart  time.Time     `json:"week_start"`
+	WeekEnd    time.Time     `json:"week_end"`
+	Zookeepers []RosterEntry `json:"zookeepers"`
+}
+
+type RosterEntry struct {
+	ZookeeperPublicID string      `json:"zookeeper_public_id"`
+	Zookeeper         string      `json:"zookeeper"`
+	Hours             float64     `json:"hours"`
+	Days              []RosterDay `json:"days"`
+}
+
+// RosterDay lists the shifts that start on the date.
+type RosterDay struct {
+	Date   string           `json:"date"`
+	Shifts []ports.ShiftDTO `json:"shifts"`
+}
+
+type ShiftService struct {
+	repo       ports.ShiftRepository
+	zookeepers *ZookeeperService
+	idGen      *id.UUIDGenerator
+	minRest    time.Duration
+}
+
+func NewShiftService(
+	repo ports.ShiftRepository,
+	zookeepers *ZookeeperService,
+	idGen *id.UUIDGenerator,
+	minRest time.Duration,
+) *ShiftService {
+	return &ShiftService{repo: repo, zookeepers: zookeepers, idGen: idGen, minRest: minRest}
+}
+
+// Create, Update and Delete are limited to zookeepers of the acting
+// manager's team.
+func (s *ShiftService) Create(
+	ctx context.Context,
+	actor Actor,
+	req ShiftRequest,
+) (ports.ShiftDTO, error) {
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.ShiftDTO{}, err
+	}
+
+	input, guard, err := s.prepare(actor, publicID, req)
+	if err != nil {
+		return ports.ShiftDTO{}, err
+	}
+
+	if err := s.zookeepers.CheckTeam(ctx, actor, input.ZookeeperPublicID); err != nil {
+		return ports.ShiftDTO{}, err
+	}
+
+	if err := s.repo.Create(ctx, input, guard); err != nil {
+		return ports.ShiftDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, publicID)
+}
+
+func (s *ShiftService) Update(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	req ShiftRequest,
+) (ports.ShiftDTO, error) {
+
+	current, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return ports.ShiftDTO{}, err
+	}
+	if err := s.zookeepers.CheckTeam(ctx, actor, current.ZookeeperPublicID); err != nil {
+		return ports.ShiftDTO{}, err
+	}
+
+	input, guard, err := s.prepare(actor, publicID, req)
+	if err != nil {
+		return ports.ShiftDTO{}, err
+	}
+
+	if input.ZookeeperPublicID != current.ZookeeperPublicID {
+		if err := s.zookeepers.CheckTeam(ctx, actor, input.ZookeeperPublicID); err != nil {
+			return ports.ShiftDTO{}, err
+		}
+	}
+
+	if err := s.repo.Update(ctx, input, guard); err != nil {
+		return ports.ShiftDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, publicID)
+}
+
+// FindByID lets zookeepers see their own shifts only.
+func (s *ShiftService) FindByID(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) (ports.ShiftDTO, error) {
+
+	result, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return ports.ShiftDTO{}, err
+	}
+
+	if !actor.IsManager() && result.ZookeeperPublicID != actor.PublicID {
+		return ports.ShiftDTO{}, ErrForbidden
+	}
+
+	return result, nil
+}
+
+// List returns the shifts between the two dates, both inclusive. Without
+// dates it lists the current week. Zookeepers only see their own shifts.
+func (s *ShiftService) List(
+	ctx context.Context,
+	actor Actor,
+	query ports.ShiftQuery,
+	from, to *time.Time,
+) ([]ports.ShiftDTO, error) {
+
+	query.From = shift.WeekStart(time.Now().UTC())
+	if from != nil {
+		query.From = *from
+	}
+	query.To = query.From.AddDate(0, 0, 7)
+	if to != nil {
+		query.To = to.AddDate(0, 0, 1)
+	}
+	if !query.From.Before(query.To) {
+		return nil, invalidShift("from must not be after to")
+	}
+	if query.To.Sub(query.From) > maxShiftRange {
+		return nil, invalidShift("the range must not exceed 31 days")
+	}
+
+	if !actor.IsManager() {
+		query.ZookeeperPublicID = &actor.PublicID
+		query.ManagerPublicID = nil
+	}
+
+	return s.repo.List(ctx, query)
+}
+
+// Roster returns the week containing the given day, Monday to Sunday. A
+// nil day means the current week.
+func (s *ShiftService) Roster(
+	ctx context.Context,
+	actor Actor,
+	query ports.ShiftQuery,
+	day *time.Time,
+) (Roster, error) {
+
+	ref := time.Now().UTC()
+	if day != nil {
+		ref = *day
+	}
+	start := shift.WeekStart(ref)
+	end := start.AddDate(0, 0, 7)
+
+	last := end.AddDate(0, 0, -1)
+	shifts, err := s.List(ctx, actor, query, &start, &last)
+	if err != nil {
+		return Roster{}, err
+	}
+
+	roster := Roster{
+		WeekStart:  start,
+		WeekEnd:    last,
+		Zookeepers: make([]RosterEntry, 0),
+	}
+
+	index := make(map[string]int)
+	for _, sh := range shifts {
+		i, ok := index[sh.ZookeeperPublicID]
+		if !ok {
+			entry := RosterEntry{
+				ZookeeperPublicID: sh.ZookeeperPublicID,
+				Zookeeper:         sh.Zookeeper,
+				Days:              make([]RosterDay, 7),
+			}
+			for d := range entry.Days {
+				entry.Days[d] = RosterDay{
+					Date:   start.AddDate(0, 0, d).Format(utils.DateLayout),
+					Shifts: make([]ports.ShiftDTO, 0),
+				}
+			}
+			i = len(roster.Zookeepers)
+			index[sh.ZookeeperPublicID] = i
+			roster.Zookeepers = append(roster.Zookeepers, entry)
+		}
+		entry := &roster.Zookeepers[i]
+
+		// a shift that started the Sunday before is listed on Monday
+		d := max(int(sh.StartsAt.Sub(start).Hours()/24), 0)
+		entry.Days[d].Shifts = append(entry.Days[d].Shifts, sh)
+
+		// hours are counted inside the week only
+		from := maxTime(sh.StartsAt, start)
+		to := minTime(sh.EndsAt, end)
+		entry.Hours += to.Sub(from).Hours()
+	}
+
+	slices.SortFunc(roster.Zookeepers, func(a, b RosterEntry) int {
+		return cmp.Compare(a.Zookeeper, b.Zookeeper)
+	})
+
+	return roster, nil
+}
+
+func (s *ShiftService) Delete(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) error {
+
+	current, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return err
+	}
+	if err := s.zookeepers.CheckTeam(ctx, actor, current.ZookeeperPublicID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, publicID)
+}
+
+// OnDuty reports whether the zookeeper has a shift on the day.
+func (s *ShiftService) OnDuty(
+	ctx context.Context,
+	zookeeperPublicID string,
+	day time.Time,
+) (bool, error) {
+
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	shifts, err := s.repo.List(ctx, ports.ShiftQuery{
+		From:              from,
+		To:                from.AddDate(0, 0, 1),
+		ZookeeperPublicID: &zookeeperPublicID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(shifts) > 0, nil
+}
+
+// Rostered returns the public ids of the zookeepers with a shift on the
+// day.
+func (s *ShiftService) Rostered(
+	ctx context.Context,
+	day time.Time,
+) (map[string]bool, error) {
+
+	shifts, err := s.OnDay(ctx, day, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(shifts))
+	for _, sh := range shifts {
+		result[sh.ZookeeperPublicID] = true
+	}
+
+	return result, nil
+}
+
+// OnDay returns the shifts that share any time with the day, of one team
+// when managerPublicID is set.
+func (s *ShiftService) OnDay(
+	ctx context.Context,
+	day time.Time,
+	managerPublicID *string,
+) ([]ports.ShiftDTO, error) {
+
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	return s.repo.List(ctx, ports.ShiftQuery{
+		From:            from,
+		To:              from.AddDate(0, 0, 1),
+		ManagerPublicID: managerPublicID,
+	})
+}
+
+// prepare validates a shift and returns the guard that checks it against
+// the zookeeper's other shifts, including those that end or start within
+// the minimum rest.
+func (s *ShiftService) prepare(
+	actor Actor,
+	publicID string,
+	req ShiftRequest,
+) (ports.ShiftInput, ports.ShiftGuard, error) {
+
+	if req.ZookeeperPublicID == "" {
+		return ports.ShiftInput{}, ports.ShiftGuard{}, invalidShift("zookeeper_public_id is required")
+	}
+	if (req.ZonePublicID == nil) == (req.CagePublicID == nil) {
+		return ports.ShiftInput{}, ports.ShiftGuard{}, invalidShift("a shift covers either a zone_public_id or a cage_public_id")
+	}
+
+	candidate := shift.Shift{
+		PublicID: publicID,
+		Start:    req.StartsAt.UTC(),
+		End:      req.EndsAt.UTC(),
+	}
+	if err := candidate.Validate(); err != nil {
+		return ports.ShiftInput{}, ports.ShiftGuard{}, fmt.Errorf("%w: %s", ErrInvalidShift, err.Error())
+	}
+
+	guard := ports.ShiftGuard{
+		Margin: s.minRest,
+		Check: func(nearby []ports.ShiftDTO) error {
+			others := make([]shift.Shift, 0, len(nearby))
+			for _, n := range nearby {
+				others = append(others, shift.Shift{
+					PublicID: n.PublicID,
+					Start:    n.StartsAt,
+					End:      n.EndsAt,
+				})
+			}
+
+			if conflicts := shift.Check(candidate, others, s.minRest); len(conflicts) > 0 {
+				return &ShiftConflictError{Conflicts: conflicts}
+			}
+			return nil
+		},
+	}
+
+	return ports.ShiftInput{
+		PublicID:          publicID,
+		ZookeeperPublicID: req.ZookeeperPublicID,
+		ZonePublicID:      req.ZonePublicID,
+		CagePublicID:      req.CagePublicID,
+		StartsAt:          candidate.Start,
+		EndsAt:            candidate.End,
+		Notes:             trimmedOrNil(req.Notes),
+		ActorPublicID:     actor.PublicID,
+	}, guard, nil
+}
+
+func invalidShift(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidShift, reason)
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
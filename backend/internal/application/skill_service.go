@@ -0,0 +1,334 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+const maxExpiringDays = 365
+
+type SkillRequest struct {
+	Code        string
+	Name        string
+	Description *string
+}
+
+type CertificationRequest struct {
+	ZookeeperPublicID string
+	SkillPublicID     string
+	CertifiedOn       *time.Time
+	ExpiresOn         *time.Time
+	Reference         *string
+	Notes             *string
+}
+
+// SkillService manages skills, the skills species and task templates
+// require, and the certifications zookeepers hold for them.
+type SkillService struct {
+	repo      ports.SkillRepository
+	templates *TaskTemplateService
+	idGen     *id.UUIDGenerator
+}
+
+func NewSkillService(
+	repo ports.SkillRepository,
+	templates *TaskTemplateService,
+	idGen *id.UUIDGenerator,
+) *SkillService {
+	return &SkillService{repo: repo, templates: templates, idGen: idGen}
+}
+
+func (s *SkillService) CreateSkill(
+	ctx context.Context,
+	req SkillRequest,
+) (ports.SkillDTO, error) {
+
+	input, err := skillInput(req)
+	if err != nil {
+		return ports.SkillDTO{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.SkillDTO{}, err
+	}
+	input.PublicID = publicID
+
+	if err := s.repo.CreateSkill(ctx, input); err != nil {
+		return ports.SkillDTO{}, err
+	}
+
+	return s.repo.FindSkill(ctx, publicID)
+}
+
+func (s *SkillService) ListSkills(ctx context.Context) ([]ports.SkillDTO, error) {
+	return s.repo.ListSkills(ctx)
+}
+
+func (s *SkillService) FindSkill(
+	ctx context.Context,
+	publicID string,
+) (ports.SkillDTO, error) {
+	return s.repo.FindSkill(ctx, publicID)
+}
+
+func (s *SkillService) UpdateSkill(
+	ctx context.Context,
+	publicID string,
+	req SkillRequest,
+) (ports.SkillDTO, error) {
+
+	input, err := skillInput(req)
+	if err != nil {
+		return ports.SkillDTO{}, err
+	}
+	input.PublicID = publicID
+
+	if err := s.repo.UpdateSkill(ctx, input); err != nil {
+		return ports.SkillDTO{}, err
+	}
+
+	return s.repo.FindSkill(ctx, publicID)
+}
+
+func (s *SkillService) DeleteSkill(
+	ctx context.Context,
+	publicID string,
+) error {
+	return s.repo.DeleteSkill(ctx, publicID)
+}
+
+func (s *SkillService) SpeciesSkills(
+	ctx context.Context,
+	speciesPublicID string,
+) ([]ports.SkillDTO, error) {
+	return s.repo.SpeciesSkills(ctx, speciesPublicID)
+}
+
+// SetSpeciesSkills replaces the skills needed to work with the species. An
+// empty list removes the requirement.
+func (s *SkillService) SetSpeciesSkills(
+	ctx context.Context,
+	speciesPublicID string,
+	skillPublicIDs []string,
+) ([]ports.SkillDTO, error) {
+
+	if err := s.repo.SetSpeciesSkills(ctx, speciesPublicID, skillPublicIDs); err != nil {
+		return nil, err
+	}
+
+	return s.repo.SpeciesSkills(ctx, speciesPublicID)
+}
+
+// TemplateSkills is limited to the manager who owns the template, like the
+// template itself.
+func (s *SkillService) TemplateSkills(
+	ctx context.Context,
+	actor Actor,
+	templatePublicID string,
+) ([]ports.SkillDTO, error) {
+
+	if _, err := s.templates.Get(ctx, actor, templatePublicID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.TemplateSkills(ctx, templatePublicID)
+}
+
+func (s *SkillService) SetTemplateSkills(
+	ctx context.Context,
+	actor Actor,
+	templatePublicID string,
+	skillPublicIDs []string,
+) ([]ports.SkillDTO, error) {
+
+	if _, err := s.templates.Get(ctx, actor, templatePublicID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetTemplateSkills(ctx, templatePublicID, skillPublicIDs); err != nil {
+		return nil, err
+	}
+
+	return s.repo.TemplateSkills(ctx, templatePublicID)
+}
+
+func (s *SkillService) CreateCertification(
+	ctx context.Context,
+	actor Actor,
+	req CertificationRequest,
+) (ports.CertificationDTO, error) {
+
+	if req.ZookeeperPublicID == "" || req.SkillPublicID == "" {
+		return ports.CertificationDTO{}, invalidCertification("zookeeper_public_id and skill_public_id are required")
+	}
+
+	input, err := certificationInput(req)
+	if err != nil {
+		return ports.CertificationDTO{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.CertificationDTO{}, err
+	}
+	input.PublicID = publicID
+	input.ActorPublicID = actor.PublicID
+
+	if err := s.repo.CreateCertification(ctx, input); err != nil {
+		return ports.CertificationDTO{}, err
+	}
+
+	return s.repo.FindCertification(ctx, publicID)
+}
+
+// ListCertifications shows zookeepers their own certifications only.
+func (s *SkillService) ListCertifications(
+	ctx context.Context,
+	actor Actor,
+	query ports.CertificationQuery,
+) ([]ports.CertificationDTO, error) {
+
+	if !actor.IsManager() {
+		query.ZookeeperPublicID = &actor.PublicID
+		query.ManagerPublicID = nil
+	}
+
+	return s.repo.ListCertifications(ctx, query)
+}
+
+// Expiring lists the certifications that expire within the next days,
+// today included.
+func (s *SkillService) Expiring(
+	ctx context.Context,
+	actor Actor,
+	days int,
+	query ports.CertificationQuery,
+) ([]ports.CertificationDTO, error) {
+
+	if days < 0 || days > maxExpiringDays {
+		return nil, invalidCertification(fmt.Sprintf("days must be between 0 and %d", maxExpiringDays))
+	}
+
+	now := time.Now().UTC()
+	query.ExpiresFrom = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	query.ExpiresTo = query.ExpiresFrom.AddDate(0, 0, days)
+
+	return s.ListCertifications(ctx, actor, query)
+}
+
+func (s *SkillService) FindCertification(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) (ports.CertificationDTO, error) {
+
+	c, err := s.repo.FindCertification(ctx, publicID)
+	if err != nil {
+		return ports.CertificationDTO{}, err
+	}
+
+	if !actor.IsManager() && c.ZookeeperPublicID != actor.PublicID {
+		return ports.CertificationDTO{}, ErrForbidden
+	}
+
+	return c, nil
+}
+
+// UpdateCertification records a renewal or a correction. The zookeeper and
+// skill of a certification cannot change.
+func (s *SkillService) UpdateCertification(
+	ctx context.Context,
+	publicID string,
+	req CertificationRequest,
+) (ports.CertificationDTO, error) {
+
+	input, err := certificationInput(req)
+	if err != nil {
+		return ports.CertificationDTO{}, err
+	}
+	input.PublicID = publicID
+
+	if err := s.repo.UpdateCertification(ctx, input); err != nil {
+		return ports.CertificationDTO{}, err
+	}
+
+	return s.repo.FindCertification(ctx, publicID)
+}
+
+func (s *SkillService) DeleteCertification(
+	ctx context.Context,
+	publicID string,
+) error {
+	return s.repo.DeleteCertification(ctx, publicID)
+}
+
+// Check returns a CertificationError when the zookeeper lacks a
+// certification, valid on the day, for a skill the animal's species or the
+// template requires.
+func (s *SkillService) Check(
+	ctx context.Context,
+	zookeeperPublicID string,
+	animalPublicID, templatePublicID *string,
+	day time.Time,
+) error {
+
+	if animalPublicID == nil && templatePublicID == nil {
+		return nil
+	}
+
+	missing, err := s.repo.MissingSkills(ctx, zookeeperPublicID, animalPublicID, templatePublicID, day)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return &CertificationError{Missing: missing}
+	}
+
+	return nil
+}
+
+func skillInput(req SkillRequest) (ports.SkillInput, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if code == "" {
+		return ports.SkillInput{}, invalidCertification("code is required")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return ports.SkillInput{}, invalidCertification("name is required")
+	}
+
+	return ports.SkillInput{
+		Code:        code,
+		Name:        name,
+		Description: trimmedOrNil(req.Description),
+	}, nil
+}
+
+func certificationInput(req CertificationRequest) (ports.CertificationInput, error) {
+	if req.CertifiedOn == nil {
+		return ports.CertificationInput{}, invalidCertification("certified_on is required")
+	}
+	if req.ExpiresOn != nil && req.ExpiresOn.Before(*req.CertifiedOn) {
+		return ports.CertificationInput{}, invalidCertification("expires_on must not be before certified_on")
+	}
+
+	return ports.CertificationInput{
+		ZookeeperPublicID: req.ZookeeperPublicID,
+		SkillPublicID:     req.SkillPublicID,
+		CertifiedOn:       *req.CertifiedOn,
+		ExpiresOn:         req.ExpiresOn,
+		Reference:         trimmedOrNil(req.Reference),
+		Notes:             trimmedOrNil(req.Notes),
+	}, nil
+}
+
+func invalidCertification(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidCertification, reason)
+}
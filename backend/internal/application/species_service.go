@@ -0,0 +1,172 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+// IUCN Red List categories, from extinct to not evaluated.
+var conservationStatuses = []string{"EX", "EW", "CR", "EN", "VU", "NT", "LC", "DD", "NE"}
+
+var dietTypes = []string{"CARNIVORE", "HERBIVORE", "OMNIVORE", "INSECTIVORE", "PISCIVORE"}
+
+type SpeciesService struct {
+	repo  ports.SpeciesRepository
+	idGen *id.UUIDGenerator
+}
+
+func NewSpeciesService(
+	repo ports.SpeciesRepository,
+	idGen *id.UUIDGenerator,
+) *SpeciesService {
+	return &SpeciesService{repo: repo, idGen: idGen}
+}
+
+func (s *SpeciesService) Create(
+	ctx context.Context,
+	input ports.SpeciesInput,
+) (ports.SpeciesDTO, error) {
+
+	if err := s.validate(ctx, &input); err != nil {
+		return ports.SpeciesDTO{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.SpeciesDTO{}, err
+	}
+	input.PublicID = publicID
+
+	if err := s.repo.Create(ctx, input); err != nil {
+		return ports.SpeciesDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, publicID)
+}
+
+func (s *SpeciesService) List(
+	ctx context.Context,
+	query ports.SpeciesListQuery,
+) (ports.Page[ports.SpeciesDTO], error) {
+	return s.repo.List(ctx, query)
+}
+
+func (s *SpeciesService) FindByID(
+	ctx context.Context,
+	publicID string,
+) (ports.SpeciesDTO, error) {
+	return s.repo.FindByID(ctx, publicID)
+}
+
+func (s *SpeciesService) Update(
+	ctx context.Context,
+	input ports.SpeciesInput,
+) (ports.SpeciesDTO, error) {
+
+	if err := s.validate(ctx, &input); err != nil {
+		return ports.SpeciesDTO{}, err
+	}
+
+	if err := s.repo.Update(ctx, input); err != nil {
+		return ports.SpeciesDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, input.PublicID)
+}
+
+func (s *SpeciesService) Delete(
+	ctx context.Context,
+	publicID string,
+) error {
+	return s.repo.Delete(ctx, publicID)
+}
+
+// Merge folds a duplicate catalog entry, such as one created from a
+// differently spelled free-text species, into the target species.
+func (s *SpeciesService) Merge(
+	ctx context.Context,
+	targetPublicID, duplicatePublicID string,
+) (ports.SpeciesDTO, error) {
+
+	if duplicatePublicID == "" {
+		return ports.SpeciesDTO{}, invalidSpecies("duplicate_public_id is required")
+	}
+	if duplicatePublicID == targetPublicID {
+		return ports.SpeciesDTO{}, invalidSpecies("a species cannot be merged into itself")
+	}
+
+	if err := s.repo.Merge(ctx, targetPublicID, duplicatePublicID); err != nil {
+		return ports.SpeciesDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, targetPublicID)
+}
+
+func (s *SpeciesService) validate(ctx context.Context, input *ports.SpeciesInput) error {
+	input.CommonName = strings.Join(strings.Fields(input.CommonName), " ")
+	if input.CommonName == "" {
+		return invalidSpecies("common_name is required")
+	}
+	input.ScientificName = trimmedOrNil(input.ScientificName)
+
+	if input.ConservationStatus = upperOrNil(input.ConservationStatus); input.ConservationStatus != nil &&
+		!slices.Contains(conservationStatuses, *input.ConservationStatus) {
+		return invalidSpecies("conservation_status must be one of " + strings.Join(conservationStatuses, ", "))
+	}
+	if input.DietType = upperOrNil(input.DietType); input.DietType != nil &&
+		!slices.Contains(dietTypes, *input.DietType) {
+		return invalidSpecies("diet_type must be one of " + strings.Join(dietTypes, ", "))
+	}
+
+	if input.MinTemperatureC != nil && input.MaxTemperatureC != nil &&
+		*input.MinTemperatureC > *input.MaxTemperatureC {
+		return invalidSpecies("min_temperature_c must not be above max_temperature_c")
+	}
+	if input.FeedingsPerDay != nil && (*input.FeedingsPerDay < 1 || *input.FeedingsPerDay > 24) {
+		return invalidSpecies("feedings_per_day must be between 1 and 24")
+	}
+
+	names := []string{input.CommonName}
+	if input.ScientificName != nil {
+		names = append(names, *input.ScientificName)
+	}
+	for _, name := range names {
+		exists, err := s.repo.NameExists(ctx, name, input.PublicID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("%w: %s", ErrSpeciesExists, name)
+		}
+	}
+
+	return nil
+}
+
+func trimmedOrNil(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	t := strings.Join(strings.Fields(*v), " ")
+	if t == "" {
+		return nil
+	}
+	return &t
+}
+
+func upperOrNil(v *string) *string {
+	t := trimmedOrNil(v)
+	if t == nil {
+		return nil
+	}
+	u := strings.ToUpper(*t)
+	return &u
+}
+
+func invalidSpecies(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidSpecies, reason)
+}
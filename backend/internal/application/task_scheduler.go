@@ -0,0 +1,265 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/domain/task"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TaskScheduler turns active templates into concrete tasks up to each
+// template's lead window, and creates the daily check task of every open
+// quarantine. Runs are idempotent: a task that already exists for a
+// template or quarantine and due date is skipped, so the scheduler can run
+// on several instances or be restarted at any time. No task is created
+// for a day on which the zookeeper has approved leave; the day is skipped
+// and logged.
+type TaskScheduler struct {
+	log         *logrus.Logger
+	templates   ports.TaskTemplateRepository
+	quarantines ports.QuarantineRepository
+	tasks       ports.TaskRepository
+	leave       *LeaveService
+	skills      *SkillService
+	idGen       *id.UUIDGenerator
+}
+
+func NewTaskScheduler(
+	log *logrus.Logger,
+	templates ports.TaskTemplateRepository,
+	quarantines ports.QuarantineRepository,
+	tasks ports.TaskRepository,
+	leave *LeaveService,
+	skills *SkillService,
+	idGen *id.UUIDGenerator,
+) *TaskScheduler {
+	return &TaskScheduler{
+		log:         log,
+		templates:   templates,
+		quarantines: quarantines,
+		tasks:       tasks,
+		leave:       leave,
+		skills:      skills,
+		idGen:       idGen,
+	}
+}
+
+// Run generates due tasks as of now and returns how many were created.
+// A failing template does not stop the others; their errors are joined.
+func (s *TaskScheduler) Run(ctx context.Context, now time.Time) (int, error) {
+
+	templates, err := s.templates.ListActive(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var errs []error
+
+	for _, t := range templates {
+		n, err := s.generate(ctx, t, now)
+		created += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("template %s: %w", t.PublicID, err))
+		}
+	}
+
+	quarantines, err := s.quarantines.ListOpen(ctx)
+	if err != nil {
+		return created, errors.Join(append(errs, err)...)
+	}
+
+	for _, q := range quarantines {
+		n, err := s.generateQuarantineChecks(ctx, q, now)
+		created += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("quarantine %s: %w", q.PublicID, err))
+		}
+	}
+
+	return created, errors.Join(errs...)
+}
+
+func (s *TaskScheduler) generate(
+	ctx context.Context,
+	t ports.TaskTemplateDTO,
+	now time.Time,
+) (int, error) {
+
+	rule, err := task.ParseRecurrence(t.Recurrence)
+	if err != nil {
+		return 0, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	from := today
+	if t.GeneratedUntil != nil && !t.GeneratedUntil.Before(from) {
+		from = t.GeneratedUntil.AddDate(0, 0, 1)
+	}
+
+	to := today.AddDate(0, 0, t.LeadDays)
+	if t.EndsOn != nil && t.EndsOn.Before(to) {
+		to = *t.EndsOn
+	}
+
+	if to.Before(from) {
+		return 0, nil
+	}
+
+	created := 0
+	for _, due := range rule.Occurrences(t.StartsOn, from, to) {
+		away, err := s.onLeave(ctx, t.ZookeeperPublicID, due, logrus.Fields{"template_id": t.PublicID})
+		if err != nil {
+			return created, err
+		}
+		if away {
+			continue
+		}
+
+		// the template stops here until the assignee is certified again or
+		// the template is given to someone who is
+		err = s.skills.Check(ctx, t.ZookeeperPublicID, t.AnimalPublicID, &t.PublicID, due)
+		if err != nil {
+			return created, err
+		}
+
+		publicID, err := s.idGen.NewID()
+		if err != nil {
+			return created, err
+		}
+
+		dueDate := due
+		_, err = s.tasks.Create(ctx, ports.TaskCreateInput{
+			PublicID:          publicID,
+			Title:             t.Title,
+			Description:       t.Description,
+			ManagerPublicID:   t.ManagerPublicID,
+			ZookeeperPublicID: t.ZookeeperPublicID,
+			AnimalPublicID:    t.AnimalPublicID,
+			DueDate:           &dueDate,
+			TemplatePublicID:  &t.PublicID,
+		})
+		if errors.Is(err, ports.ErrDuplicate) {
+			continue
+		}
+		if err != nil {
+			return created, err
+		}
+		created++
+	}
+
+	return created, s.templates.MarkGenerated(ctx, t.PublicID, to)
+}
+
+// generateQuarantineChecks creates one check task per day for the assigned
+// zookeeper while the quarantine is open, also past its expected end.
+// Days missed while the scheduler was down are not backfilled.
+func (s *TaskScheduler) generateQuarantineChecks(
+	ctx context.Context,
+	q ports.QuarantineDTO,
+	now time.Time,
+) (int, error) {
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	from := today
+	if q.StartedOn.After(from) {
+		from = q.StartedOn
+	}
+	if q.GeneratedUntil != nil && !q.GeneratedUntil.Before(from) {
+		from = q.GeneratedUntil.AddDate(0, 0, 1)
+	}
+
+	if today.Before(from) {
+		return 0, nil
+	}
+
+	description := quarantineCheckDescription(q)
+
+	created := 0
+	for due := from; !due.After(today); due = due.AddDate(0, 0, 1) {
+		away, err := s.onLeave(ctx, q.ZookeeperPublicID, due, logrus.Fields{"quarantine_id": q.PublicID})
+		if err != nil {
+			return created, err
+		}
+		if away {
+			continue
+		}
+
+		publicID, err := s.idGen.NewID()
+		if err != nil {
+			return created, err
+		}
+
+		dueDate := due
+		_, err = s.tasks.Create(ctx, ports.TaskCreateInput{
+			PublicID:           publicID,
+			Title:              "Quarantine check: " + q.AnimalName,
+			Description:        &description,
+			ManagerPublicID:    q.ManagerPublicID,
+			ZookeeperPublicID:  q.ZookeeperPublicID,
+			AnimalPublicID:     &q.AnimalPublicID,
+			DueDate:            &dueDate,
+			QuarantinePublicID: &q.PublicID,
+		})
+		if errors.Is(err, ports.ErrDuplicate) {
+			continue
+		}
+		if err != nil {
+			return created, err
+		}
+		created++
+	}
+
+	return created, s.quarantines.MarkGenerated(ctx, q.PublicID, today)
+}
+
+// onLeave reports whether the zookeeper has approved leave on the day,
+// logging the task that is skipped for it.
+func (s *TaskScheduler) onLeave(
+	ctx context.Context,
+	zookeeperPublicID string,
+	due time.Time,
+	fields logrus.Fields,
+) (bool, error) {
+
+	away, err := s.leave.Away(ctx, zookeeperPublicID, due)
+	if err != nil || away == nil {
+		return false, err
+	}
+
+	s.log.WithFields(fields).WithFields(logrus.Fields{
+		"zookeeper_id": zookeeperPublicID,
+		"due_date":     due.Format(utils.DateLayout),
+		"leave_id":     away.PublicID,
+	}).Warn("task skipped, the zookeeper is on leave")
+
+	return true, nil
+}
+
+func quarantineCheckDescription(q ports.QuarantineDTO) string {
+	var pending []string
+	for _, c := range q.Checks {
+		if c.PassedAt == nil {
+			pending = append(pending, c.Name)
+		}
+	}
+
+	description := fmt.Sprintf(
+		"Daily quarantine check in cage %s (vet: %s, expected end %s).",
+		q.CageCode, q.VetName, q.ExpectedEndOn.Format(utils.DateLayout),
+	)
+	if len(pending) > 0 {
+		description += " Pending clearance checks: " + strings.Join(pending, ", ") + "."
+	}
+
+	return description
+}
@@ -2,77 +2,304 @@ package application
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
+	"wit-leisure-park/backend/internal/domain/leave"
+	"wit-leisure-park/backend/internal/domain/task"
 	"wit-leisure-park/backend/internal/infrastructure/id"
 	"wit-leisure-park/backend/internal/ports"
+	"wit-leisure-park/backend/internal/utils"
 )
 
+// CreatedTask is a new task with the warnings the manager should know
+// about. Warnings never prevent the task from being created. Assignment
+// explains the choice of an automatically assigned zookeeper.
+type CreatedTask struct {
+	PublicID   string      `json:"public_id"`
+	Warnings   []string    `json:"warnings,omitempty"`
+	Assignment *Assignment `json:"assignment,omitempty"`
+}
+
 type TaskService struct {
-	repo  ports.TaskRepository
-	idGen *id.UUIDGenerator
+	repo       ports.TaskRepository
+	animals    *AnimalService
+	zones      *ZoneService
+	zookeepers *ZookeeperService
+	shifts     *ShiftService
+	leave      *LeaveService
+	skills     *SkillService
+	assigner   *AssignmentService
+	idGen      *id.UUIDGenerator
 }
 
 func NewTaskService(
 	repo ports.TaskRepository,
+	animals *AnimalService,
+	zones *ZoneService,
+	zookeepers *ZookeeperService,
+	shifts *ShiftService,
+	leave *LeaveService,
+	skills *SkillService,
+	assigner *AssignmentService,
 	idGen *id.UUIDGenerator,
 ) *TaskService {
 	return &TaskService{
-		repo:  repo,
-		idGen: idGen,
+		repo:       repo,
+		animals:    animals,
+		zones:      zones,
+		zookeepers: zookeepers,
+		shifts:     shifts,
+		leave:      leave,
+		skills:     skills,
+		assigner:   assigner,
+		idGen:      idGen,
 	}
 }
 
+// Create refuses a task due while the zookeeper is on approved leave, and
+// a task for an animal whose species needs a certification the zookeeper
+// does not hold on the due date (today without one). Pending leave only
+// warns. The zookeeper must be in the acting manager's team, and
+// zone-scoped managers can only create tasks for animals in their zones.
 func (s *TaskService) Create(
 	ctx context.Context,
+	actor Actor,
 	title string,
 	description *string,
-	managerPublicID string,
 	zookeeperPublicID string,
 	animalPublicID *string,
 	dueDate *time.Time,
-) (string, error) {
+) (CreatedTask, error) {
+
+	if err := s.zookeepers.CheckTeam(ctx, actor, zookeeperPublicID); err != nil {
+		return CreatedTask{}, err
+	}
+
+	if animalPublicID != nil {
+		if _, err := s.animals.FindByID(ctx, actor, *animalPublicID); err != nil {
+			return CreatedTask{}, err
+		}
+	}
+
+	day := time.Now().UTC()
+	if dueDate != nil {
+		day = *dueDate
+	}
+	if err := s.skills.Check(ctx, zookeeperPublicID, animalPublicID, nil, day); err != nil {
+		return CreatedTask{}, err
+	}
+
+	var warnings []string
+
+	if dueDate != nil {
+		away, err := s.leave.On(ctx, zookeeperPublicID, *dueDate)
+		if err != nil {
+			return CreatedTask{}, err
+		}
+		for _, l := range away {
+			if l.Status == leave.StatusApproved {
+				return CreatedTask{}, fmt.Errorf("%w: %s leave from %s to %s",
+					ErrZookeeperUnavailable,
+					strings.ToLower(string(l.Type)),
+					l.StartsOn.Format(utils.DateLayout),
+					l.EndsOn.Format(utils.DateLayout),
+				)
+			}
+			warnings = append(warnings, fmt.Sprintf(
+				"the zookeeper has requested leave from %s to %s",
+				l.StartsOn.Format(utils.DateLayout),
+				l.EndsOn.Format(utils.DateLayout),
+			))
+		}
+
+		onDuty, err := s.shifts.OnDuty(ctx, zookeeperPublicID, *dueDate)
+		if err != nil {
+			return CreatedTask{}, err
+		}
+		if !onDuty {
+			warnings = append(warnings, fmt.Sprintf(
+				"the zookeeper has no shift on %s", dueDate.Format(utils.DateLayout),
+			))
+		}
+	}
 
 	publicID, err := s.idGen.NewID()
 	if err != nil {
-		return "", err
+		return CreatedTask{}, err
 	}
 
-	return s.repo.Create(ctx, ports.TaskCreateInput{
+	taskID, err := s.repo.Create(ctx, ports.TaskCreateInput{
 		PublicID:          publicID,
 		Title:             title,
 		Description:       description,
-		ManagerPublicID:   managerPublicID,
+		ManagerPublicID:   actor.PublicID,
 		ZookeeperPublicID: zookeeperPublicID,
 		AnimalPublicID:    animalPublicID,
 		DueDate:           dueDate,
 	})
+	if err != nil {
+		return CreatedTask{}, err
+	}
+
+	return CreatedTask{PublicID: taskID, Warnings: warnings}, nil
 }
 
-func (s *TaskService) ListByManager(
+// CreateAutoAssigned creates a task for the zookeeper of the acting
+// manager's team that the assigner ranks best on the due date.
+func (s *TaskService) CreateAutoAssigned(
 	ctx context.Context,
-	managerPublicID string,
-) ([]ports.TaskDTO, error) {
-	return s.repo.ListByManager(ctx, managerPublicID)
+	actor Actor,
+	title string,
+	description *string,
+	animalPublicID *string,
+	dueDate *time.Time,
+) (CreatedTask, error) {
+
+	if dueDate == nil {
+		return CreatedTask{}, ErrAutoAssignDueDate
+	}
+
+	assignment, err := s.assigner.Choose(ctx, actor, animalPublicID, *dueDate)
+	if err != nil {
+		return CreatedTask{}, err
+	}
+
+	result, err := s.Create(
+		ctx,
+		actor,
+		title,
+		description,
+		assignment.ZookeeperPublicID,
+		animalPublicID,
+		dueDate,
+	)
+	if err != nil {
+		return CreatedTask{}, err
+	}
+
+	result.Assignment = &assignment
+	return result, nil
 }
 
-func (s *TaskService) ListByZookeeper(
+// List shows managers the tasks they created, leaving out those for
+// animals outside their zones, and zookeepers the tasks assigned to them.
+func (s *TaskService) List(
 	ctx context.Context,
-	zookeeperPublicID string,
-) ([]ports.TaskDTO, error) {
-	return s.repo.ListByZookeeper(ctx, zookeeperPublicID)
+	actor Actor,
+	query ports.TaskListQuery,
+) (ports.Page[ports.TaskDTO], error) {
+
+	if !actor.IsManager() {
+		return s.repo.ListByZookeeper(ctx, actor.PublicID, query)
+	}
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return ports.Page[ports.TaskDTO]{}, err
+	}
+	query.ScopeZonePublicIDs = scope
+
+	return s.repo.ListByManager(ctx, actor.PublicID, query)
 }
 
+// UpdateStatus is allowed for the assigned zookeeper, the manager who
+// created the task and the manager of the assignee's team.
 func (s *TaskService) UpdateStatus(
 	ctx context.Context,
+	actor Actor,
 	publicID string,
 	status ports.TaskStatus,
+	note *string,
 ) error {
-	return s.repo.UpdateStatus(ctx, publicID, status)
+
+	owner, err := s.ownership(ctx, publicID)
+	if err != nil {
+		return err
+	}
+
+	if !canAccessTask(actor, owner) {
+		return ErrForbidden
+	}
+
+	if err := task.ValidateTransition(owner.Status, status); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateStatus(ctx, ports.TaskStatusChange{
+		PublicID:      publicID,
+		From:          owner.Status,
+		To:            status,
+		ActorPublicID: actor.PublicID,
+		Note:          note,
+	})
 }
 
+// History is visible to everyone allowed to change the task's status.
+func (s *TaskService) History(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) ([]ports.TaskStatusHistoryDTO, error) {
+
+	owner, err := s.ownership(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canAccessTask(actor, owner) {
+		return nil, ErrForbidden
+	}
+
+	return s.repo.ListStatusHistory(ctx, publicID)
+}
+
+// Delete is allowed for the manager who created the task and the manager
+// of the assignee's team.
 func (s *TaskService) Delete(
 	ctx context.Context,
+	actor Actor,
 	publicID string,
 ) error {
+
+	owner, err := s.ownership(ctx, publicID)
+	if err != nil {
+		return err
+	}
+
+	if !managesTask(actor, owner) {
+		return ErrForbidden
+	}
+
 	return s.repo.Delete(ctx, publicID)
 }
+
+func (s *TaskService) ownership(
+	ctx context.Context,
+	publicID string,
+) (ports.TaskOwnership, error) {
+
+	owner, err := s.repo.FindOwnership(ctx, publicID)
+	if errors.Is(err, ports.ErrNotFound) {
+		return ports.TaskOwnership{}, ErrTaskNotFound
+	}
+
+	return owner, err
+}
+
+func canAccessTask(actor Actor, owner ports.TaskOwnership) bool {
+	return owner.ZookeeperPublicID == actor.PublicID || managesTask(actor, owner)
+}
+
+func managesTask(actor Actor, owner ports.TaskOwnership) bool {
+	if !actor.IsManager() {
+		return false
+	}
+
+	if owner.ManagerPublicID == actor.PublicID {
+		return true
+	}
+
+	return owner.TeamManagerPublicID != nil && *owner.TeamManagerPublicID == actor.PublicID
+}
@@ -0,0 +1,184 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"wit-leisure-park/backend/internal/domain/task"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+const maxTemplateLeadDays = 90
+
+// TaskTemplateService manages recurring task templates. Zone-scoped
+// managers only see and set up templates for animals in their zones.
+type TaskTemplateService struct {
+	repo    ports.TaskTemplateRepository
+	animals *AnimalService
+	zones   *ZoneService
+	idGen   *id.UUIDGenerator
+}
+
+func NewTaskTemplateService(
+	repo ports.TaskTemplateRepository,
+	animals *AnimalService,
+	zones *ZoneService,
+	idGen *id.UUIDGenerator,
+) *TaskTemplateService {
+	return &TaskTemplateService{
+		repo:    repo,
+		animals: animals,
+		zones:   zones,
+		idGen:   idGen,
+	}
+}
+
+// Create stores a template owned by the acting manager. Tasks are not
+// generated here; the scheduler picks the template up on its next run.
+func (s *TaskTemplateService) Create(
+	ctx context.Context,
+	actor Actor,
+	input ports.TaskTemplateInput,
+) (string, error) {
+
+	if err := validateTemplate(&input); err != nil {
+		return "", err
+	}
+
+	if err := s.checkAnimal(ctx, actor, input.AnimalPublicID); err != nil {
+		return "", err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return "", err
+	}
+
+	input.PublicID = publicID
+	input.ManagerPublicID = actor.PublicID
+
+	return s.repo.Create(ctx, input)
+}
+
+// List leaves out templates for animals outside the manager's zones.
+func (s *TaskTemplateService) List(
+	ctx context.Context,
+	actor Actor,
+) ([]ports.TaskTemplateDTO, error) {
+
+	scope, err := s.zones.Scope(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListByManager(ctx, actor.PublicID, scope)
+}
+
+func (s *TaskTemplateService) Get(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) (ports.TaskTemplateDTO, error) {
+	return s.owned(ctx, actor, publicID)
+}
+
+// Update changes future generation only; tasks already created from the
+// template are left as they are.
+func (s *TaskTemplateService) Update(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	input ports.TaskTemplateInput,
+) error {
+
+	if _, err := s.owned(ctx, actor, publicID); err != nil {
+		return err
+	}
+
+	if err := validateTemplate(&input); err != nil {
+		return err
+	}
+
+	if err := s.checkAnimal(ctx, actor, input.AnimalPublicID); err != nil {
+		return err
+	}
+
+	input.PublicID = publicID
+	input.ManagerPublicID = actor.PublicID
+
+	return s.repo.Update(ctx, input)
+}
+
+// Delete removes the template; generated tasks keep existing without it.
+func (s *TaskTemplateService) Delete(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) error {
+
+	if _, err := s.owned(ctx, actor, publicID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, publicID)
+}
+
+func (s *TaskTemplateService) owned(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) (ports.TaskTemplateDTO, error) {
+
+	t, err := s.repo.FindByID(ctx, publicID)
+	if err != nil {
+		return ports.TaskTemplateDTO{}, err
+	}
+
+	if t.ManagerPublicID != actor.PublicID {
+		return ports.TaskTemplateDTO{}, ErrForbidden
+	}
+
+	if err := s.checkAnimal(ctx, actor, t.AnimalPublicID); err != nil {
+		return ports.TaskTemplateDTO{}, err
+	}
+
+	return t, nil
+}
+
+// checkAnimal hides animals outside the actor's zones as not found.
+// Templates without an animal are not tied to a zone.
+func (s *TaskTemplateService) checkAnimal(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID *string,
+) error {
+
+	if animalPublicID == nil {
+		return nil
+	}
+
+	_, err := s.animals.FindByID(ctx, actor, *animalPublicID)
+	return err
+}
+
+func validateTemplate(input *ports.TaskTemplateInput) error {
+	input.Title = strings.TrimSpace(input.Title)
+	if input.Title == "" {
+		return ErrTemplateTitle
+	}
+
+	if _, err := task.ParseRecurrence(input.Recurrence); err != nil {
+		return err
+	}
+	input.Recurrence = strings.ToUpper(strings.TrimSpace(input.Recurrence))
+
+	if input.LeadDays < 0 || input.LeadDays > maxTemplateLeadDays {
+		return ErrInvalidLeadDays
+	}
+
+	if input.EndsOn != nil && input.EndsOn.Before(input.StartsOn) {
+		return ErrInvalidTemplateDays
+	}
+
+	return nil
+}
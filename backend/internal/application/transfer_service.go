@@ -0,0 +1,119 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+type TransferService struct {
+	repo    ports.CageAssignmentRepository
+	animals *AnimalService
+	zones   *ZoneService
+	compat  *CompatibilityService
+}
+
+func NewTransferService(
+	repo ports.CageAssignmentRepository,
+	animals *AnimalService,
+	zones *ZoneService,
+	compat *CompatibilityService,
+) *TransferService {
+	return &TransferService{repo: repo, animals: animals, zones: zones, compat: compat}
+}
+
+// Transfer moves an animal to another cage and records who moved it and
+// why. A back-dated transfer must not start before the animal's current
+// stay did. overrideReason approves sharing the cage with species that
+// need a manager's approval. Zone-scoped managers can only move animals
+// within their zones.
+func (s *TransferService) Transfer(
+	ctx context.Context,
+	actor Actor,
+	input ports.TransferInput,
+	overrideReason *string,
+) (ports.TransferDTO, error) {
+
+	input.Reason = strings.TrimSpace(input.Reason)
+	if input.Reason == "" {
+		return ports.TransferDTO{}, invalidTransfer("reason is required")
+	}
+	if input.ToCagePublicID == "" {
+		return ports.TransferDTO{}, invalidTransfer("to_cage_public_id is required")
+	}
+	if input.TransferredAt != nil && input.TransferredAt.After(time.Now().Add(5*time.Minute)) {
+		return ports.TransferDTO{}, invalidTransfer("transferred_at must not be in the future")
+	}
+
+	if _, err := s.animals.FindByID(ctx, actor, input.AnimalPublicID); err != nil {
+		return ports.TransferDTO{}, err
+	}
+	if err := s.zones.CheckCage(ctx, actor, input.ToCagePublicID); err != nil {
+		return ports.TransferDTO{}, err
+	}
+
+	// every animal has at least the stay it was created with
+	history, err := s.repo.ListByAnimal(ctx, input.AnimalPublicID)
+	if err != nil {
+		return ports.TransferDTO{}, err
+	}
+	if len(history) == 0 {
+		return ports.TransferDTO{}, ports.ErrNotFound
+	}
+
+	// an animal away from the park has no open stay
+	current := history[0]
+	if current.EndedAt != nil {
+		return ports.TransferDTO{}, invalidTransfer("animal is not in the park")
+	}
+	if current.CagePublicID == input.ToCagePublicID {
+		return ports.TransferDTO{}, invalidTransfer("animal already lives in cage " + current.CageCode)
+	}
+	if input.TransferredAt != nil && input.TransferredAt.Before(current.StartedAt) {
+		return ports.TransferDTO{}, invalidTransfer("transferred_at is before the animal moved into cage " + current.CageCode)
+	}
+
+	input.ActorPublicID = actor.PublicID
+
+	return s.repo.Transfer(ctx, input, s.compat.Guard(actor, overrideReason))
+}
+
+func (s *TransferService) History(
+	ctx context.Context,
+	actor Actor,
+	animalPublicID string,
+) ([]ports.CageAssignmentDTO, error) {
+
+	if _, err := s.animals.FindByID(ctx, actor, animalPublicID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListByAnimal(ctx, animalPublicID)
+}
+
+// Occupants returns everyone who lived in the cage between the from and to
+// days, both inclusive, including the current occupants.
+func (s *TransferService) Occupants(
+	ctx context.Context,
+	actor Actor,
+	cagePublicID string,
+	from, to *time.Time,
+) ([]ports.CageAssignmentDTO, error) {
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, invalidTransfer("to must not be before from")
+	}
+	if err := s.zones.CheckCageVisible(ctx, actor, cagePublicID); err != nil {
+		return nil, err
+	}
+	if to != nil {
+		end := to.AddDate(0, 0, 1)
+		to = &end
+	}
+	return s.repo.ListByCage(ctx, cagePublicID, from, to)
+}
+
+func invalidTransfer(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidTransfer, reason)
+}
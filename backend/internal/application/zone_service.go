@@ -0,0 +1,357 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"wit-leisure-park/backend/internal/infrastructure/id"
+	"wit-leisure-park/backend/internal/ports"
+)
+
+type ZoneRequest struct {
+	Code            string
+	Name            string
+	Description     *string
+	ManagerPublicID *string
+}
+
+type AreaRequest struct {
+	Name        string
+	Description *string
+}
+
+// ZoneService manages the park's zones and areas. When scoped access is
+// enabled, a manager responsible for zones only sees and edits the data of
+// those zones; managers without a zone keep park-wide access.
+type ZoneService struct {
+	repo   ports.ZoneRepository
+	idGen  *id.UUIDGenerator
+	scoped bool
+}
+
+func NewZoneService(
+	repo ports.ZoneRepository,
+	idGen *id.UUIDGenerator,
+	scoped bool,
+) *ZoneService {
+	return &ZoneService{repo: repo, idGen: idGen, scoped: scoped}
+}
+
+func (s *ZoneService) Create(
+	ctx context.Context,
+	actor Actor,
+	req ZoneRequest,
+) (ports.ZoneDTO, error) {
+
+	if err := s.checkParkWide(ctx, actor); err != nil {
+		return ports.ZoneDTO{}, err
+	}
+
+	input, err := zoneInput(req)
+	if err != nil {
+		return ports.ZoneDTO{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.ZoneDTO{}, err
+	}
+	input.PublicID = publicID
+
+	if err := s.repo.Create(ctx, input); err != nil {
+		return ports.ZoneDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, publicID)
+}
+
+func (s *ZoneService) List(
+	ctx context.Context,
+	actor Actor,
+) ([]ports.ZoneDTO, error) {
+
+	scope, err := s.Scope(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := s.repo.List(ctx)
+	if err != nil || scope == nil {
+		return zones, err
+	}
+
+	return slices.DeleteFunc(zones, func(z ports.ZoneDTO) bool {
+		return !slices.Contains(scope, z.PublicID)
+	}), nil
+}
+
+func (s *ZoneService) FindByID(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) (ports.ZoneDTO, error) {
+
+	if err := s.checkZone(ctx, actor, publicID, ports.ErrNotFound); err != nil {
+		return ports.ZoneDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, publicID)
+}
+
+func (s *ZoneService) Update(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+	req ZoneRequest,
+) (ports.ZoneDTO, error) {
+
+	// moving a zone to another manager is a park-wide decision
+	if err := s.checkParkWide(ctx, actor); err != nil {
+		return ports.ZoneDTO{}, err
+	}
+
+	input, err := zoneInput(req)
+	if err != nil {
+		return ports.ZoneDTO{}, err
+	}
+	input.PublicID = publicID
+
+	if err := s.repo.Update(ctx, input); err != nil {
+		return ports.ZoneDTO{}, err
+	}
+
+	return s.repo.FindByID(ctx, publicID)
+}
+
+func (s *ZoneService) Delete(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) error {
+
+	if err := s.checkParkWide(ctx, actor); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, publicID)
+}
+
+func (s *ZoneService) CreateArea(
+	ctx context.Context,
+	actor Actor,
+	zonePublicID string,
+	req AreaRequest,
+) (ports.AreaDTO, error) {
+
+	if err := s.checkZone(ctx, actor, zonePublicID, ErrForbidden); err != nil {
+		return ports.AreaDTO{}, err
+	}
+
+	input, err := areaInput(zonePublicID, req)
+	if err != nil {
+		return ports.AreaDTO{}, err
+	}
+
+	publicID, err := s.idGen.NewID()
+	if err != nil {
+		return ports.AreaDTO{}, err
+	}
+	input.PublicID = publicID
+
+	if err := s.repo.CreateArea(ctx, input); err != nil {
+		return ports.AreaDTO{}, err
+	}
+
+	return ports.AreaDTO{
+		PublicID:     publicID,
+		ZonePublicID: zonePublicID,
+		Name:         input.Name,
+		Description:  input.Description,
+	}, nil
+}
+
+func (s *ZoneService) ListAreas(
+	ctx context.Context,
+	actor Actor,
+	zonePublicID string,
+) ([]ports.AreaDTO, error) {
+
+	zone, err := s.FindByID(ctx, actor, zonePublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	return zone.Areas, nil
+}
+
+func (s *ZoneService) UpdateArea(
+	ctx context.Context,
+	actor Actor,
+	zonePublicID, areaPublicID string,
+	req AreaRequest,
+) error {
+
+	if err := s.checkZone(ctx, actor, zonePublicID, ErrForbidden); err != nil {
+		return err
+	}
+
+	input, err := areaInput(zonePublicID, req)
+	if err != nil {
+		return err
+	}
+	input.PublicID = areaPublicID
+
+	return s.repo.UpdateArea(ctx, input)
+}
+
+func (s *ZoneService) DeleteArea(
+	ctx context.Context,
+	actor Actor,
+	zonePublicID, areaPublicID string,
+) error {
+
+	if err := s.checkZone(ctx, actor, zonePublicID, ErrForbidden); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteArea(ctx, zonePublicID, areaPublicID)
+}
+
+// Scope returns the zones the actor is limited to, or nil when the actor
+// may see the whole park: scoping is off, the actor is not a manager, or
+// the manager is not responsible for any zone.
+func (s *ZoneService) Scope(ctx context.Context, actor Actor) ([]string, error) {
+	if !s.scoped || !actor.IsManager() {
+		return nil, nil
+	}
+
+	zones, err := s.repo.ManagedBy(ctx, actor.PublicID)
+	if err != nil || len(zones) == 0 {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// CheckCage returns ErrForbidden when the cage is outside the actor's
+// zones. Cages that are not placed in a zone are outside every scope.
+func (s *ZoneService) CheckCage(ctx context.Context, actor Actor, cagePublicID string) error {
+	return s.checkCage(ctx, actor, cagePublicID,
+		fmt.Errorf("%w: cage %s is outside your zones", ErrForbidden, cagePublicID))
+}
+
+// CheckCageVisible hides a cage outside the actor's zones as not found, for
+// reads.
+func (s *ZoneService) CheckCageVisible(ctx context.Context, actor Actor, cagePublicID string) error {
+	return s.checkCage(ctx, actor, cagePublicID, ports.ErrNotFound)
+}
+
+func (s *ZoneService) checkCage(ctx context.Context, actor Actor, cagePublicID string, denied error) error {
+	scope, err := s.Scope(ctx, actor)
+	if err != nil || scope == nil {
+		return err
+	}
+
+	zone, err := s.repo.CageZone(ctx, cagePublicID)
+	if err != nil {
+		return err
+	}
+	if !inScope(scope, zone) {
+		return denied
+	}
+
+	return nil
+}
+
+// CheckArea returns ErrForbidden when the area is outside the actor's
+// zones. A nil area is only allowed for park-wide actors.
+func (s *ZoneService) CheckArea(ctx context.Context, actor Actor, areaPublicID *string) error {
+	scope, err := s.Scope(ctx, actor)
+	if err != nil || scope == nil {
+		return err
+	}
+
+	if areaPublicID == nil {
+		return fmt.Errorf("%w: cages must be placed in one of your zones", ErrForbidden)
+	}
+
+	zone, err := s.repo.AreaZone(ctx, *areaPublicID)
+	if err != nil {
+		return err
+	}
+	if !inScope(scope, &zone) {
+		return fmt.Errorf("%w: area %s is outside your zones", ErrForbidden, *areaPublicID)
+	}
+
+	return nil
+}
+
+// checkZone rejects zones outside the actor's scope with denied, so reads
+// can hide them as not found while writes are refused.
+func (s *ZoneService) checkZone(ctx context.Context, actor Actor, zonePublicID string, denied error) error {
+	scope, err := s.Scope(ctx, actor)
+	if err != nil {
+		return err
+	}
+	if scope != nil && !slices.Contains(scope, zonePublicID) {
+		return denied
+	}
+	return nil
+}
+
+func (s *ZoneService) checkParkWide(ctx context.Context, actor Actor) error {
+	scope, err := s.Scope(ctx, actor)
+	if err != nil {
+		return err
+	}
+	if scope != nil {
+		return fmt.Errorf("%w: only park-wide managers can change zones", ErrForbidden)
+	}
+	return nil
+}
+
+// inScope reports whether a zone is visible within scope; a nil scope
+// allows everything.
+func inScope(scope []string, zone *string) bool {
+	if scope == nil {
+		return true
+	}
+	return zone != nil && slices.Contains(scope, *zone)
+}
+
+func zoneInput(req ZoneRequest) (ports.ZoneInput, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if code == "" {
+		return ports.ZoneInput{}, invalidZone("code is required")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return ports.ZoneInput{}, invalidZone("name is required")
+	}
+
+	return ports.ZoneInput{
+		Code:            code,
+		Name:            name,
+		Description:     trimmedOrNil(req.Description),
+		ManagerPublicID: trimmedOrNil(req.ManagerPublicID),
+	}, nil
+}
+
+func areaInput(zonePublicID string, req AreaRequest) (ports.AreaInput, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return ports.AreaInput{}, invalidZone("area name is required")
+	}
+
+	return ports.AreaInput{
+		ZonePublicID: zonePublicID,
+		Name:         name,
+		Description:  trimmedOrNil(req.Description),
+	}, nil
+}
+
+func invalidZone(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidZone, reason)
+}
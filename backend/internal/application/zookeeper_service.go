@@ -70,8 +70,11 @@ func (s *ZookeeperService) Create(
 	}, nil
 }
 
-func (s *ZookeeperService) List(ctx context.Context) ([]ports.ZookeeperDTO, error) {
-	return s.repo.List(ctx)
+func (s *ZookeeperService) List(
+	ctx context.Context,
+	query ports.ZookeeperListQuery,
+) (ports.Page[ports.ZookeeperDTO], error) {
+	return s.repo.List(ctx, query)
 }
 
 func (s *ZookeeperService) FindByID(
@@ -81,6 +84,25 @@ func (s *ZookeeperService) FindByID(
 	return s.repo.FindByID(ctx, publicID)
 }
 
+// CheckTeam returns ErrForbidden unless the zookeeper reports to the
+// acting manager.
+func (s *ZookeeperService) CheckTeam(
+	ctx context.Context,
+	actor Actor,
+	publicID string,
+) error {
+
+	ok, err := s.repo.InTeam(ctx, publicID, actor.PublicID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
 func (s *ZookeeperService) Update(
 	ctx context.Context,
 	publicID string,
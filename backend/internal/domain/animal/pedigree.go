@@ -0,0 +1,140 @@
+package animal
+
+import "sort"
+
+// Parents are the known dam and sire of an animal; an empty id is unknown.
+type Parents struct {
+	Dam  string
+	Sire string
+}
+
+// Pedigree maps animal ids to their parents. Animals missing from the map,
+// or whose parents are unknown, are treated as unrelated founders, so a
+// pedigree cut off after a few generations gives a lower bound.
+type Pedigree map[string]Parents
+
+// Inbreeding returns the inbreeding coefficient of an animal, the
+// probability that both copies of a gene are identical by descent. It
+// equals the kinship of its parents.
+func (p Pedigree) Inbreeding(id string) float64 {
+	k := p.newKinship()
+	return k.inbreeding(id)
+}
+
+// PairingInbreeding returns the inbreeding coefficient the offspring of
+// the dam and sire would have.
+func (p Pedigree) PairingInbreeding(dam, sire string) float64 {
+	k := p.newKinship()
+	return k.coefficient(dam, sire)
+}
+
+// CommonAncestors returns the ancestors shared by a and b, including a or
+// b themselves when one descends from the other, sorted by id.
+func (p Pedigree) CommonAncestors(a, b string) []string {
+	left := p.ancestors(a)
+	right := p.ancestors(b)
+
+	var common []string
+	for id := range left {
+		if right[id] {
+			common = append(common, id)
+		}
+	}
+	sort.Strings(common)
+
+	return common
+}
+
+// ancestors returns id and all of its known ancestors.
+func (p Pedigree) ancestors(id string) map[string]bool {
+	seen := make(map[string]bool)
+	stack := []string{id}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if current == "" || seen[current] {
+			continue
+		}
+		seen[current] = true
+		parents := p[current]
+		stack = append(stack, parents.Dam, parents.Sire)
+	}
+
+	return seen
+}
+
+// kinship computes coefficients of kinship with the recursive tabular
+// method, memoising every pair it visits.
+type kinship struct {
+	pedigree    Pedigree
+	generations map[string]int
+	memo        map[[2]string]float64
+}
+
+func (p Pedigree) newKinship() *kinship {
+	return &kinship{
+		pedigree:    p,
+		generations: make(map[string]int),
+		memo:        make(map[[2]string]float64),
+	}
+}
+
+// generation is 0 for founders and one more than the latest parent
+// otherwise, so a descendant always has a higher generation than any of
+// its ancestors.
+func (k *kinship) generation(id string) int {
+	if id == "" {
+		return -1
+	}
+	if g, ok := k.generations[id]; ok {
+		return g
+	}
+
+	// guards against cycles in bad data
+	k.generations[id] = 0
+
+	parents := k.pedigree[id]
+	g := max(k.generation(parents.Dam), k.generation(parents.Sire)) + 1
+	k.generations[id] = g
+
+	return g
+}
+
+func (k *kinship) inbreeding(id string) float64 {
+	parents := k.pedigree[id]
+	return k.coefficient(parents.Dam, parents.Sire)
+}
+
+// coefficient is the probability that a gene drawn at random from a and
+// one drawn from b are identical by descent.
+func (k *kinship) coefficient(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a > b {
+		a, b = b, a
+	}
+
+	key := [2]string{a, b}
+	if v, ok := k.memo[key]; ok {
+		return v
+	}
+	k.memo[key] = 0
+
+	var v float64
+	if a == b {
+		v = (1 + k.inbreeding(a)) / 2
+	} else {
+		// expand the younger animal, which cannot be an ancestor of the other
+		if k.generation(a) < k.generation(b) {
+			a, b = b, a
+		}
+		parents := k.pedigree[a]
+		v = (k.coefficient(parents.Dam, b) + k.coefficient(parents.Sire, b)) / 2
+	}
+
+	k.memo[key] = v
+
+	return v
+}
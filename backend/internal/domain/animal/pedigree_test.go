@@ -0,0 +1,138 @@
+package animal
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// family is a small studbook: dam1 and sire are founders, full1 and full2
+// are full siblings, half is their half sibling by the sire, cousin1 and
+// cousin2 are first cousins through full1 and full2, and pup is the
+// offspring of a full sibling mating.
+var family = Pedigree{
+	"full1":   {Dam: "dam1", Sire: "sire"},
+	"full2":   {Dam: "dam1", Sire: "sire"},
+	"half":    {Dam: "dam2", Sire: "sire"},
+	"cousin1": {Dam: "full1", Sire: "mate1"},
+	"cousin2": {Dam: "mate2", Sire: "full2"},
+	"pup":     {Dam: "full1", Sire: "full2"},
+}
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestPairingInbreeding(t *testing.T) {
+	tests := []struct {
+		name      string
+		dam, sire string
+		want      float64
+	}{
+		{"full siblings", "full1", "full2", 0.25},
+		{"half siblings", "full1", "half", 0.125},
+		{"parent and offspring", "full1", "sire", 0.25},
+		{"first cousins", "cousin1", "cousin2", 0.0625},
+		{"an animal with itself", "sire", "sire", 0.5},
+		{"unrelated founders", "dam1", "dam2", 0},
+		{"animals not in the pedigree", "stray1", "stray2", 0},
+		{"an unknown parent", "full1", "", 0},
+	}
+
+	for _, tt := range tests {
+		if got := family.PairingInbreeding(tt.dam, tt.sire); !approx(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		if got := family.PairingInbreeding(tt.sire, tt.dam); !approx(got, tt.want) {
+			t.Errorf("%s, reversed: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestInbreeding(t *testing.T) {
+	tests := []struct {
+		id   string
+		want float64
+	}{
+		{"pup", 0.25},
+		{"full1", 0},
+		{"sire", 0},
+		{"stray", 0},
+	}
+
+	for _, tt := range tests {
+		if got := family.Inbreeding(tt.id); !approx(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestInbreedingMissingParent(t *testing.T) {
+	// only the dams are recorded, so the two are half siblings at most
+	p := Pedigree{
+		"a":   {Dam: "dam"},
+		"b":   {Dam: "dam"},
+		"pup": {Dam: "a", Sire: "b"},
+	}
+
+	if got := p.Inbreeding("pup"); !approx(got, 0.125) {
+		t.Errorf("got %v, want 0.125", got)
+	}
+}
+
+func TestInbreedingInbredParents(t *testing.T) {
+	// dam and sire are themselves full siblings, so their offspring share
+	// more than ordinary full siblings do
+	full := Pedigree{
+		"dam":  {Dam: "gdam", Sire: "gsire"},
+		"sire": {Dam: "gdam", Sire: "gsire"},
+		"a":    {Dam: "dam", Sire: "sire"},
+		"b":    {Dam: "dam", Sire: "sire"},
+	}
+	if got := full.PairingInbreeding("a", "b"); !approx(got, 0.375) {
+		t.Errorf("full pedigree: got %v, want 0.375", got)
+	}
+
+	// cut off after one generation, dam and sire become founders and the
+	// result is a lower bound
+	capped := Pedigree{
+		"a": {Dam: "dam", Sire: "sire"},
+		"b": {Dam: "dam", Sire: "sire"},
+	}
+	if got := capped.PairingInbreeding("a", "b"); !approx(got, 0.25) {
+		t.Errorf("capped pedigree: got %v, want 0.25", got)
+	}
+}
+
+func TestInbreedingCycle(t *testing.T) {
+	// bad data must not recurse forever
+	p := Pedigree{
+		"a": {Dam: "b", Sire: "x"},
+		"b": {Dam: "a", Sire: "y"},
+	}
+
+	got := p.PairingInbreeding("a", "b")
+	if math.IsNaN(got) || got < 0 || got > 1 {
+		t.Errorf("got %v, want a coefficient between 0 and 1", got)
+	}
+}
+
+func TestCommonAncestors(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []string
+	}{
+		{"full siblings", "full1", "full2", []string{"dam1", "sire"}},
+		{"half siblings", "full1", "half", []string{"sire"}},
+		{"parent and offspring", "full1", "sire", []string{"sire"}},
+		{"first cousins", "cousin1", "cousin2", []string{"dam1", "sire"}},
+		{"unrelated", "dam1", "dam2", nil},
+	}
+
+	for _, tt := range tests {
+		if got := family.CommonAncestors(tt.a, tt.b); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
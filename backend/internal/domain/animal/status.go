@@ -0,0 +1,82 @@
+package animal
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Status string
+
+const (
+	StatusActive         Status = "ACTIVE"
+	StatusQuarantined    Status = "QUARANTINED"
+	StatusOnLoan         Status = "ON_LOAN"
+	StatusDeceased       Status = "DECEASED"
+	StatusTransferredOut Status = "TRANSFERRED_OUT"
+)
+
+var (
+	ErrInvalidStatus     = errors.New("invalid animal status")
+	ErrInvalidTransition = errors.New("invalid animal status transition")
+)
+
+// transitions lists the statuses an animal may move to from each status.
+// DECEASED and TRANSFERRED_OUT are terminal.
+var transitions = map[Status][]Status{
+	StatusActive:         {StatusQuarantined, StatusOnLoan, StatusDeceased, StatusTransferredOut},
+	StatusQuarantined:    {StatusActive, StatusDeceased},
+	StatusOnLoan:         {StatusActive, StatusQuarantined, StatusDeceased, StatusTransferredOut},
+	StatusDeceased:       {},
+	StatusTransferredOut: {},
+}
+
+func (s Status) Valid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// Active reports whether the animal still belongs to the collection.
+// Inactive animals are kept for records but hidden from default lists.
+func (s Status) Active() bool {
+	return s != StatusDeceased && s != StatusTransferredOut
+}
+
+// InPark reports whether the animal lives in its cage and takes up a slot.
+func (s Status) InPark() bool {
+	return s == StatusActive || s == StatusQuarantined
+}
+
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllStatuses lists every status, in lifecycle order.
+func AllStatuses() []Status {
+	return []Status{StatusActive, StatusQuarantined, StatusOnLoan, StatusDeceased, StatusTransferredOut}
+}
+
+// ActiveStatuses are the statuses listed when no status filter is given.
+func ActiveStatuses() []Status {
+	return []Status{StatusActive, StatusQuarantined, StatusOnLoan}
+}
+
+// ValidateTransition returns an error wrapping ErrInvalidStatus or
+// ErrInvalidTransition when an animal may not move from one status to
+// another.
+func ValidateTransition(from, to Status) error {
+	if !to.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidStatus, to)
+	}
+
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+	}
+
+	return nil
+}
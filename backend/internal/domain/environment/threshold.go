@@ -0,0 +1,69 @@
+package environment
+
+type Metric string
+
+const (
+	MetricTemperature Metric = "TEMPERATURE"
+	MetricHumidity    Metric = "HUMIDITY"
+)
+
+// plausible bounds the values a working sensor can report, in degrees
+// Celsius and percent relative humidity.
+var plausible = map[Metric][2]float64{
+	MetricTemperature: {-50, 70},
+	MetricHumidity:    {0, 100},
+}
+
+func (m Metric) Valid() bool {
+	_, ok := plausible[m]
+	return ok
+}
+
+// Plausible reports whether a value can come from a working sensor, so
+// readings of a broken device are rejected instead of raising alerts.
+func (m Metric) Plausible(v float64) bool {
+	bounds, ok := plausible[m]
+	return ok && v >= bounds[0] && v <= bounds[1]
+}
+
+// AllMetrics lists every metric a sensor can report.
+func AllMetrics() []Metric {
+	return []Metric{MetricTemperature, MetricHumidity}
+}
+
+// Breach tells on which side of its threshold a reading is, empty when it
+// is in range.
+type Breach string
+
+const (
+	BreachNone Breach = ""
+	BreachLow  Breach = "LOW"
+	BreachHigh Breach = "HIGH"
+)
+
+// Threshold is the range a cage's readings of one metric should stay in.
+// Either bound may be open.
+type Threshold struct {
+	Min *float64
+	Max *float64
+}
+
+func (t Threshold) Check(v float64) Breach {
+	switch {
+	case t.Min != nil && v < *t.Min:
+		return BreachLow
+	case t.Max != nil && v > *t.Max:
+		return BreachHigh
+	default:
+		return BreachNone
+	}
+}
+
+// Worse reports whether v is further out of range than peak for a breach
+// of the given kind.
+func (b Breach) Worse(v, peak float64) bool {
+	if b == BreachLow {
+		return v < peak
+	}
+	return v > peak
+}
@@ -0,0 +1,74 @@
+package leave
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrInvalidType       = errors.New("invalid leave type")
+	ErrInvalidStatus     = errors.New("invalid leave status")
+	ErrInvalidTransition = errors.New("invalid leave status transition")
+)
+
+type Type string
+
+const (
+	TypeAnnual   Type = "ANNUAL"
+	TypeSick     Type = "SICK"
+	TypeTraining Type = "TRAINING"
+	TypePersonal Type = "PERSONAL"
+	TypeOther    Type = "OTHER"
+)
+
+func (t Type) Valid() bool {
+	switch t {
+	case TypeAnnual, TypeSick, TypeTraining, TypePersonal, TypeOther:
+		return true
+	}
+	return false
+}
+
+type Status string
+
+const (
+	StatusPending   Status = "PENDING"
+	StatusApproved  Status = "APPROVED"
+	StatusRejected  Status = "REJECTED"
+	StatusCancelled Status = "CANCELLED"
+)
+
+// transitions lists the statuses a leave request may move to. Rejected and
+// cancelled requests are final; approved leave can still be cancelled.
+var transitions = map[Status][]Status{
+	StatusPending:   {StatusApproved, StatusRejected, StatusCancelled},
+	StatusApproved:  {StatusCancelled},
+	StatusRejected:  {},
+	StatusCancelled: {},
+}
+
+func (s Status) Valid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// Blocking reports whether leave in this status keeps the zookeeper from
+// taking another leave request for the same days.
+func (s Status) Blocking() bool {
+	return s == StatusPending || s == StatusApproved
+}
+
+// ValidateTransition returns an error wrapping ErrInvalidTransition when a
+// leave request may not move from one status to another.
+func ValidateTransition(from, to Status) error {
+	if !to.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidStatus, to)
+	}
+
+	if !slices.Contains(transitions[from], to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+	}
+
+	return nil
+}
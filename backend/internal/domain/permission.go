@@ -0,0 +1,61 @@
+package domain
+
+type Permission string
+
+const (
+	PermManagersRead  Permission = "managers:read"
+	PermManagersWrite Permission = "managers:write"
+
+	PermZookeepersRead  Permission = "zookeepers:read"
+	PermZookeepersWrite Permission = "zookeepers:write"
+
+	PermShiftsRead  Permission = "shifts:read"
+	PermShiftsWrite Permission = "shifts:write"
+
+	PermLeaveRead    Permission = "leave:read"
+	PermLeaveRequest Permission = "leave:request"
+	PermLeaveApprove Permission = "leave:approve"
+
+	PermSkillsRead  Permission = "skills:read"
+	PermSkillsWrite Permission = "skills:write"
+
+	PermCagesRead  Permission = "cages:read"
+	PermCagesWrite Permission = "cages:write"
+
+	PermZonesRead  Permission = "zones:read"
+	PermZonesWrite Permission = "zones:write"
+
+	PermAnimalsRead  Permission = "animals:read"
+	PermAnimalsWrite Permission = "animals:write"
+
+	PermSpeciesRead  Permission = "species:read"
+	PermSpeciesWrite Permission = "species:write"
+
+	PermMedicalRead  Permission = "medical:read"
+	PermMedicalWrite Permission = "medical:write"
+
+	PermQuarantineRead  Permission = "quarantine:read"
+	PermQuarantineWrite Permission = "quarantine:write"
+
+	PermBreedingRead  Permission = "breeding:read"
+	PermBreedingWrite Permission = "breeding:write"
+
+	PermFeedingRead  Permission = "feeding:read"
+	PermFeedingWrite Permission = "feeding:write"
+	PermFeedingLog   Permission = "feeding:log"
+
+	PermMeasurementsRead      Permission = "measurements:read"
+	PermMeasurementsWrite     Permission = "measurements:write"
+	PermMeasurementsConfigure Permission = "measurements:configure"
+
+	PermSensorsRead  Permission = "sensors:read"
+	PermSensorsWrite Permission = "sensors:write"
+
+	PermTasksRead         Permission = "tasks:read"
+	PermTasksAssign       Permission = "tasks:assign"
+	PermTasksDelete       Permission = "tasks:delete"
+	PermTasksUpdateStatus Permission = "tasks:update_status"
+
+	PermUsersManage Permission = "users:manage"
+	PermRolesManage Permission = "roles:manage"
+)
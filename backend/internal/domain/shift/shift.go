@@ -0,0 +1,116 @@
+package shift
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxDuration caps a single shift; longer spans are almost certainly a
+// typo in the end time.
+const MaxDuration = 16 * time.Hour
+
+var (
+	ErrInvalidPeriod = errors.New("shift must end after it starts")
+	ErrTooLong       = errors.New("shift must not be longer than 16 hours")
+)
+
+// Shift is the time a zookeeper is on duty, from Start up to but not
+// including End.
+type Shift struct {
+	PublicID string
+	Start    time.Time
+	End      time.Time
+}
+
+func (s Shift) Validate() error {
+	if !s.End.After(s.Start) {
+		return ErrInvalidPeriod
+	}
+	if s.End.Sub(s.Start) > MaxDuration {
+		return ErrTooLong
+	}
+	return nil
+}
+
+// Overlaps reports whether the two shifts share any time. Back-to-back
+// shifts do not overlap.
+func (s Shift) Overlaps(other Shift) bool {
+	return s.Start.Before(other.End) && other.Start.Before(s.End)
+}
+
+// Covers reports whether the shift shares any time with [from, to).
+func (s Shift) Covers(from, to time.Time) bool {
+	return s.Start.Before(to) && from.Before(s.End)
+}
+
+// restBetween is the gap between two shifts that do not overlap.
+func restBetween(a, b Shift) time.Duration {
+	if a.End.After(b.Start) {
+		return a.Start.Sub(b.End)
+	}
+	return b.Start.Sub(a.End)
+}
+
+type ConflictKind string
+
+const (
+	ConflictOverlap          ConflictKind = "OVERLAP"
+	ConflictInsufficientRest ConflictKind = "INSUFFICIENT_REST"
+)
+
+// Conflict names an existing shift the candidate clashes with. RestHours
+// is the gap between the two for rest conflicts.
+type Conflict struct {
+	Kind             ConflictKind `json:"kind"`
+	ShiftPublicID    string       `json:"shift_public_id"`
+	StartsAt         time.Time    `json:"starts_at"`
+	EndsAt           time.Time    `json:"ends_at"`
+	RestHours        *float64     `json:"rest_hours,omitempty"`
+	MinimumRestHours float64      `json:"minimum_rest_hours,omitempty"`
+}
+
+// Check compares a candidate shift with the zookeeper's other shifts. A
+// shift that overlaps is a conflict, and so is one that leaves less than
+// minRest between the two. Shifts with the candidate's own id are skipped
+// so a shift can be moved in place.
+func Check(candidate Shift, others []Shift, minRest time.Duration) []Conflict {
+	var conflicts []Conflict
+
+	for _, o := range others {
+		if o.PublicID != "" && o.PublicID == candidate.PublicID {
+			continue
+		}
+
+		if candidate.Overlaps(o) {
+			conflicts = append(conflicts, Conflict{
+				Kind:          ConflictOverlap,
+				ShiftPublicID: o.PublicID,
+				StartsAt:      o.Start,
+				EndsAt:        o.End,
+			})
+			continue
+		}
+
+		if rest := restBetween(candidate, o); rest < minRest {
+			hours := rest.Hours()
+			conflicts = append(conflicts, Conflict{
+				Kind:             ConflictInsufficientRest,
+				ShiftPublicID:    o.PublicID,
+				StartsAt:         o.Start,
+				EndsAt:           o.End,
+				RestHours:        &hours,
+				MinimumRestHours: minRest.Hours(),
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// WeekStart returns midnight of the Monday of the week containing t, in
+// t's location.
+func WeekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
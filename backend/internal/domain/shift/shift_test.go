@@ -0,0 +1,133 @@
+package shift
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// at returns the time on 2 March 2026, a Monday, at the given hour.
+func at(hour int) time.Time {
+	return time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC).Add(time.Duration(hour) * time.Hour)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end int
+		want       error
+	}{
+		{"an ordinary shift", 8, 16, nil},
+		{"exactly the maximum", 6, 22, nil},
+		{"longer than the maximum", 6, 23, ErrTooLong},
+		{"ends when it starts", 8, 8, ErrInvalidPeriod},
+		{"ends before it starts", 16, 8, ErrInvalidPeriod},
+	}
+
+	for _, tt := range tests {
+		s := Shift{Start: at(tt.start), End: at(tt.end)}
+		if err := s.Validate(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestRestBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Shift
+		want time.Duration
+	}{
+		{"b after a", Shift{Start: at(6), End: at(14)}, Shift{Start: at(22), End: at(30)}, 8 * time.Hour},
+		{"b before a", Shift{Start: at(22), End: at(30)}, Shift{Start: at(6), End: at(14)}, 8 * time.Hour},
+		{"back to back", Shift{Start: at(6), End: at(14)}, Shift{Start: at(14), End: at(22)}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := restBetween(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	candidate := Shift{PublicID: "new", Start: at(14), End: at(22)}
+
+	tests := []struct {
+		name    string
+		other   Shift
+		minRest time.Duration
+		want    ConflictKind
+		rest    float64
+	}{
+		{"overlapping", Shift{PublicID: "o", Start: at(10), End: at(15)}, 0, ConflictOverlap, 0},
+		{"contained", Shift{PublicID: "o", Start: at(16), End: at(18)}, 0, ConflictOverlap, 0},
+		{"back to back before without a rest rule", Shift{PublicID: "o", Start: at(6), End: at(14)}, 0, "", 0},
+		{"back to back after without a rest rule", Shift{PublicID: "o", Start: at(22), End: at(30)}, 0, "", 0},
+		{"back to back with a rest rule", Shift{PublicID: "o", Start: at(6), End: at(14)}, 11 * time.Hour, ConflictInsufficientRest, 0},
+		{"too little rest after", Shift{PublicID: "o", Start: at(30), End: at(38)}, 11 * time.Hour, ConflictInsufficientRest, 8},
+		{"exactly the minimum rest", Shift{PublicID: "o", Start: at(33), End: at(41)}, 11 * time.Hour, "", 0},
+		{"its own id", Shift{PublicID: "new", Start: at(10), End: at(15)}, 11 * time.Hour, "", 0},
+	}
+
+	for _, tt := range tests {
+		conflicts := Check(candidate, []Shift{tt.other}, tt.minRest)
+
+		if tt.want == "" {
+			if len(conflicts) != 0 {
+				t.Errorf("%s: got %+v, want no conflict", tt.name, conflicts)
+			}
+			continue
+		}
+
+		if len(conflicts) != 1 {
+			t.Errorf("%s: got %d conflicts, want 1", tt.name, len(conflicts))
+			continue
+		}
+		c := conflicts[0]
+		if c.Kind != tt.want || c.ShiftPublicID != tt.other.PublicID {
+			t.Errorf("%s: got %s with %s, want %s with %s", tt.name, c.Kind, c.ShiftPublicID, tt.want, tt.other.PublicID)
+		}
+		if tt.want == ConflictInsufficientRest {
+			if c.RestHours == nil || *c.RestHours != tt.rest {
+				t.Errorf("%s: got rest %v, want %v", tt.name, c.RestHours, tt.rest)
+			}
+			if c.MinimumRestHours != tt.minRest.Hours() {
+				t.Errorf("%s: got minimum %v, want %v", tt.name, c.MinimumRestHours, tt.minRest.Hours())
+			}
+		}
+	}
+}
+
+func TestCheckSkipsOnlyTheSameID(t *testing.T) {
+	// a new shift has no id yet and must still be checked against others
+	// that have none
+	candidate := Shift{Start: at(8), End: at(16)}
+	others := []Shift{{Start: at(10), End: at(12)}}
+
+	if conflicts := Check(candidate, others, 0); len(conflicts) != 1 {
+		t.Errorf("got %d conflicts, want 1", len(conflicts))
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	tests := []struct {
+		name string
+		in   time.Time
+		want time.Time
+	}{
+		{"a Monday", time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC), time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"a Wednesday", time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"a Sunday", time.Date(2026, 3, 8, 23, 59, 0, 0, time.UTC), time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"across a month", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC)},
+		{"another location", time.Date(2026, 3, 8, 1, 0, 0, 0, jakarta), time.Date(2026, 3, 2, 0, 0, 0, 0, jakarta)},
+	}
+
+	for _, tt := range tests {
+		if got := WeekStart(tt.in); !got.Equal(tt.want) {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
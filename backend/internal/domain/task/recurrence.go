@@ -0,0 +1,172 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Recurrence is the subset of an RFC 5545 RRULE the park needs:
+// FREQ=DAILY|WEEKLY|MONTHLY with optional INTERVAL, BYDAY (weekly) and
+// BYMONTHDAY (monthly, -1 meaning the last day of the month).
+type Recurrence struct {
+	Frequency  Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+}
+
+// ParseRecurrence parses rules such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH".
+func ParseRecurrence(rule string) (Recurrence, error) {
+	r := Recurrence{Interval: 1}
+
+	rule = strings.TrimPrefix(strings.TrimSpace(strings.ToUpper(rule)), "RRULE:")
+	if rule == "" {
+		return Recurrence{}, fmt.Errorf("%w: empty rule", ErrInvalidRecurrence)
+	}
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return Recurrence{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRecurrence, part)
+		}
+
+		switch key {
+		case "FREQ":
+			r.Frequency = Frequency(value)
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Recurrence{}, fmt.Errorf("%w: INTERVAL must be a positive number", ErrInvalidRecurrence)
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, d := range strings.Split(value, ",") {
+				wd, ok := weekdays[d]
+				if !ok {
+					return Recurrence{}, fmt.Errorf("%w: unknown day %q", ErrInvalidRecurrence, d)
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(d)
+				if err != nil || n == 0 || n < -1 || n > 31 {
+					return Recurrence{}, fmt.Errorf("%w: BYMONTHDAY must be 1-31 or -1", ErrInvalidRecurrence)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, n)
+			}
+		default:
+			return Recurrence{}, fmt.Errorf("%w: unsupported part %q", ErrInvalidRecurrence, key)
+		}
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		if len(r.ByDay) > 0 || len(r.ByMonthDay) > 0 {
+			return Recurrence{}, fmt.Errorf("%w: DAILY does not take BYDAY or BYMONTHDAY", ErrInvalidRecurrence)
+		}
+	case FrequencyWeekly:
+		if len(r.ByMonthDay) > 0 {
+			return Recurrence{}, fmt.Errorf("%w: WEEKLY does not take BYMONTHDAY", ErrInvalidRecurrence)
+		}
+	case FrequencyMonthly:
+		if len(r.ByDay) > 0 {
+			return Recurrence{}, fmt.Errorf("%w: MONTHLY does not take BYDAY", ErrInvalidRecurrence)
+		}
+	default:
+		return Recurrence{}, fmt.Errorf("%w: FREQ must be DAILY, WEEKLY or MONTHLY", ErrInvalidRecurrence)
+	}
+
+	return r, nil
+}
+
+// Occurrences returns the dates in [from, to] on which the rule fires when
+// anchored at start. All values are treated as calendar dates.
+func (r Recurrence) Occurrences(start, from, to time.Time) []time.Time {
+	start = truncateDay(start)
+	from = truncateDay(from)
+	to = truncateDay(to)
+
+	if from.Before(start) {
+		from = start
+	}
+
+	result := make([]time.Time, 0)
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if r.matches(start, d) {
+			result = append(result, d)
+		}
+	}
+
+	return result
+}
+
+func (r Recurrence) matches(start, d time.Time) bool {
+	switch r.Frequency {
+	case FrequencyDaily:
+		days := int(d.Sub(start).Hours() / 24)
+		return days%r.Interval == 0
+
+	case FrequencyWeekly:
+		weeks := int(weekStart(d).Sub(weekStart(start)).Hours() / (24 * 7))
+		if weeks%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return d.Weekday() == start.Weekday()
+		}
+		return slices.Contains(r.ByDay, d.Weekday())
+
+	case FrequencyMonthly:
+		months := (d.Year()-start.Year())*12 + int(d.Month()-start.Month())
+		if months%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByMonthDay) == 0 {
+			return d.Day() == start.Day()
+		}
+		lastDay := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		for _, md := range r.ByMonthDay {
+			if md == d.Day() || (md == -1 && d.Day() == lastDay) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return false
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// weekStart returns the Monday of the week d falls in.
+func weekStart(d time.Time) time.Time {
+	offset := (int(d.Weekday()) + 6) % 7
+	return d.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,167 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestParseRecurrence(t *testing.T) {
+	r, err := ParseRecurrence(" rrule:freq=weekly;interval=2;byday=mo,th ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Frequency != FrequencyWeekly || r.Interval != 2 {
+		t.Errorf("got %s every %d, want WEEKLY every 2", r.Frequency, r.Interval)
+	}
+	if !slices.Equal(r.ByDay, []time.Weekday{time.Monday, time.Thursday}) {
+		t.Errorf("got BYDAY %v, want [Monday Thursday]", r.ByDay)
+	}
+
+	r, err = ParseRecurrence("FREQ=DAILY")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Interval != 1 {
+		t.Errorf("got interval %d, want 1 by default", r.Interval)
+	}
+}
+
+func TestParseRecurrenceRejects(t *testing.T) {
+	tests := []string{
+		"",
+		"FREQ",
+		"FREQ=",
+		"FREQ=YEARLY",
+		"INTERVAL=2",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=x",
+		"FREQ=DAILY;COUNT=3",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=MO",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYMONTHDAY=-2",
+	}
+
+	for _, rule := range tests {
+		if _, err := ParseRecurrence(rule); !errors.Is(err, ErrInvalidRecurrence) {
+			t.Errorf("%q: got %v, want ErrInvalidRecurrence", rule, err)
+		}
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start string
+		from  string
+		to    string
+		want  []string
+	}{
+		{
+			"every day",
+			"FREQ=DAILY", "2026-03-01", "2026-03-01", "2026-03-03",
+			[]string{"2026-03-01", "2026-03-02", "2026-03-03"},
+		},
+		{
+			"every third day counted from the start",
+			"FREQ=DAILY;INTERVAL=3", "2026-03-01", "2026-03-05", "2026-03-12",
+			[]string{"2026-03-07", "2026-03-10"},
+		},
+		{
+			"nothing before the start",
+			"FREQ=DAILY", "2026-03-10", "2026-03-01", "2026-03-12",
+			[]string{"2026-03-10", "2026-03-11", "2026-03-12"},
+		},
+		{
+			"weekly on the start's weekday",
+			"FREQ=WEEKLY", "2026-03-04", "2026-03-01", "2026-03-31",
+			[]string{"2026-03-04", "2026-03-11", "2026-03-18", "2026-03-25"},
+		},
+		{
+			"every other week on Monday and Thursday",
+			"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", "2026-03-04", "2026-03-01", "2026-03-31",
+			[]string{"2026-03-05", "2026-03-16", "2026-03-19", "2026-03-30"},
+		},
+		{
+			"a Sunday start belongs to the week that began on Monday",
+			"FREQ=WEEKLY;INTERVAL=2;BYDAY=SU", "2026-03-01", "2026-03-01", "2026-03-31",
+			[]string{"2026-03-01", "2026-03-15", "2026-03-29"},
+		},
+		{
+			"monthly on the 31st skips shorter months",
+			"FREQ=MONTHLY", "2026-01-31", "2026-01-01", "2026-05-31",
+			[]string{"2026-01-31", "2026-03-31", "2026-05-31"},
+		},
+		{
+			"BYMONTHDAY=31 only fires in long months",
+			"FREQ=MONTHLY;BYMONTHDAY=31", "2026-01-01", "2026-02-01", "2026-04-30",
+			[]string{"2026-03-31"},
+		},
+		{
+			"last day of the month in a leap year",
+			"FREQ=MONTHLY;BYMONTHDAY=-1", "2028-01-15", "2028-01-01", "2028-04-30",
+			[]string{"2028-01-31", "2028-02-29", "2028-03-31", "2028-04-30"},
+		},
+		{
+			"every other month on the 1st and 15th",
+			"FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=1,15", "2026-01-10", "2026-01-01", "2026-05-31",
+			[]string{"2026-01-15", "2026-03-01", "2026-03-15", "2026-05-01", "2026-05-15"},
+		},
+		{
+			"quarterly across the new year",
+			"FREQ=MONTHLY;INTERVAL=3", "2026-11-05", "2026-11-01", "2027-06-30",
+			[]string{"2026-11-05", "2027-02-05", "2027-05-05"},
+		},
+		{
+			"an empty range",
+			"FREQ=DAILY", "2026-03-01", "2026-03-05", "2026-03-04",
+			[]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseRecurrence(tt.rule)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		got := make([]string, 0)
+		for _, d := range r.Occurrences(date(tt.start), date(tt.from), date(tt.to)) {
+			got = append(got, d.Format("2006-01-02"))
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOccurrencesIgnoreTimeOfDay(t *testing.T) {
+	r, err := ParseRecurrence("FREQ=DAILY;INTERVAL=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 3, 1, 22, 30, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 5, 6, 0, 0, 0, time.UTC)
+
+	got := r.Occurrences(start, start, to)
+	want := []time.Time{date("2026-03-01"), date("2026-03-03"), date("2026-03-05")}
+	if !slices.EqualFunc(got, want, time.Time.Equal) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidStatus     = errors.New("invalid task status")
+	ErrInvalidTransition = errors.New("invalid task status transition")
+)
+
+// transitions lists the statuses a task may move to from each status.
+// DONE is terminal.
+var transitions = map[Status][]Status{
+	StatusPending:    {StatusInProgress, StatusDone},
+	StatusInProgress: {StatusPending, StatusDone},
+	StatusDone:       {},
+}
+
+func (s Status) Valid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateTransition returns an error wrapping ErrInvalidStatus or
+// ErrInvalidTransition when a task may not move from one status to another.
+func ValidateTransition(from, to Status) error {
+	if !to.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidStatus, to)
+	}
+
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+	}
+
+	return nil
+}
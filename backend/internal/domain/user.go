@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type Role string
 
 const (
@@ -8,9 +10,16 @@ const (
 )
 
 type User struct {
-	ID           int64
-	PublicID     string
-	Username     string
-	PasswordHash string
-	Role         Role
+	ID                 int64
+	PublicID           string
+	Username           string
+	PasswordHash       string
+	Role               Role
+	MustChangePassword bool
+	LockedUntil        *time.Time
+}
+
+// IsLocked reports whether the account is locked out at the given time.
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && u.LockedUntil.After(now)
 }
@@ -2,6 +2,8 @@ package config
 
 import (
 	"log"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -9,7 +11,35 @@ import (
 type Config struct {
 	AppPort string
 
-	JWTSecret string
+	// TrustedProxies are the addresses allowed to pass the client IP in
+	// X-Forwarded-For, such as the frontend server.
+	TrustedProxies []string
+
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	PasswordResetTTL time.Duration
+
+	MFAIssuer     string
+	MFAPendingTTL time.Duration
+
+	LoginMaxFailedAttempts   int
+	LoginIPMaxFailedAttempts int
+	LoginAttemptWindow       time.Duration
+	LoginLockoutBase         time.Duration
+	LoginLockoutMax          time.Duration
+
+	SchedulerInterval time.Duration
+
+	WeightAlertMaxChangePercent float64
+	WeightAlertWindowDays       int
+
+	// ZoneScopedAccess limits managers responsible for zones to the data of
+	// those zones.
+	ZoneScopedAccess bool
+
+	ShiftMinRest time.Duration
 
 	DBHost string
 	DBPort string
@@ -23,6 +53,22 @@ func Load() *Config {
 	viper.SetConfigType("env")
 	viper.AutomaticEnv()
 
+	viper.SetDefault("ACCESS_TOKEN_TTL", "15m")
+	viper.SetDefault("REFRESH_TOKEN_TTL", "168h")
+	viper.SetDefault("PASSWORD_RESET_TTL", "24h")
+	viper.SetDefault("MFA_ISSUER", "WIT Leisure Park")
+	viper.SetDefault("MFA_PENDING_TTL", "5m")
+	viper.SetDefault("LOGIN_MAX_FAILED_ATTEMPTS", 5)
+	viper.SetDefault("LOGIN_IP_MAX_FAILED_ATTEMPTS", 20)
+	viper.SetDefault("LOGIN_ATTEMPT_WINDOW", "15m")
+	viper.SetDefault("LOGIN_LOCKOUT_BASE", "1m")
+	viper.SetDefault("LOGIN_LOCKOUT_MAX", "1h")
+	viper.SetDefault("SCHEDULER_INTERVAL", "1h")
+	viper.SetDefault("WEIGHT_ALERT_MAX_CHANGE_PERCENT", 10)
+	viper.SetDefault("WEIGHT_ALERT_WINDOW_DAYS", 30)
+	viper.SetDefault("ZONE_SCOPED_ACCESS", false)
+	viper.SetDefault("SHIFT_MIN_REST", "11h")
+
 	if err := viper.ReadInConfig(); err != nil {
 		log.Println("No .env file found, using environment variables")
 	}
@@ -30,7 +76,31 @@ func Load() *Config {
 	return &Config{
 		AppPort: viper.GetString("APP_PORT"),
 
-		JWTSecret: viper.GetString("JWT_SECRET"),
+		TrustedProxies: splitList(viper.GetString("TRUSTED_PROXIES")),
+
+		JWTSecret:       viper.GetString("JWT_SECRET"),
+		AccessTokenTTL:  viper.GetDuration("ACCESS_TOKEN_TTL"),
+		RefreshTokenTTL: viper.GetDuration("REFRESH_TOKEN_TTL"),
+
+		PasswordResetTTL: viper.GetDuration("PASSWORD_RESET_TTL"),
+
+		MFAIssuer:     viper.GetString("MFA_ISSUER"),
+		MFAPendingTTL: viper.GetDuration("MFA_PENDING_TTL"),
+
+		LoginMaxFailedAttempts:   viper.GetInt("LOGIN_MAX_FAILED_ATTEMPTS"),
+		LoginIPMaxFailedAttempts: viper.GetInt("LOGIN_IP_MAX_FAILED_ATTEMPTS"),
+		LoginAttemptWindow:       viper.GetDuration("LOGIN_ATTEMPT_WINDOW"),
+		LoginLockoutBase:         viper.GetDuration("LOGIN_LOCKOUT_BASE"),
+		LoginLockoutMax:          viper.GetDuration("LOGIN_LOCKOUT_MAX"),
+
+		SchedulerInterval: viper.GetDuration("SCHEDULER_INTERVAL"),
+
+		WeightAlertMaxChangePercent: viper.GetFloat64("WEIGHT_ALERT_MAX_CHANGE_PERCENT"),
+		WeightAlertWindowDays:       viper.GetInt("WEIGHT_ALERT_WINDOW_DAYS"),
+
+		ZoneScopedAccess: viper.GetBool("ZONE_SCOPED_ACCESS"),
+
+		ShiftMinRest: viper.GetDuration("SHIFT_MIN_REST"),
 
 		DBHost: viper.GetString("DB_HOST"),
 		DBPort: viper.GetString("DB_PORT"),
@@ -39,3 +109,14 @@ func Load() *Config {
 		DBName: viper.GetString("DB_NAME"),
 	}
 }
+
+// splitList reads a comma separated setting, ignoring blank entries.
+func splitList(value string) []string {
+	result := make([]string, 0)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"wit-leisure-park/backend/internal/adapters/http/handler"
 	"wit-leisure-park/backend/internal/adapters/http/middleware"
+	"wit-leisure-park/backend/internal/domain"
 	"wit-leisure-park/backend/internal/infrastructure/config"
 
 	"github.com/gofiber/fiber/v2"
@@ -10,34 +11,95 @@ import (
 )
 
 type HTTPServer struct {
-	log              *logrus.Logger
-	cfg              *config.Config
-	authHandler      *handler.AuthHandler
-	managerHandler   *handler.ManagerHandler
-	zookeeperHandler *handler.ZookeeperHandler
-	cageHandler      *handler.CageHandler
-	animalHandler    *handler.AnimalHandler
-	taskHandler      *handler.TaskHandler
+	log                  *logrus.Logger
+	cfg                  *config.Config
+	sessions             middleware.SessionValidator
+	permissions          middleware.PermissionChecker
+	authHandler          *handler.AuthHandler
+	managerHandler       *handler.ManagerHandler
+	zookeeperHandler     *handler.ZookeeperHandler
+	cageHandler          *handler.CageHandler
+	animalHandler        *handler.AnimalHandler
+	taskHandler          *handler.TaskHandler
+	passwordHandler      *handler.PasswordHandler
+	lockoutHandler       *handler.LockoutHandler
+	mfaHandler           *handler.MFAHandler
+	permissionHandler    *handler.PermissionHandler
+	templateHandler      *handler.TaskTemplateHandler
+	medicalHandler       *handler.MedicalHandler
+	feedingHandler       *handler.FeedingHandler
+	measurementHandler   *handler.MeasurementHandler
+	transferHandler      *handler.TransferHandler
+	speciesHandler       *handler.SpeciesHandler
+	compatibilityHandler *handler.CompatibilityHandler
+	quarantineHandler    *handler.QuarantineHandler
+	breedingHandler      *handler.BreedingHandler
+	sensorHandler        *handler.SensorHandler
+	zoneHandler          *handler.ZoneHandler
+	shiftHandler         *handler.ShiftHandler
+	leaveHandler         *handler.LeaveHandler
+	skillHandler         *handler.SkillHandler
 }
 
 func NewHTTPServer(
 	cfg *config.Config,
-	log *logrus.Logger, authHandler *handler.AuthHandler,
+	log *logrus.Logger,
+	sessions middleware.SessionValidator,
+	permissions middleware.PermissionChecker,
+	authHandler *handler.AuthHandler,
 	managerHandler *handler.ManagerHandler,
 	zookeeperHandler *handler.ZookeeperHandler,
 	cageHandler *handler.CageHandler,
 	animalHandler *handler.AnimalHandler,
 	taskHandler *handler.TaskHandler,
+	passwordHandler *handler.PasswordHandler,
+	lockoutHandler *handler.LockoutHandler,
+	mfaHandler *handler.MFAHandler,
+	permissionHandler *handler.PermissionHandler,
+	templateHandler *handler.TaskTemplateHandler,
+	medicalHandler *handler.MedicalHandler,
+	feedingHandler *handler.FeedingHandler,
+	measurementHandler *handler.MeasurementHandler,
+	transferHandler *handler.TransferHandler,
+	speciesHandler *handler.SpeciesHandler,
+	compatibilityHandler *handler.CompatibilityHandler,
+	quarantineHandler *handler.QuarantineHandler,
+	breedingHandler *handler.BreedingHandler,
+	sensorHandler *handler.SensorHandler,
+	zoneHandler *handler.ZoneHandler,
+	shiftHandler *handler.ShiftHandler,
+	leaveHandler *handler.LeaveHandler,
+	skillHandler *handler.SkillHandler,
 ) *HTTPServer {
 	return &HTTPServer{
-		log:              log,
-		cfg:              cfg,
-		authHandler:      authHandler,
-		managerHandler:   managerHandler,
-		zookeeperHandler: zookeeperHandler,
-		cageHandler:      cageHandler,
-		animalHandler:    animalHandler,
-		taskHandler:      taskHandler,
+		log:                  log,
+		cfg:                  cfg,
+		sessions:             sessions,
+		permissions:          permissions,
+		authHandler:          authHandler,
+		managerHandler:       managerHandler,
+		zookeeperHandler:     zookeeperHandler,
+		cageHandler:          cageHandler,
+		animalHandler:        animalHandler,
+		taskHandler:          taskHandler,
+		passwordHandler:      passwordHandler,
+		lockoutHandler:       lockoutHandler,
+		mfaHandler:           mfaHandler,
+		permissionHandler:    permissionHandler,
+		templateHandler:      templateHandler,
+		medicalHandler:       medicalHandler,
+		feedingHandler:       feedingHandler,
+		measurementHandler:   measurementHandler,
+		transferHandler:      transferHandler,
+		speciesHandler:       speciesHandler,
+		compatibilityHandler: compatibilityHandler,
+		quarantineHandler:    quarantineHandler,
+		breedingHandler:      breedingHandler,
+		sensorHandler:        sensorHandler,
+		zoneHandler:          zoneHandler,
+		shiftHandler:         shiftHandler,
+		leaveHandler:         leaveHandler,
+		skillHandler:         skillHandler,
 	}
 }
 
@@ -47,7 +109,14 @@ func (s *HTTPServer) Start() {
 		port = "8080"
 	}
 
-	app := fiber.New()
+	// Logins are throttled per client IP, so behind the frontend the IP is
+	// read from X-Forwarded-For, but only when a trusted proxy sent it.
+	app := fiber.New(fiber.Config{
+		ProxyHeader:             fiber.HeaderXForwardedFor,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          s.cfg.TrustedProxies,
+		EnableIPValidation:      true,
+	})
 
 	// Health check
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -61,61 +130,227 @@ func (s *HTTPServer) Start() {
 	// Auth Routes (public)
 	auth := app.Group("/auth")
 	auth.Post("/login", s.authHandler.Login)
+	auth.Post("/mfa/verify", s.authHandler.VerifyMFA)
+	auth.Post("/refresh", s.authHandler.Refresh)
+	auth.Post("/logout", s.authHandler.Logout)
+	auth.Post("/password-reset", s.passwordHandler.Reset)
+
+	// Sensor ingestion (device key, no user login)
+	app.Post("/ingest/readings", s.sensorHandler.Ingest)
+
+	can := func(permission domain.Permission) fiber.Handler {
+		return middleware.RequirePermission(s.permissions, permission)
+	}
 
 	// Protected API
 	api := app.Group("/api",
-		middleware.JWT(s.cfg.JWTSecret),
+		middleware.JWT(s.cfg.JWTSecret, s.sessions),
+		middleware.RequirePasswordChanged("/api/me/password"),
 	)
 
-	manager := api.Group("/managers",
-		middleware.RequireRole("MANAGER"),
-	)
-	manager.Post("/", s.managerHandler.Create)
-	manager.Get("/", s.managerHandler.List)
-	manager.Get("/:public_id", s.managerHandler.FindByID)
-	manager.Put("/:public_id", s.managerHandler.Update)
-	manager.Delete("/:public_id", s.managerHandler.Delete)
+	me := api.Group("/me")
+	me.Put("/password", s.passwordHandler.Change)
 
-	zookeeper := api.Group("/zookeepers",
+	mfa := me.Group("/mfa",
 		middleware.RequireRole("MANAGER"),
 	)
-	zookeeper.Post("/", s.zookeeperHandler.Create)
-	zookeeper.Get("/", s.zookeeperHandler.List)
-	zookeeper.Get("/:public_id", s.zookeeperHandler.FindByID)
-	zookeeper.Put("/:public_id", s.zookeeperHandler.Update)
-	zookeeper.Delete("/:public_id", s.zookeeperHandler.Delete)
+	mfa.Post("/enroll", s.mfaHandler.Enroll)
+	mfa.Post("/confirm", s.mfaHandler.Confirm)
+	mfa.Delete("/", s.mfaHandler.Disable)
 
-	cage := api.Group("/cages",
-		middleware.RequireRole("MANAGER"),
-	)
-	cage.Post("/", s.cageHandler.Create)
-	cage.Get("/", s.cageHandler.List)
-	cage.Get("/:public_id", s.cageHandler.FindByID)
-	cage.Put("/:public_id", s.cageHandler.Update)
-	cage.Delete("/:public_id", s.cageHandler.Delete)
+	user := api.Group("/users")
+	user.Get("/locked", can(domain.PermUsersManage), s.lockoutHandler.ListLocked)
+	user.Post("/:public_id/password-reset", can(domain.PermUsersManage), s.passwordHandler.IssueReset)
+	user.Get("/:public_id/lock", can(domain.PermUsersManage), s.lockoutHandler.LockState)
+	user.Delete("/:public_id/lock", can(domain.PermUsersManage), s.lockoutHandler.Unlock)
 
-	animal := api.Group("/animals",
-		middleware.RequireRole("MANAGER"),
-	)
-	animal.Post("/", s.animalHandler.Create)
-	animal.Get("/", s.animalHandler.List)
-	animal.Get("/:public_id", s.animalHandler.FindByID)
-	animal.Put("/:public_id", s.animalHandler.Update)
-	animal.Delete("/:public_id", s.animalHandler.Delete)
+	api.Get("/permissions", can(domain.PermRolesManage), s.permissionHandler.ListPermissions)
 
-	// Task Routes
-	task := api.Group("/tasks")
+	role := api.Group("/roles")
+	role.Get("/", can(domain.PermRolesManage), s.permissionHandler.ListRoles)
+	role.Put("/:role/permissions", can(domain.PermRolesManage), s.permissionHandler.SetRolePermissions)
 
-	// MANAGER routes
-	managerTask := task.Group("/",
-		middleware.RequireRole("MANAGER"),
-	)
-	managerTask.Post("/", s.taskHandler.Create)
-	managerTask.Delete("/:public_id", s.taskHandler.Delete)
+	manager := api.Group("/managers")
+	manager.Post("/", can(domain.PermManagersWrite), s.managerHandler.Create)
+	manager.Get("/", can(domain.PermManagersRead), s.managerHandler.List)
+	manager.Get("/:public_id", can(domain.PermManagersRead), s.managerHandler.FindByID)
+	manager.Put("/:public_id", can(domain.PermManagersWrite), s.managerHandler.Update)
+	manager.Delete("/:public_id", can(domain.PermManagersWrite), s.managerHandler.Delete)
+
+	zookeeper := api.Group("/zookeepers")
+	zookeeper.Post("/", can(domain.PermZookeepersWrite), s.zookeeperHandler.Create)
+	zookeeper.Get("/", can(domain.PermZookeepersRead), s.zookeeperHandler.List)
+	// registered before /:public_id so "availability" is not taken for an id
+	zookeeper.Get("/availability", can(domain.PermLeaveRead), s.leaveHandler.Availability)
+	zookeeper.Get("/:public_id", can(domain.PermZookeepersRead), s.zookeeperHandler.FindByID)
+	zookeeper.Put("/:public_id", can(domain.PermZookeepersWrite), s.zookeeperHandler.Update)
+	zookeeper.Delete("/:public_id", can(domain.PermZookeepersWrite), s.zookeeperHandler.Delete)
+
+	shift := api.Group("/shifts")
+	shift.Post("/", can(domain.PermShiftsWrite), s.shiftHandler.Create)
+	shift.Get("/", can(domain.PermShiftsRead), s.shiftHandler.List)
+	// registered before /:public_id so "roster" is not taken for an id
+	shift.Get("/roster", can(domain.PermShiftsRead), s.shiftHandler.Roster)
+	shift.Get("/:public_id", can(domain.PermShiftsRead), s.shiftHandler.FindByID)
+	shift.Put("/:public_id", can(domain.PermShiftsWrite), s.shiftHandler.Update)
+	shift.Delete("/:public_id", can(domain.PermShiftsWrite), s.shiftHandler.Delete)
+
+	leave := api.Group("/leave-requests")
+	leave.Post("/", can(domain.PermLeaveRequest), s.leaveHandler.Submit)
+	leave.Get("/", can(domain.PermLeaveRead), s.leaveHandler.List)
+	leave.Get("/:public_id", can(domain.PermLeaveRead), s.leaveHandler.FindByID)
+	leave.Post("/:public_id/approve", can(domain.PermLeaveApprove), s.leaveHandler.Approve)
+	leave.Post("/:public_id/reject", can(domain.PermLeaveApprove), s.leaveHandler.Reject)
+	leave.Post("/:public_id/cancel", can(domain.PermLeaveRead), s.leaveHandler.Cancel)
+	leave.Post("/:public_id/reassign", can(domain.PermLeaveApprove), s.leaveHandler.Reassign)
+
+	skill := api.Group("/skills")
+	skill.Post("/", can(domain.PermSkillsWrite), s.skillHandler.CreateSkill)
+	skill.Get("/", can(domain.PermSkillsRead), s.skillHandler.ListSkills)
+	skill.Get("/:public_id", can(domain.PermSkillsRead), s.skillHandler.FindSkill)
+	skill.Put("/:public_id", can(domain.PermSkillsWrite), s.skillHandler.UpdateSkill)
+	skill.Delete("/:public_id", can(domain.PermSkillsWrite), s.skillHandler.DeleteSkill)
+
+	certification := api.Group("/certifications")
+	certification.Post("/", can(domain.PermSkillsWrite), s.skillHandler.CreateCertification)
+	certification.Get("/", can(domain.PermSkillsRead), s.skillHandler.ListCertifications)
+	// registered before /:public_id so "expiring" is not taken for an id
+	certification.Get("/expiring", can(domain.PermSkillsRead), s.skillHandler.Expiring)
+	certification.Get("/:public_id", can(domain.PermSkillsRead), s.skillHandler.FindCertification)
+	certification.Put("/:public_id", can(domain.PermSkillsWrite), s.skillHandler.UpdateCertification)
+	certification.Delete("/:public_id", can(domain.PermSkillsWrite), s.skillHandler.DeleteCertification)
+
+	zone := api.Group("/zones")
+	zone.Post("/", can(domain.PermZonesWrite), s.zoneHandler.Create)
+	zone.Get("/", can(domain.PermZonesRead), s.zoneHandler.List)
+	zone.Get("/:public_id", can(domain.PermZonesRead), s.zoneHandler.FindByID)
+	zone.Put("/:public_id", can(domain.PermZonesWrite), s.zoneHandler.Update)
+	zone.Delete("/:public_id", can(domain.PermZonesWrite), s.zoneHandler.Delete)
+	zone.Post("/:public_id/areas", can(domain.PermZonesWrite), s.zoneHandler.CreateArea)
+	zone.Get("/:public_id/areas", can(domain.PermZonesRead), s.zoneHandler.ListAreas)
+	zone.Put("/:public_id/areas/:area_id", can(domain.PermZonesWrite), s.zoneHandler.UpdateArea)
+	zone.Delete("/:public_id/areas/:area_id", can(domain.PermZonesWrite), s.zoneHandler.DeleteArea)
+
+	cage := api.Group("/cages")
+	cage.Post("/", can(domain.PermCagesWrite), s.cageHandler.Create)
+	cage.Get("/", can(domain.PermCagesRead), s.cageHandler.List)
+	cage.Get("/:public_id", can(domain.PermCagesRead), s.cageHandler.FindByID)
+	cage.Get("/:public_id/occupancy", can(domain.PermCagesRead), s.cageHandler.Occupancy)
+	cage.Get("/:public_id/occupants", can(domain.PermCagesRead), s.transferHandler.Occupants)
+	cage.Put("/:public_id", can(domain.PermCagesWrite), s.cageHandler.Update)
+	cage.Delete("/:public_id", can(domain.PermCagesWrite), s.cageHandler.Delete)
+	cage.Get("/:public_id/environment", can(domain.PermSensorsRead), s.sensorHandler.Environment)
+	cage.Get("/:public_id/environment/thresholds", can(domain.PermSensorsRead), s.sensorHandler.ListThresholds)
+	cage.Put("/:public_id/environment/thresholds/:metric", can(domain.PermSensorsWrite), s.sensorHandler.SetThreshold)
+	cage.Delete("/:public_id/environment/thresholds/:metric", can(domain.PermSensorsWrite), s.sensorHandler.DeleteThreshold)
+
+	sensor := api.Group("/sensors")
+	sensor.Post("/", can(domain.PermSensorsWrite), s.sensorHandler.Create)
+	sensor.Get("/", can(domain.PermSensorsRead), s.sensorHandler.List)
+	// registered before /:public_id so "alerts" is not taken for an id
+	sensor.Get("/alerts", can(domain.PermSensorsRead), s.sensorHandler.ListAlerts)
+	sensor.Post("/alerts/:public_id/acknowledge", can(domain.PermSensorsWrite), s.sensorHandler.AcknowledgeAlert)
+	sensor.Get("/:public_id", can(domain.PermSensorsRead), s.sensorHandler.FindByID)
+	sensor.Put("/:public_id", can(domain.PermSensorsWrite), s.sensorHandler.Update)
+	sensor.Delete("/:public_id", can(domain.PermSensorsWrite), s.sensorHandler.Delete)
+	sensor.Post("/:public_id/rotate-key", can(domain.PermSensorsWrite), s.sensorHandler.RotateKey)
+
+	species := api.Group("/species")
+	species.Post("/", can(domain.PermSpeciesWrite), s.speciesHandler.Create)
+	species.Get("/", can(domain.PermSpeciesRead), s.speciesHandler.List)
+	// registered before /:public_id so "compatibility" is not taken for an id
+	species.Get("/compatibility", can(domain.PermSpeciesRead), s.compatibilityHandler.ListRules)
+	species.Get("/compatibility/overrides", can(domain.PermCagesRead), s.compatibilityHandler.ListOverrides)
+	species.Get("/:public_id", can(domain.PermSpeciesRead), s.speciesHandler.FindByID)
+	species.Put("/:public_id", can(domain.PermSpeciesWrite), s.speciesHandler.Update)
+	species.Delete("/:public_id", can(domain.PermSpeciesWrite), s.speciesHandler.Delete)
+	species.Post("/:public_id/merge", can(domain.PermSpeciesWrite), s.speciesHandler.Merge)
+	species.Get("/:public_id/skills", can(domain.PermSkillsRead), s.skillHandler.SpeciesSkills)
+	species.Put("/:public_id/skills", can(domain.PermSkillsWrite), s.skillHandler.SetSpeciesSkills)
+	species.Put("/:public_id/compatibility/:other_public_id", can(domain.PermSpeciesWrite), s.compatibilityHandler.SetRule)
+	species.Delete("/:public_id/compatibility/:other_public_id", can(domain.PermSpeciesWrite), s.compatibilityHandler.DeleteRule)
+
+	animal := api.Group("/animals")
+	animal.Post("/", can(domain.PermAnimalsWrite), s.animalHandler.Create)
+	animal.Get("/", can(domain.PermAnimalsRead), s.animalHandler.List)
+	animal.Get("/:public_id", can(domain.PermAnimalsRead), s.animalHandler.FindByID)
+	animal.Put("/:public_id", can(domain.PermAnimalsWrite), s.animalHandler.Update)
+	animal.Delete("/:public_id", can(domain.PermAnimalsWrite), s.animalHandler.Delete)
+	animal.Post("/:public_id/transfer", can(domain.PermAnimalsWrite), s.transferHandler.Transfer)
+	animal.Get("/:public_id/residences", can(domain.PermAnimalsRead), s.transferHandler.History)
+	animal.Post("/:public_id/status", can(domain.PermAnimalsWrite), s.animalHandler.ChangeStatus)
+	animal.Get("/:public_id/status-history", can(domain.PermAnimalsRead), s.animalHandler.StatusHistory)
+
+	animal.Get("/:public_id/medical", can(domain.PermMedicalRead), s.medicalHandler.Timeline)
+	animal.Post("/:public_id/medical/visits", can(domain.PermMedicalWrite), s.medicalHandler.CreateVisit)
+	animal.Post("/:public_id/medical/diagnoses", can(domain.PermMedicalWrite), s.medicalHandler.CreateDiagnosis)
+	animal.Patch("/:public_id/medical/diagnoses/:diagnosis_id/resolve", can(domain.PermMedicalWrite), s.medicalHandler.ResolveDiagnosis)
+	animal.Post("/:public_id/medical/prescriptions", can(domain.PermMedicalWrite), s.medicalHandler.CreatePrescription)
+	animal.Post("/:public_id/medical/vaccinations", can(domain.PermMedicalWrite), s.medicalHandler.CreateVaccination)
+
+	api.Get("/medical/vaccinations/due", can(domain.PermMedicalRead), s.medicalHandler.VaccinationsDue)
+
+	animal.Get("/:public_id/quarantines", can(domain.PermQuarantineRead), s.quarantineHandler.ListByAnimal)
+	animal.Post("/:public_id/quarantines", can(domain.PermQuarantineWrite), s.quarantineHandler.Open)
+
+	quarantine := api.Group("/quarantines")
+	quarantine.Get("/", can(domain.PermQuarantineRead), s.quarantineHandler.List)
+	quarantine.Get("/:public_id", can(domain.PermQuarantineRead), s.quarantineHandler.FindByID)
+	quarantine.Put("/:public_id", can(domain.PermQuarantineWrite), s.quarantineHandler.Update)
+	quarantine.Post("/:public_id/checks/:check_id/pass", can(domain.PermQuarantineWrite), s.quarantineHandler.PassCheck)
+	quarantine.Post("/:public_id/clear", can(domain.PermQuarantineWrite), s.quarantineHandler.Clear)
+
+	animal.Put("/:public_id/studbook", can(domain.PermBreedingWrite), s.breedingHandler.SetStudbook)
+	animal.Get("/:public_id/pedigree", can(domain.PermBreedingRead), s.breedingHandler.Pedigree)
+
+	breeding := api.Group("/breeding")
+	breeding.Get("/pedigree", can(domain.PermBreedingRead), s.breedingHandler.AssessPairing)
+	breeding.Post("/pairings", can(domain.PermBreedingWrite), s.breedingHandler.CreatePairing)
+	breeding.Get("/pairings", can(domain.PermBreedingRead), s.breedingHandler.ListPairings)
+	breeding.Get("/pairings/:public_id", can(domain.PermBreedingRead), s.breedingHandler.FindPairing)
+	breeding.Post("/pairings/:public_id/end", can(domain.PermBreedingWrite), s.breedingHandler.EndPairing)
+	breeding.Post("/pregnancies", can(domain.PermBreedingWrite), s.breedingHandler.CreatePregnancy)
+	breeding.Get("/pregnancies", can(domain.PermBreedingRead), s.breedingHandler.ListPregnancies)
+	breeding.Get("/pregnancies/:public_id", can(domain.PermBreedingRead), s.breedingHandler.FindPregnancy)
+	breeding.Post("/pregnancies/:public_id/lost", can(domain.PermBreedingWrite), s.breedingHandler.MarkPregnancyLost)
+	breeding.Post("/births", can(domain.PermBreedingWrite), s.breedingHandler.RecordBirth)
+	breeding.Get("/births", can(domain.PermBreedingRead), s.breedingHandler.ListBirths)
+	breeding.Get("/births/:public_id", can(domain.PermBreedingRead), s.breedingHandler.FindBirth)
+
+	animal.Get("/:public_id/diet-plans", can(domain.PermFeedingRead), s.feedingHandler.ListDietPlans)
+	animal.Post("/:public_id/diet-plans", can(domain.PermFeedingWrite), s.feedingHandler.CreateDietPlan)
+	animal.Put("/:public_id/diet-plans/:plan_id", can(domain.PermFeedingWrite), s.feedingHandler.UpdateDietPlan)
+	animal.Delete("/:public_id/diet-plans/:plan_id", can(domain.PermFeedingWrite), s.feedingHandler.DeleteDietPlan)
+	animal.Get("/:public_id/feedings", can(domain.PermFeedingRead), s.feedingHandler.ListFeedingLogs)
+	animal.Post("/:public_id/feedings", can(domain.PermFeedingLog), s.feedingHandler.LogFeeding)
+
+	api.Get("/feedings/missed", can(domain.PermFeedingRead), s.feedingHandler.Missed)
+
+	animal.Get("/:public_id/measurements", can(domain.PermMeasurementsRead), s.measurementHandler.List)
+	animal.Post("/:public_id/measurements", can(domain.PermMeasurementsWrite), s.measurementHandler.Create)
+	animal.Get("/:public_id/measurements/trend", can(domain.PermMeasurementsRead), s.measurementHandler.Trend)
+
+	measurement := api.Group("/measurements")
+	measurement.Get("/alerts", can(domain.PermMeasurementsRead), s.measurementHandler.Alerts)
+	measurement.Get("/thresholds", can(domain.PermMeasurementsRead), s.measurementHandler.ListThresholds)
+	measurement.Put("/thresholds/:species_public_id", can(domain.PermMeasurementsConfigure), s.measurementHandler.SetThreshold)
+	measurement.Delete("/thresholds/:species_public_id", can(domain.PermMeasurementsConfigure), s.measurementHandler.DeleteThreshold)
+
+	task := api.Group("/tasks")
+	task.Post("/", can(domain.PermTasksAssign), s.taskHandler.Create)
+	task.Get("/", can(domain.PermTasksRead), s.taskHandler.List)
+	task.Patch("/:public_id/status", can(domain.PermTasksUpdateStatus), s.taskHandler.UpdateStatus)
+	task.Get("/:public_id/history", can(domain.PermTasksRead), s.taskHandler.History)
+	task.Delete("/:public_id", can(domain.PermTasksDelete), s.taskHandler.Delete)
 
-	// Shared routes (MANAGER & ZOOKEEPER)
-	task.Get("/", s.taskHandler.List)
-	task.Patch("/:public_id/status", s.taskHandler.UpdateStatus)
+	template := api.Group("/task-templates")
+	template.Post("/", can(domain.PermTasksAssign), s.templateHandler.Create)
+	template.Get("/", can(domain.PermTasksAssign), s.templateHandler.List)
+	template.Get("/:public_id", can(domain.PermTasksAssign), s.templateHandler.Get)
+	template.Put("/:public_id", can(domain.PermTasksAssign), s.templateHandler.Update)
+	template.Delete("/:public_id", can(domain.PermTasksAssign), s.templateHandler.Delete)
+	template.Get("/:public_id/skills", can(domain.PermTasksAssign), s.skillHandler.TemplateSkills)
+	template.Put("/:public_id/skills", can(domain.PermTasksAssign), s.skillHandler.SetTemplateSkills)
 
 	s.log.Infof("🚀 HTTP server running on port %s", port)
 
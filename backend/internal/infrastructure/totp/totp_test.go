@@ -0,0 +1,75 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 seed of RFC 6238 appendix B.
+var rfcSecret = []byte("12345678901234567890")
+
+func TestGenerateRFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tt := range tests {
+		if got := generate(rfcSecret, tt.unix/period, 8); got != tt.want {
+			t.Errorf("T=%d: got %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateWindow(t *testing.T) {
+	secret := encoding.EncodeToString(rfcSecret)
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / period
+
+	tests := []struct {
+		name   string
+		offset int64
+		ok     bool
+	}{
+		{"two steps behind", -2, false},
+		{"one step behind", -1, true},
+		{"current step", 0, true},
+		{"one step ahead", 1, true},
+		{"two steps ahead", 2, false},
+	}
+
+	for _, tt := range tests {
+		code := generate(rfcSecret, current+tt.offset, digits)
+
+		step, ok := Validate(secret, code, now)
+		if ok != tt.ok {
+			t.Errorf("%s: got ok=%v, want %v", tt.name, ok, tt.ok)
+			continue
+		}
+		if ok && step != current+tt.offset {
+			t.Errorf("%s: got step %d, want %d", tt.name, step, current+tt.offset)
+		}
+	}
+}
+
+func TestValidateRejectsMalformedInput(t *testing.T) {
+	secret := encoding.EncodeToString(rfcSecret)
+	now := time.Unix(59, 0)
+
+	if _, ok := Validate(secret, "94287082", now); ok {
+		t.Error("accepted an 8 digit code")
+	}
+	if _, ok := Validate("not base32!", "287082", now); ok {
+		t.Error("accepted an invalid secret")
+	}
+	if _, ok := Validate(secret, "287082", now); !ok {
+		t.Error("rejected the valid code")
+	}
+}
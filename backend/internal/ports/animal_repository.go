@@ -3,33 +3,82 @@ package ports
 import (
 	"context"
 	"time"
+	"wit-leisure-park/backend/internal/domain/animal"
+)
+
+// AnimalStatus aliases the domain type, which owns the allowed transitions.
+type AnimalStatus = animal.Status
+
+const (
+	AnimalActive         = animal.StatusActive
+	AnimalQuarantined    = animal.StatusQuarantined
+	AnimalOnLoan         = animal.StatusOnLoan
+	AnimalDeceased       = animal.StatusDeceased
+	AnimalTransferredOut = animal.StatusTransferredOut
 )
 
 type AnimalRepository interface {
 	Create(
 		ctx context.Context,
-		publicID, name, species string,
+		publicID, name, speciesPublicID string,
 		cagePublicID string,
 		dateOfBirth *time.Time,
+		guard PlacementGuard,
 	) (string, error)
 
-	List(ctx context.Context) ([]AnimalDTO, error)
+	List(ctx context.Context, query AnimalListQuery) (Page[AnimalDTO], error)
 
 	FindByID(ctx context.Context, publicID string) (AnimalDTO, error)
 
 	Update(
 		ctx context.Context,
-		publicID, name, species, cagePublicID string,
+		publicID, name, speciesPublicID, cagePublicID string,
 		dateOfBirth *time.Time,
+		guard PlacementGuard,
 	) error
 
 	Delete(ctx context.Context, publicID string) error
+
+	// ChangeStatus applies the change only if the animal still has the From
+	// status and records it in the status history. Leaving the park closes
+	// the animal's cage stay; coming back reserves a slot in its cage again
+	// and runs the guard against the animals living there. It returns
+	// ErrConflict when the status was changed concurrently.
+	ChangeStatus(ctx context.Context, change AnimalStatusChange, guard PlacementGuard) error
+	ListStatusHistory(ctx context.Context, publicID string) ([]AnimalStatusHistoryDTO, error)
 }
 
 type AnimalDTO struct {
-	PublicID    string     `json:"public_id"`
-	Name        string     `json:"name"`
-	Species     string     `json:"species"`
-	CageID      string     `json:"cage_public_id"`
-	DateOfBirth *time.Time `json:"date_of_birth,omitempty"`
+	PublicID        string       `json:"public_id"`
+	Name            string       `json:"name"`
+	SpeciesPublicID string       `json:"species_public_id"`
+	Species         string       `json:"species"`
+	CageID          string       `json:"cage_public_id"`
+	DateOfBirth     *time.Time   `json:"date_of_birth,omitempty"`
+	Status          AnimalStatus `json:"status"`
+	Sex             AnimalSex    `json:"sex"`
+	DamPublicID     *string      `json:"dam_public_id,omitempty"`
+	SirePublicID    *string      `json:"sire_public_id,omitempty"`
+	MicrochipID     *string      `json:"microchip_id,omitempty"`
+	StudbookID      *string      `json:"studbook_id,omitempty"`
+	ZonePublicID    *string      `json:"zone_public_id"`
+}
+
+type AnimalStatusChange struct {
+	PublicID string
+	From     AnimalStatus
+	To       AnimalStatus
+	Reason   string
+	// nil means the change takes effect now
+	EffectiveAt   *time.Time
+	ActorPublicID string
+}
+
+type AnimalStatusHistoryDTO struct {
+	FromStatus    *AnimalStatus `json:"from_status"`
+	ToStatus      AnimalStatus  `json:"to_status"`
+	Reason        *string       `json:"reason,omitempty"`
+	EffectiveAt   time.Time     `json:"effective_at"`
+	ActorPublicID *string       `json:"actor_public_id,omitempty"`
+	ActorUsername *string       `json:"actor_username,omitempty"`
 }
@@ -0,0 +1,187 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type AnimalSex string
+
+const (
+	SexMale    AnimalSex = "MALE"
+	SexFemale  AnimalSex = "FEMALE"
+	SexUnknown AnimalSex = "UNKNOWN"
+)
+
+type PregnancyStatus string
+
+const (
+	PregnancyOngoing PregnancyStatus = "ONGOING"
+	PregnancyBorn    PregnancyStatus = "BORN"
+	PregnancyLost    PregnancyStatus = "LOST"
+)
+
+// StudbookInput replaces the identification and parentage of an animal.
+type StudbookInput struct {
+	AnimalPublicID string
+	Sex            AnimalSex
+	DamPublicID    *string
+	SirePublicID   *string
+	MicrochipID    *string
+	StudbookID     *string
+}
+
+// PedigreeEntry is an animal in an ancestry query with its parents.
+type PedigreeEntry struct {
+	PublicID        string       `json:"public_id"`
+	Name            string       `json:"name"`
+	SpeciesPublicID string       `json:"species_public_id"`
+	Sex             AnimalSex    `json:"sex"`
+	DateOfBirth     *time.Time   `json:"date_of_birth,omitempty"`
+	StudbookID      *string      `json:"studbook_id,omitempty"`
+	Status          AnimalStatus `json:"status"`
+	DamPublicID     *string      `json:"dam_public_id,omitempty"`
+	SirePublicID    *string      `json:"sire_public_id,omitempty"`
+}
+
+// PedigreeNode is an animal in an ancestry tree. Dam and Sire are empty
+// when unknown or beyond the requested generations.
+type PedigreeNode struct {
+	PublicID    string        `json:"public_id"`
+	Name        string        `json:"name"`
+	Sex         AnimalSex     `json:"sex"`
+	DateOfBirth *time.Time    `json:"date_of_birth,omitempty"`
+	StudbookID  *string       `json:"studbook_id,omitempty"`
+	Status      AnimalStatus  `json:"status"`
+	Dam         *PedigreeNode `json:"dam,omitempty"`
+	Sire        *PedigreeNode `json:"sire,omitempty"`
+}
+
+type PedigreeDTO struct {
+	Generations           int          `json:"generations"`
+	InbreedingCoefficient float64      `json:"inbreeding_coefficient"`
+	Animal                PedigreeNode `json:"animal"`
+}
+
+// PairingAssessmentDTO describes the offspring a proposed pairing would
+// produce.
+type PairingAssessmentDTO struct {
+	Generations           int             `json:"generations"`
+	InbreedingCoefficient float64         `json:"inbreeding_coefficient"`
+	CommonAncestors       []PedigreeEntry `json:"common_ancestors"`
+	Dam                   PedigreeNode    `json:"dam"`
+	Sire                  PedigreeNode    `json:"sire"`
+}
+
+type PairingDTO struct {
+	PublicID     string     `json:"public_id"`
+	DamPublicID  string     `json:"dam_public_id"`
+	DamName      string     `json:"dam_name"`
+	SirePublicID string     `json:"sire_public_id"`
+	SireName     string     `json:"sire_name"`
+	PairedOn     time.Time  `json:"paired_on"`
+	SeparatedOn  *time.Time `json:"separated_on,omitempty"`
+	Inbreeding   *float64   `json:"inbreeding_coefficient,omitempty"`
+	Notes        *string    `json:"notes,omitempty"`
+	CreatedBy    *string    `json:"created_by,omitempty"`
+}
+
+type PairingInput struct {
+	PublicID          string
+	DamPublicID       string
+	SirePublicID      string
+	PairedOn          time.Time
+	Inbreeding        float64
+	Notes             *string
+	CreatedByPublicID string
+}
+
+type PregnancyDTO struct {
+	PublicID        string          `json:"public_id"`
+	DamPublicID     string          `json:"dam_public_id"`
+	DamName         string          `json:"dam_name"`
+	SirePublicID    *string         `json:"sire_public_id,omitempty"`
+	PairingPublicID *string         `json:"pairing_public_id,omitempty"`
+	DetectedOn      time.Time       `json:"detected_on"`
+	ExpectedDueOn   *time.Time      `json:"expected_due_on,omitempty"`
+	Status          PregnancyStatus `json:"status"`
+	EndedOn         *time.Time      `json:"ended_on,omitempty"`
+	Notes           *string         `json:"notes,omitempty"`
+}
+
+type PregnancyInput struct {
+	PublicID           string
+	DamPublicID        string
+	SirePublicID       *string
+	PairingPublicID    *string
+	DetectedOn         time.Time
+	ExpectedDueOn      *time.Time
+	Notes              *string
+	RecordedByPublicID string
+}
+
+type OffspringInput struct {
+	PublicID    string
+	Name        string
+	Sex         AnimalSex
+	MicrochipID *string
+	StudbookID  *string
+}
+
+// BirthInput records a birth. The offspring go to the dam's current cage
+// unless CagePublicID is set.
+type BirthInput struct {
+	PublicID           string
+	DamPublicID        string
+	SirePublicID       *string
+	PregnancyPublicID  *string
+	CagePublicID       *string
+	BornOn             time.Time
+	StillbornCount     int
+	Notes              *string
+	Offspring          []OffspringInput
+	RecordedByPublicID string
+}
+
+type BirthDTO struct {
+	PublicID          string      `json:"public_id"`
+	DamPublicID       string      `json:"dam_public_id"`
+	SirePublicID      *string     `json:"sire_public_id,omitempty"`
+	PregnancyPublicID *string     `json:"pregnancy_public_id,omitempty"`
+	BornOn            time.Time   `json:"born_on"`
+	StillbornCount    int         `json:"stillborn_count"`
+	Notes             *string     `json:"notes,omitempty"`
+	Offspring         []AnimalDTO `json:"offspring"`
+}
+
+type BreedingRepository interface {
+	// SetStudbook updates the animal's sex, parents and identifiers. It
+	// returns ErrNotFound for a missing animal or parent and ErrDuplicate
+	// when the microchip or studbook id belongs to another animal.
+	SetStudbook(ctx context.Context, input StudbookInput) error
+
+	// Ancestors returns the given animals and their ancestors up to the
+	// given number of generations, each animal once.
+	Ancestors(ctx context.Context, publicIDs []string, generations int) ([]PedigreeEntry, error)
+
+	CreatePairing(ctx context.Context, input PairingInput) error
+	// ListPairings returns pairings newest first, optionally only those
+	// of one animal. A non-nil scope keeps those whose dam lives in one of
+	// the zones, as do the other breeding lists.
+	ListPairings(ctx context.Context, animalPublicID *string, scope []string) ([]PairingDTO, error)
+	FindPairing(ctx context.Context, publicID string) (PairingDTO, error)
+	EndPairing(ctx context.Context, publicID string, separatedOn time.Time) error
+
+	// CreatePregnancy returns ErrConflict when the dam already has an
+	// ongoing pregnancy.
+	CreatePregnancy(ctx context.Context, input PregnancyInput) error
+	ListPregnancies(ctx context.Context, status *PregnancyStatus, scope []string) ([]PregnancyDTO, error)
+	FindPregnancy(ctx context.Context, publicID string) (PregnancyDTO, error)
+	EndPregnancy(ctx context.Context, publicID string, status PregnancyStatus, endedOn time.Time, notes *string) error
+
+	// RecordBirth creates the offspring as ACTIVE animals of the dam's
+	// species, enforcing the cage capacity, and marks the pregnancy BORN.
+	RecordBirth(ctx context.Context, input BirthInput, guard PlacementGuard) error
+	ListBirths(ctx context.Context, damPublicID *string, scope []string) ([]BirthDTO, error)
+	FindBirth(ctx context.Context, publicID string) (BirthDTO, error)
+}
@@ -0,0 +1,52 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// CageAssignmentDTO is one stay of an animal in a cage. EndedAt is empty
+// while the animal still lives there.
+type CageAssignmentDTO struct {
+	AnimalPublicID  string     `json:"animal_public_id"`
+	AnimalName      string     `json:"animal_name"`
+	SpeciesPublicID string     `json:"species_public_id"`
+	Species         string     `json:"species"`
+	CagePublicID    string     `json:"cage_public_id"`
+	CageCode        string     `json:"cage_code"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	Reason          *string    `json:"reason,omitempty"`
+	MovedBy         *string    `json:"moved_by,omitempty"`
+}
+
+type TransferInput struct {
+	AnimalPublicID string
+	ToCagePublicID string
+	Reason         string
+	// nil means the transfer happens now
+	TransferredAt *time.Time
+	ActorPublicID string
+}
+
+// TransferDTO holds the stay a transfer closed and the one it opened.
+type TransferDTO struct {
+	From CageAssignmentDTO `json:"from"`
+	To   CageAssignmentDTO `json:"to"`
+}
+
+type CageAssignmentRepository interface {
+	// Transfer moves the animal into another cage, closing its current
+	// assignment and opening a new one. The target cage capacity is
+	// enforced in the same transaction, as is the guard.
+	Transfer(ctx context.Context, input TransferInput, guard PlacementGuard) (TransferDTO, error)
+
+	// ListByAnimal returns where the animal has lived, newest first, or
+	// ErrNotFound when the animal does not exist.
+	ListByAnimal(ctx context.Context, animalPublicID string) ([]CageAssignmentDTO, error)
+
+	// ListByCage returns the stays in the cage that overlap [from, to),
+	// newest first. A nil bound leaves that side open. Returns ErrNotFound
+	// when the cage does not exist.
+	ListByCage(ctx context.Context, cagePublicID string, from, to *time.Time) ([]CageAssignmentDTO, error)
+}
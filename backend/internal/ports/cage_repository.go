@@ -5,19 +5,69 @@ import "context"
 type CageRepository interface {
 	CodeExists(ctx context.Context, code string) (bool, error)
 
-	Create(ctx context.Context, publicID, code, location string) (string, error)
+	Create(ctx context.Context, input CageInput) (string, error)
 
-	List(ctx context.Context) ([]CageDTO, error)
+	List(ctx context.Context, query CageListQuery) (Page[CageDTO], error)
 
 	FindByID(ctx context.Context, publicID string) (CageDTO, error)
 
-	Update(ctx context.Context, publicID, code, location string) error
+	// Create and Update return ErrNotFound when the area does not exist.
+	// Update returns ErrCapacityExceeded when the new limits are below
+	// the current occupancy.
+	Update(ctx context.Context, input CageInput) error
 
 	Delete(ctx context.Context, publicID string) error
+
+	Occupancy(ctx context.Context, publicID string) (CageOccupancyDTO, error)
 }
 
 type CageDTO struct {
-	PublicID string `json:"public_id"`
-	Code     string `json:"code"`
-	Location string `json:"location"`
+	PublicID  string `json:"public_id"`
+	Code      string `json:"code"`
+	Location  string `json:"location"`
+	Capacity  *int   `json:"capacity"`
+	Occupancy int    `json:"occupancy"`
+	FreeSlots *int   `json:"free_slots"`
+
+	AreaPublicID *string `json:"area_public_id"`
+	Area         *string `json:"area"`
+	ZonePublicID *string `json:"zone_public_id"`
+	Zone         *string `json:"zone"`
+}
+
+// CageSpeciesLimit caps the animals of one species in a cage. Species is
+// the common name and is only filled when reading.
+type CageSpeciesLimit struct {
+	SpeciesPublicID string `json:"species_public_id"`
+	Species         string `json:"species,omitempty"`
+	MaxCount        int    `json:"max_count"`
+}
+
+// CageInput carries the editable cage fields. A nil SpeciesLimits keeps the
+// existing limits on update; an empty slice removes them. A nil AreaPublicID
+// leaves the cage outside any zone.
+type CageInput struct {
+	PublicID      string
+	Code          string
+	Location      string
+	Capacity      *int
+	AreaPublicID  *string
+	SpeciesLimits []CageSpeciesLimit
+}
+
+type SpeciesOccupancyDTO struct {
+	SpeciesPublicID string `json:"species_public_id"`
+	Species         string `json:"species"`
+	Count           int    `json:"count"`
+	MaxCount        *int   `json:"max_count"`
+	FreeSlots       *int   `json:"free_slots"`
+}
+
+type CageOccupancyDTO struct {
+	PublicID  string                `json:"public_id"`
+	Code      string                `json:"code"`
+	Capacity  *int                  `json:"capacity"`
+	Occupancy int                   `json:"occupancy"`
+	FreeSlots *int                  `json:"free_slots"`
+	Species   []SpeciesOccupancyDTO `json:"species"`
 }
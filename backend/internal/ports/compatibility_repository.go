@@ -0,0 +1,73 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type CompatibilityRule string
+
+const (
+	CompatibilityAllowed          CompatibilityRule = "ALLOWED"
+	CompatibilityForbidden        CompatibilityRule = "FORBIDDEN"
+	CompatibilityRequiresApproval CompatibilityRule = "REQUIRES_APPROVAL"
+)
+
+type CompatibilityRuleDTO struct {
+	SpeciesAPublicID string            `json:"species_a_public_id"`
+	SpeciesA         string            `json:"species_a,omitempty"`
+	SpeciesBPublicID string            `json:"species_b_public_id"`
+	SpeciesB         string            `json:"species_b,omitempty"`
+	Rule             CompatibilityRule `json:"rule"`
+	Notes            *string           `json:"notes,omitempty"`
+}
+
+// CompatibilityConflict is an animal already in a cage whose species may
+// not, or not without approval, share the cage with the incoming species.
+type CompatibilityConflict struct {
+	AnimalPublicID  string            `json:"animal_public_id"`
+	AnimalName      string            `json:"animal_name"`
+	SpeciesPublicID string            `json:"species_public_id"`
+	Species         string            `json:"species"`
+	Rule            CompatibilityRule `json:"rule"`
+	Notes           *string           `json:"notes,omitempty"`
+}
+
+// CompatibilityOverrideInput is a manager's approval of a placement despite
+// the conflicts; it is recorded for the animal and cage of the placement.
+type CompatibilityOverrideInput struct {
+	ApprovedByPublicID string
+	Reason             string
+	Conflicts          []CompatibilityConflict
+}
+
+// PlacementGuard decides whether an animal may move into a cage given the
+// conflicts found there. Repositories placing an animal run it once the
+// cage is locked and record the override it returns, if any, in the same
+// transaction. A nil guard skips the check.
+type PlacementGuard func(conflicts []CompatibilityConflict) (*CompatibilityOverrideInput, error)
+
+type CompatibilityOverrideDTO struct {
+	AnimalPublicID string                  `json:"animal_public_id"`
+	AnimalName     string                  `json:"animal_name"`
+	CagePublicID   string                  `json:"cage_public_id"`
+	CageCode       string                  `json:"cage_code"`
+	ApprovedBy     *string                 `json:"approved_by,omitempty"`
+	Reason         string                  `json:"reason"`
+	Conflicts      []CompatibilityConflict `json:"conflicts"`
+	CreatedAt      time.Time               `json:"created_at"`
+}
+
+type CompatibilityRepository interface {
+	// ListRules returns every rule, or only those involving the species
+	// when speciesPublicID is set.
+	ListRules(ctx context.Context, speciesPublicID *string) ([]CompatibilityRuleDTO, error)
+
+	// UpsertRule returns ErrNotFound when either species does not exist.
+	UpsertRule(ctx context.Context, rule CompatibilityRuleDTO) error
+	DeleteRule(ctx context.Context, speciesAPublicID, speciesBPublicID string) error
+
+	// ListOverrides returns the recorded overrides, newest first, optionally
+	// only those for one cage. A non-nil scope keeps cages in those zones.
+	ListOverrides(ctx context.Context, cagePublicID *string, scope []string) ([]CompatibilityOverrideDTO, error)
+}
@@ -0,0 +1,29 @@
+package ports
+
+import "errors"
+
+var (
+	// ErrNotFound is returned by repositories when the requested row does not
+	// exist, so services can tell a missing record apart from a query failure.
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict is returned when a row changed between being read and
+	// being written.
+	ErrConflict = errors.New("record was modified concurrently")
+
+	// ErrDuplicate is returned when an insert collides with an existing row
+	// and was skipped.
+	ErrDuplicate = errors.New("record already exists")
+
+	// ErrCapacityExceeded is returned when a cage has no room left for an
+	// animal, overall or for its species.
+	ErrCapacityExceeded = errors.New("cage capacity exceeded")
+
+	// ErrInUse is returned when a row cannot be deleted because other
+	// records still reference it.
+	ErrInUse = errors.New("record is still in use")
+
+	// ErrQuarantined is returned when an animal cannot leave its cage or
+	// become active because its quarantine has not been cleared.
+	ErrQuarantined = errors.New("animal is in quarantine")
+)
@@ -0,0 +1,91 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type DietPlanDTO struct {
+	PublicID       string     `json:"public_id"`
+	AnimalPublicID string     `json:"animal_public_id"`
+	FoodItem       string     `json:"food_item"`
+	Quantity       float64    `json:"quantity"`
+	Unit           string     `json:"unit"`
+	TimesPerDay    int        `json:"times_per_day"`
+	Notes          *string    `json:"notes,omitempty"`
+	StartsOn       time.Time  `json:"starts_on"`
+	EndsOn         *time.Time `json:"ends_on,omitempty"`
+}
+
+type DietPlanInput struct {
+	PublicID          string
+	AnimalPublicID    string
+	FoodItem          string
+	Quantity          float64
+	Unit              string
+	TimesPerDay       int
+	Notes             *string
+	StartsOn          time.Time
+	EndsOn            *time.Time
+	CreatedByPublicID string
+}
+
+type FeedingLogDTO struct {
+	PublicID         string    `json:"public_id"`
+	DietPlanPublicID *string   `json:"diet_plan_public_id,omitempty"`
+	FedAt            time.Time `json:"fed_at"`
+	FoodItem         string    `json:"food_item"`
+	QuantityGiven    float64   `json:"quantity_given"`
+	QuantityRefused  float64   `json:"quantity_refused"`
+	Unit             string    `json:"unit"`
+	Notes            *string   `json:"notes,omitempty"`
+	FedBy            *string   `json:"fed_by,omitempty"`
+}
+
+type FeedingLogInput struct {
+	PublicID         string
+	AnimalPublicID   string
+	DietPlanPublicID *string
+	FedAt            time.Time
+	FoodItem         string
+	QuantityGiven    float64
+	QuantityRefused  float64
+	Unit             string
+	Notes            *string
+	FedByPublicID    string
+}
+
+// MissedFeedingDTO is a diet plan that got fewer feedings on a day than it
+// asks for.
+type MissedFeedingDTO struct {
+	AnimalPublicID   string  `json:"animal_public_id"`
+	AnimalName       string  `json:"animal_name"`
+	CageCode         string  `json:"cage_code"`
+	DietPlanPublicID string  `json:"diet_plan_public_id"`
+	FoodItem         string  `json:"food_item"`
+	Quantity         float64 `json:"quantity"`
+	Unit             string  `json:"unit"`
+	Expected         int     `json:"expected"`
+	Logged           int     `json:"logged"`
+	Missing          int     `json:"missing"`
+}
+
+type FeedingRepository interface {
+	CreateDietPlan(ctx context.Context, input DietPlanInput) error
+	// ListDietPlans returns the plans of an animal, or ErrNotFound when the
+	// animal does not exist.
+	ListDietPlans(ctx context.Context, animalPublicID string) ([]DietPlanDTO, error)
+	FindDietPlan(ctx context.Context, publicID string) (DietPlanDTO, error)
+	UpdateDietPlan(ctx context.Context, input DietPlanInput) error
+	DeleteDietPlan(ctx context.Context, publicID string) error
+
+	// CreateFeedingLog fails with ErrNotFound when the animal or the diet
+	// plan does not exist, or the plan belongs to another animal.
+	CreateFeedingLog(ctx context.Context, input FeedingLogInput) error
+	ListFeedingLogs(ctx context.Context, animalPublicID string, from, to time.Time) ([]FeedingLogDTO, error)
+
+	// ListMissedFeedings compares the plans in effect on the given day with
+	// the feedings logged against them that day. A non-nil scope limits it
+	// to animals in those zones.
+	ListMissedFeedings(ctx context.Context, day time.Time, scope []string) ([]MissedFeedingDTO, error)
+}
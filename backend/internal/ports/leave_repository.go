@@ -0,0 +1,81 @@
+package ports
+
+import (
+	"context"
+	"time"
+	"wit-leisure-park/backend/internal/domain/leave"
+)
+
+// LeaveType and LeaveStatus alias the domain types, which own the allowed
+// values and transitions.
+type LeaveType = leave.Type
+type LeaveStatus = leave.Status
+
+type LeaveDTO struct {
+	PublicID          string      `json:"public_id"`
+	ZookeeperPublicID string      `json:"zookeeper_public_id"`
+	Zookeeper         string      `json:"zookeeper"`
+	Type              LeaveType   `json:"type"`
+	StartsOn          time.Time   `json:"starts_on"`
+	EndsOn            time.Time   `json:"ends_on"`
+	Reason            *string     `json:"reason,omitempty"`
+	Status            LeaveStatus `json:"status"`
+	DecidedByPublicID *string     `json:"decided_by_public_id,omitempty"`
+	DecidedAt         *time.Time  `json:"decided_at,omitempty"`
+	DecisionNote      *string     `json:"decision_note,omitempty"`
+	CreatedAt         time.Time   `json:"created_at"`
+}
+
+type LeaveInput struct {
+	PublicID          string
+	ZookeeperPublicID string
+	Type              LeaveType
+	StartsOn          time.Time
+	EndsOn            time.Time
+	Reason            *string
+}
+
+// LeaveQuery lists leave that shares any day with [From, To], both
+// inclusive. Zero dates leave that side open.
+type LeaveQuery struct {
+	ZookeeperPublicID *string
+	// ManagerPublicID limits the list to the manager's team
+	ManagerPublicID *string
+	Statuses        []LeaveStatus
+	From            time.Time
+	To              time.Time
+}
+
+type LeaveDecision struct {
+	PublicID      string
+	From          LeaveStatus
+	To            LeaveStatus
+	ActorPublicID string
+	Note          *string
+}
+
+// AvailabilityDTO tells whether a zookeeper can take work on a day. The
+// leave fields describe the approved leave that makes them unavailable.
+type AvailabilityDTO struct {
+	ZookeeperPublicID string     `json:"zookeeper_public_id"`
+	Zookeeper         string     `json:"zookeeper"`
+	Name              string     `json:"name"`
+	Available         bool       `json:"available"`
+	OnShift           bool       `json:"on_shift"`
+	LeavePublicID     *string    `json:"leave_public_id,omitempty"`
+	LeaveType         *LeaveType `json:"leave_type,omitempty"`
+	LeaveEndsOn       *time.Time `json:"leave_ends_on,omitempty"`
+}
+
+type LeaveRepository interface {
+	// Create returns ErrNotFound when the zookeeper does not exist.
+	Create(ctx context.Context, input LeaveInput) error
+	FindByID(ctx context.Context, publicID string) (LeaveDTO, error)
+	List(ctx context.Context, query LeaveQuery) ([]LeaveDTO, error)
+	// Decide applies the change only if the request still has the From
+	// status. It returns ErrConflict when it was changed concurrently.
+	Decide(ctx context.Context, decision LeaveDecision) error
+	// Availability lists the zookeepers, of one team when managerPublicID
+	// is set, with their approved leave on the day.
+	Availability(ctx context.Context, day time.Time, managerPublicID *string) ([]AvailabilityDTO, error)
+}
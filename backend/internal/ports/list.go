@@ -0,0 +1,135 @@
+package ports
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+// ErrInvalidCursor is returned when a cursor was not issued by a previous
+// page.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrInvalidSort is returned when a list is asked to sort by a field it
+// does not support.
+var ErrInvalidSort = errors.New("invalid sort field")
+
+// ListParams are the paging and sorting options shared by every list. Sort
+// is a field name, optionally prefixed with "-" for descending order; each
+// repository whitelists the fields it accepts.
+type ListParams struct {
+	Limit  int
+	Offset int
+	Sort   string
+}
+
+// Page is the envelope returned by list endpoints. NextCursor is set while
+// more rows follow; passing it back as ?cursor= returns the next page with
+// the same filters and sort.
+type Page[T any] struct {
+	Items      []T     `json:"items"`
+	Total      int     `json:"total"`
+	Limit      int     `json:"limit"`
+	Offset     int     `json:"offset"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+func NewPage[T any](items []T, total int, p ListParams) Page[T] {
+	page := Page[T]{
+		Items:  items,
+		Total:  total,
+		Limit:  p.Limit,
+		Offset: p.Offset,
+	}
+
+	if next := p.Offset + len(items); len(items) > 0 && next < total {
+		cursor := EncodeCursor(next)
+		page.NextCursor = &cursor
+	}
+
+	return page
+}
+
+// EncodeCursor and DecodeCursor keep cursors opaque to clients so the
+// paging strategy can change without breaking them.
+func EncodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("o:" + strconv.Itoa(offset)))
+}
+
+func DecodeCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(raw) < 3 || string(raw[:2]) != "o:" {
+		return 0, ErrInvalidCursor
+	}
+
+	offset, err := strconv.Atoi(string(raw[2:]))
+	if err != nil || offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return offset, nil
+}
+
+type AnimalListQuery struct {
+	ListParams
+	// Species matches the common or the scientific name
+	Species         *string
+	SpeciesPublicID *string
+	CagePublicID    *string
+	ZonePublicID    *string
+	Search          *string
+	// empty lists the active statuses only
+	Statuses []AnimalStatus
+	// ScopeZonePublicIDs restricts the list to animals in these zones; nil
+	// lists every zone
+	ScopeZonePublicIDs []string
+}
+
+type SpeciesListQuery struct {
+	ListParams
+	ConservationStatus *string
+	DietType           *string
+	// Search matches the common or the scientific name
+	Search *string
+}
+
+type CageListQuery struct {
+	ListParams
+	Location     *string
+	ZonePublicID *string
+	AreaPublicID *string
+	Search       *string
+	// ScopeZonePublicIDs restricts the list to cages in these zones; nil
+	// lists every cage
+	ScopeZonePublicIDs []string
+}
+
+type ZookeeperListQuery struct {
+	ListParams
+	ManagerPublicID *string
+	Search          *string
+}
+
+type ManagerListQuery struct {
+	ListParams
+	Search *string
+}
+
+type TaskListQuery struct {
+	ListParams
+	Status            *TaskStatus
+	ZookeeperPublicID *string
+	AnimalPublicID    *string
+	ZonePublicID      *string
+	DueBefore         *time.Time
+	DueAfter          *time.Time
+	// ScopeZonePublicIDs restricts the list to tasks without an animal or
+	// for animals in these zones; nil lists every zone
+	ScopeZonePublicIDs []string
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type LoginAttemptRepository interface {
+	Record(ctx context.Context, username, ipAddress string, success bool) error
+
+	CountFailuresByIP(ctx context.Context, ipAddress string, since time.Time) (int, error)
+}
@@ -13,7 +13,7 @@ type ManagerRepository interface {
 		name string,
 	) (string, error)
 
-	ListManagers(ctx context.Context) ([]ManagerDTO, error)
+	ListManagers(ctx context.Context, query ManagerListQuery) (Page[ManagerDTO], error)
 
 	FindByPublicID(ctx context.Context, publicID string) (ManagerDTO, error)
 
@@ -0,0 +1,67 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type MeasurementDTO struct {
+	PublicID           string    `json:"public_id"`
+	MeasuredAt         time.Time `json:"measured_at"`
+	WeightKg           *float64  `json:"weight_kg,omitempty"`
+	LengthCm           *float64  `json:"length_cm,omitempty"`
+	BodyConditionScore *float64  `json:"body_condition_score,omitempty"`
+	Notes              *string   `json:"notes,omitempty"`
+	RecordedBy         *string   `json:"recorded_by,omitempty"`
+}
+
+type MeasurementInput struct {
+	PublicID           string
+	AnimalPublicID     string
+	MeasuredAt         time.Time
+	WeightKg           *float64
+	LengthCm           *float64
+	BodyConditionScore *float64
+	Notes              *string
+	RecordedByPublicID string
+}
+
+// WeightSample is one weight measurement with the animal it belongs to,
+// used to evaluate weight alerts across all animals.
+type WeightSample struct {
+	AnimalPublicID  string
+	AnimalName      string
+	SpeciesPublicID string
+	Species         string
+	MeasuredAt      time.Time
+	WeightKg        float64
+}
+
+// WeightThresholdDTO overrides the weight alert defaults for a species.
+// Species is the common name and is only filled when reading.
+type WeightThresholdDTO struct {
+	SpeciesPublicID  string  `json:"species_public_id"`
+	Species          string  `json:"species,omitempty"`
+	MaxChangePercent float64 `json:"max_change_percent"`
+	WindowDays       int     `json:"window_days"`
+}
+
+// MeasurementRepository is append-only: measurements are never updated or
+// deleted, corrections are recorded as new measurements.
+type MeasurementRepository interface {
+	Create(ctx context.Context, input MeasurementInput) error
+
+	// ListByAnimal returns the measurements taken in [from, to), oldest
+	// first, or ErrNotFound when the animal does not exist.
+	ListByAnimal(ctx context.Context, animalPublicID string, from, to time.Time) ([]MeasurementDTO, error)
+
+	// ListWeightsSince returns every weight measured at or after since,
+	// ordered by animal and time. A non-nil scope keeps animals in those
+	// zones.
+	ListWeightsSince(ctx context.Context, since time.Time, scope []string) ([]WeightSample, error)
+
+	ListThresholds(ctx context.Context) ([]WeightThresholdDTO, error)
+	// UpsertThreshold returns ErrNotFound when the species does not exist.
+	UpsertThreshold(ctx context.Context, threshold WeightThresholdDTO) error
+	DeleteThreshold(ctx context.Context, speciesPublicID string) error
+}
@@ -0,0 +1,130 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type VetVisitDTO struct {
+	PublicID   string    `json:"public_id"`
+	VisitedAt  time.Time `json:"visited_at"`
+	VetName    string    `json:"vet_name"`
+	Reason     *string   `json:"reason,omitempty"`
+	Notes      *string   `json:"notes,omitempty"`
+	RecordedBy *string   `json:"recorded_by,omitempty"`
+}
+
+type DiagnosisDTO struct {
+	PublicID      string     `json:"public_id"`
+	VisitPublicID *string    `json:"visit_public_id,omitempty"`
+	Condition     string     `json:"condition"`
+	Severity      string     `json:"severity"`
+	Notes         *string    `json:"notes,omitempty"`
+	DiagnosedOn   time.Time  `json:"diagnosed_on"`
+	ResolvedOn    *time.Time `json:"resolved_on,omitempty"`
+	RecordedBy    *string    `json:"recorded_by,omitempty"`
+}
+
+type PrescriptionDTO struct {
+	PublicID          string     `json:"public_id"`
+	VisitPublicID     *string    `json:"visit_public_id,omitempty"`
+	DiagnosisPublicID *string    `json:"diagnosis_public_id,omitempty"`
+	Medication        string     `json:"medication"`
+	Dosage            string     `json:"dosage"`
+	Route             *string    `json:"route,omitempty"`
+	IntervalHours     int        `json:"interval_hours"`
+	StartsOn          time.Time  `json:"starts_on"`
+	EndsOn            *time.Time `json:"ends_on,omitempty"`
+	Instructions      *string    `json:"instructions,omitempty"`
+	RecordedBy        *string    `json:"recorded_by,omitempty"`
+}
+
+type VaccinationDTO struct {
+	PublicID       string     `json:"public_id"`
+	VisitPublicID  *string    `json:"visit_public_id,omitempty"`
+	Vaccine        string     `json:"vaccine"`
+	AdministeredOn time.Time  `json:"administered_on"`
+	DueOn          *time.Time `json:"due_on,omitempty"`
+	BatchNumber    *string    `json:"batch_number,omitempty"`
+	Notes          *string    `json:"notes,omitempty"`
+	RecordedBy     *string    `json:"recorded_by,omitempty"`
+}
+
+// VaccinationDueDTO is a vaccination whose next dose falls due and has not
+// been given yet.
+type VaccinationDueDTO struct {
+	AnimalPublicID string    `json:"animal_public_id"`
+	AnimalName     string    `json:"animal_name"`
+	Vaccine        string    `json:"vaccine"`
+	AdministeredOn time.Time `json:"administered_on"`
+	DueOn          time.Time `json:"due_on"`
+}
+
+type VetVisitInput struct {
+	PublicID           string
+	AnimalPublicID     string
+	VisitedAt          time.Time
+	VetName            string
+	Reason             *string
+	Notes              *string
+	RecordedByPublicID string
+}
+
+type DiagnosisInput struct {
+	PublicID           string
+	AnimalPublicID     string
+	VisitPublicID      *string
+	Condition          string
+	Severity           string
+	Notes              *string
+	DiagnosedOn        time.Time
+	RecordedByPublicID string
+}
+
+type PrescriptionInput struct {
+	PublicID           string
+	AnimalPublicID     string
+	VisitPublicID      *string
+	DiagnosisPublicID  *string
+	Medication         string
+	Dosage             string
+	Route              *string
+	IntervalHours      int
+	StartsOn           time.Time
+	EndsOn             *time.Time
+	Instructions       *string
+	RecordedByPublicID string
+}
+
+type VaccinationInput struct {
+	PublicID           string
+	AnimalPublicID     string
+	VisitPublicID      *string
+	Vaccine            string
+	AdministeredOn     time.Time
+	DueOn              *time.Time
+	BatchNumber        *string
+	Notes              *string
+	RecordedByPublicID string
+}
+
+// MedicalRepository stores the medical history of animals. Every method
+// taking an animal returns ErrNotFound when the animal does not exist, and
+// referenced visits or diagnoses must belong to the same animal.
+type MedicalRepository interface {
+	CreateVisit(ctx context.Context, input VetVisitInput) error
+	CreateDiagnosis(ctx context.Context, input DiagnosisInput) error
+	ResolveDiagnosis(ctx context.Context, animalPublicID, diagnosisPublicID string, resolvedOn time.Time) error
+	CreatePrescription(ctx context.Context, input PrescriptionInput) error
+	CreateVaccination(ctx context.Context, input VaccinationInput) error
+
+	ListVisits(ctx context.Context, animalPublicID string) ([]VetVisitDTO, error)
+	ListDiagnoses(ctx context.Context, animalPublicID string) ([]DiagnosisDTO, error)
+	ListPrescriptions(ctx context.Context, animalPublicID string) ([]PrescriptionDTO, error)
+	ListVaccinations(ctx context.Context, animalPublicID string) ([]VaccinationDTO, error)
+
+	// ListVaccinationsDue returns, per animal and vaccine, the latest
+	// vaccination when its due date is on or before the given date. A
+	// non-nil scope limits it to animals in those zones.
+	ListVaccinationsDue(ctx context.Context, before time.Time, scope []string) ([]VaccinationDueDTO, error)
+}
@@ -0,0 +1,29 @@
+package ports
+
+import "context"
+
+type MFAConfig struct {
+	Secret       string
+	Enabled      bool
+	LastUsedStep *int64
+}
+
+type MFARepository interface {
+	FindByUser(ctx context.Context, userPublicID string) (MFAConfig, error)
+
+	// SaveSecret starts a new, not yet enabled enrollment.
+	SaveSecret(ctx context.Context, userPublicID, secret string) error
+
+	// Enable activates the pending enrollment and replaces the recovery codes.
+	Enable(ctx context.Context, userPublicID string, recoveryCodeHashes []string) error
+
+	Disable(ctx context.Context, userPublicID string) error
+
+	// MarkStepUsed records the TOTP step of an accepted code. It returns
+	// false when the step, or a later one, was already used.
+	MarkStepUsed(ctx context.Context, userPublicID string, step int64) (bool, error)
+
+	// ConsumeRecoveryCode marks an unused recovery code as used. It returns
+	// false when no such code exists.
+	ConsumeRecoveryCode(ctx context.Context, userPublicID, codeHash string) (bool, error)
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"wit-leisure-park/backend/internal/domain"
+)
+
+type PermissionDTO struct {
+	Code        domain.Permission `json:"code"`
+	Description string            `json:"description"`
+}
+
+type RolePermissionsDTO struct {
+	Role        domain.Role         `json:"role"`
+	Permissions []domain.Permission `json:"permissions"`
+}
+
+type PermissionRepository interface {
+	ListPermissions(ctx context.Context) ([]PermissionDTO, error)
+
+	ListRolePermissions(ctx context.Context) ([]RolePermissionsDTO, error)
+
+	ReplaceRolePermissions(
+		ctx context.Context,
+		role domain.Role,
+		permissions []domain.Permission,
+	) error
+}
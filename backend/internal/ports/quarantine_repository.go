@@ -0,0 +1,101 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type QuarantineStatus string
+
+const (
+	QuarantineOpen    QuarantineStatus = "OPEN"
+	QuarantineCleared QuarantineStatus = "CLEARED"
+	// QuarantineClosed ends a quarantine without clearance, e.g. when the
+	// animal dies or leaves the park.
+	QuarantineClosed QuarantineStatus = "CLOSED"
+)
+
+type QuarantineCheckDTO struct {
+	PublicID string     `json:"public_id"`
+	Name     string     `json:"name"`
+	PassedAt *time.Time `json:"passed_at,omitempty"`
+	PassedBy *string    `json:"passed_by,omitempty"`
+	Notes    *string    `json:"notes,omitempty"`
+}
+
+type QuarantineDTO struct {
+	PublicID          string               `json:"public_id"`
+	AnimalPublicID    string               `json:"animal_public_id"`
+	AnimalName        string               `json:"animal_name"`
+	CagePublicID      string               `json:"cage_public_id"`
+	CageCode          string               `json:"cage_code"`
+	Reason            string               `json:"reason"`
+	VetName           string               `json:"vet_name"`
+	ManagerPublicID   string               `json:"manager_public_id"`
+	ZookeeperPublicID string               `json:"zookeeper_public_id"`
+	StartedOn         time.Time            `json:"started_on"`
+	ExpectedEndOn     time.Time            `json:"expected_end_on"`
+	Status            QuarantineStatus     `json:"status"`
+	EndedAt           *time.Time           `json:"ended_at,omitempty"`
+	EndedBy           *string              `json:"ended_by,omitempty"`
+	OutcomeNotes      *string              `json:"outcome_notes,omitempty"`
+	GeneratedUntil    *time.Time           `json:"generated_until,omitempty"`
+	Checks            []QuarantineCheckDTO `json:"checks"`
+}
+
+type QuarantineInput struct {
+	PublicID          string
+	AnimalPublicID    string
+	CagePublicID      string
+	Reason            string
+	VetName           string
+	ManagerPublicID   string
+	ZookeeperPublicID string
+	StartedOn         time.Time
+	ExpectedEndOn     time.Time
+	// Checks are the required clearance checks, with new public ids.
+	Checks []QuarantineCheckDTO
+}
+
+type QuarantineUpdateInput struct {
+	PublicID          string
+	VetName           string
+	ZookeeperPublicID string
+	ExpectedEndOn     time.Time
+}
+
+type QuarantineRepository interface {
+	// Open moves the animal into the quarantine cage, sets its status to
+	// QUARANTINED and records the quarantine with its checks, all in one
+	// transaction. The cage capacity is enforced. Returns ErrConflict when
+	// the animal is already in an open quarantine or not in the From
+	// status anymore.
+	Open(ctx context.Context, input QuarantineInput, from AnimalStatus, guard PlacementGuard) error
+
+	// List returns quarantines, newest first, optionally filtered by status.
+	// A non-nil scope limits it to quarantine cages in those zones.
+	List(ctx context.Context, status *QuarantineStatus, scope []string) ([]QuarantineDTO, error)
+
+	// ListByAnimal returns ErrNotFound when the animal does not exist.
+	ListByAnimal(ctx context.Context, animalPublicID string) ([]QuarantineDTO, error)
+
+	FindByID(ctx context.Context, publicID string) (QuarantineDTO, error)
+
+	// FindOpenByAnimal returns ErrNotFound when the animal is not in an open
+	// quarantine.
+	FindOpenByAnimal(ctx context.Context, animalPublicID string) (QuarantineDTO, error)
+
+	Update(ctx context.Context, input QuarantineUpdateInput) error
+
+	PassCheck(ctx context.Context, quarantinePublicID, checkPublicID, actorPublicID string, notes *string) error
+
+	// Clear ends an open quarantine whose checks have all passed and makes
+	// the animal ACTIVE again. The animal stays in the quarantine cage until
+	// it is transferred.
+	Clear(ctx context.Context, publicID, actorPublicID string, notes *string) error
+
+	// ListOpen returns every open quarantine, for the scheduler.
+	ListOpen(ctx context.Context) ([]QuarantineDTO, error)
+
+	MarkGenerated(ctx context.Context, publicID string, until time.Time) error
+}
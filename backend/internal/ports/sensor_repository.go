@@ -0,0 +1,151 @@
+package ports
+
+import (
+	"context"
+	"time"
+	"wit-leisure-park/backend/internal/domain/environment"
+)
+
+// EnvironmentMetric aliases the domain type, which knows the plausible
+// range of each metric.
+type EnvironmentMetric = environment.Metric
+
+const (
+	MetricTemperature = environment.MetricTemperature
+	MetricHumidity    = environment.MetricHumidity
+)
+
+type AlertKind = environment.Breach
+
+const (
+	AlertLow  = environment.BreachLow
+	AlertHigh = environment.BreachHigh
+)
+
+type SensorDTO struct {
+	PublicID     string     `json:"public_id"`
+	CagePublicID string     `json:"cage_public_id"`
+	CageCode     string     `json:"cage_code"`
+	Name         string     `json:"name"`
+	SerialNumber *string    `json:"serial_number,omitempty"`
+	Active       bool       `json:"active"`
+	LastSeenAt   *time.Time `json:"last_seen_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// SensorInput carries the editable sensor fields. DeviceKeyHash is only
+// used on create.
+type SensorInput struct {
+	PublicID      string
+	CagePublicID  string
+	Name          string
+	SerialNumber  *string
+	Active        bool
+	DeviceKeyHash string
+}
+
+type ReadingInput struct {
+	Metric     EnvironmentMetric
+	Value      float64
+	RecordedAt time.Time
+}
+
+// EnvironmentThresholdDTO is the range the readings of one metric should
+// stay in for a cage.
+type EnvironmentThresholdDTO struct {
+	Metric   EnvironmentMetric `json:"metric"`
+	MinValue *float64          `json:"min_value"`
+	MaxValue *float64          `json:"max_value"`
+}
+
+func (t EnvironmentThresholdDTO) Threshold() environment.Threshold {
+	return environment.Threshold{Min: t.MinValue, Max: t.MaxValue}
+}
+
+// EnvironmentAggregateDTO summarises the readings of one metric in one
+// hour.
+type EnvironmentAggregateDTO struct {
+	Metric  EnvironmentMetric `json:"metric"`
+	Hour    time.Time         `json:"hour"`
+	Min     float64           `json:"min"`
+	Max     float64           `json:"max"`
+	Avg     float64           `json:"avg"`
+	Samples int               `json:"samples"`
+}
+
+type EnvironmentQuery struct {
+	CagePublicID   string
+	SensorPublicID *string
+	Metric         *EnvironmentMetric
+	From           time.Time
+	To             time.Time
+}
+
+type EnvironmentAlertDTO struct {
+	PublicID       string            `json:"public_id"`
+	CagePublicID   string            `json:"cage_public_id"`
+	CageCode       string            `json:"cage_code"`
+	SensorPublicID *string           `json:"sensor_public_id,omitempty"`
+	Metric         EnvironmentMetric `json:"metric"`
+	Kind           AlertKind         `json:"kind"`
+	MinValue       *float64          `json:"min_value"`
+	MaxValue       *float64          `json:"max_value"`
+	StartedAt      time.Time         `json:"started_at"`
+	LastReadingAt  time.Time         `json:"last_reading_at"`
+	LastValue      float64           `json:"last_value"`
+	PeakValue      float64           `json:"peak_value"`
+	ResolvedAt     *time.Time        `json:"resolved_at,omitempty"`
+	AcknowledgedAt *time.Time        `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy *string           `json:"acknowledged_by,omitempty"`
+}
+
+type EnvironmentAlertQuery struct {
+	CagePublicID *string
+	// nil lists every alert, true only unresolved ones
+	Open *bool
+	// ScopeZonePublicIDs restricts the list to cages in these zones; nil
+	// lists every cage
+	ScopeZonePublicIDs []string
+}
+
+type SensorRepository interface {
+	// Create returns ErrNotFound for a missing cage and ErrDuplicate when
+	// the serial number is already registered.
+	Create(ctx context.Context, input SensorInput) error
+	// List returns the sensors of one cage, or of every cage in the scope
+	// zones when cagePublicID is nil; a nil scope lists the whole park.
+	List(ctx context.Context, cagePublicID *string, scope []string) ([]SensorDTO, error)
+	FindByID(ctx context.Context, publicID string) (SensorDTO, error)
+	Update(ctx context.Context, input SensorInput) error
+	RotateKey(ctx context.Context, publicID, deviceKeyHash string) error
+	// Delete returns ErrInUse when the sensor has readings; deactivate it
+	// instead.
+	Delete(ctx context.Context, publicID string) error
+
+	FindByKeyHash(ctx context.Context, deviceKeyHash string) (SensorDTO, error)
+
+	// SaveReadings stores the readings against the sensor's current cage,
+	// skipping those already stored, and returns the new ones oldest first.
+	SaveReadings(ctx context.Context, sensorPublicID string, readings []ReadingInput) ([]ReadingInput, error)
+
+	HourlyAggregates(ctx context.Context, query EnvironmentQuery) ([]EnvironmentAggregateDTO, error)
+
+	ListThresholds(ctx context.Context, cagePublicID string) ([]EnvironmentThresholdDTO, error)
+	// FindThreshold returns ErrNotFound when the cage has no threshold for
+	// the metric.
+	FindThreshold(ctx context.Context, cagePublicID string, metric EnvironmentMetric) (EnvironmentThresholdDTO, error)
+	UpsertThreshold(ctx context.Context, cagePublicID string, threshold EnvironmentThresholdDTO) error
+	// DeleteThreshold also resolves the open alert of the metric.
+	DeleteThreshold(ctx context.Context, cagePublicID string, metric EnvironmentMetric) error
+
+	// LatestAlert returns the most recent alert of the cage and metric,
+	// open or resolved, or ErrNotFound.
+	LatestAlert(ctx context.Context, cagePublicID string, metric EnvironmentMetric) (EnvironmentAlertDTO, error)
+	// OpenAlert returns ErrDuplicate when the cage already has an open
+	// alert for the metric.
+	OpenAlert(ctx context.Context, alert EnvironmentAlertDTO) error
+	UpdateAlert(ctx context.Context, alert EnvironmentAlertDTO) error
+	ListAlerts(ctx context.Context, query EnvironmentAlertQuery) ([]EnvironmentAlertDTO, error)
+	FindAlert(ctx context.Context, publicID string) (EnvironmentAlertDTO, error)
+	AcknowledgeAlert(ctx context.Context, publicID, userPublicID string) error
+}
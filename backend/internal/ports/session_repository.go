@@ -0,0 +1,45 @@
+package ports
+
+import (
+	"context"
+	"time"
+	"wit-leisure-park/backend/internal/domain"
+)
+
+type SessionCreateInput struct {
+	PublicID         string
+	UserPublicID     string
+	RefreshTokenHash string
+	ExpiresAt        time.Time
+}
+
+type RefreshTokenRecord struct {
+	SessionPublicID    string
+	UserPublicID       string
+	Role               domain.Role
+	MustChangePassword bool
+	ExpiresAt          time.Time
+	UsedAt             *time.Time
+	SessionRevokedAt   *time.Time
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, input SessionCreateInput) error
+
+	FindRefreshToken(ctx context.Context, tokenHash string) (RefreshTokenRecord, error)
+
+	// Rotate marks the old token as used and stores its replacement. It
+	// returns false when the old token had already been used.
+	Rotate(
+		ctx context.Context,
+		oldTokenHash string,
+		newTokenHash string,
+		expiresAt time.Time,
+	) (bool, error)
+
+	Revoke(ctx context.Context, sessionPublicID string) error
+
+	RevokeAllForUser(ctx context.Context, userPublicID string) error
+
+	IsActive(ctx context.Context, sessionPublicID string) (bool, error)
+}
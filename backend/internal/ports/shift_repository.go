@@ -0,0 +1,61 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type ShiftDTO struct {
+	PublicID          string    `json:"public_id"`
+	ZookeeperPublicID string    `json:"zookeeper_public_id"`
+	Zookeeper         string    `json:"zookeeper"`
+	ZonePublicID      *string   `json:"zone_public_id,omitempty"`
+	Zone              *string   `json:"zone,omitempty"`
+	CagePublicID      *string   `json:"cage_public_id,omitempty"`
+	Cage              *string   `json:"cage,omitempty"`
+	StartsAt          time.Time `json:"starts_at"`
+	EndsAt            time.Time `json:"ends_at"`
+	Notes             *string   `json:"notes,omitempty"`
+}
+
+// ShiftInput covers either a zone or a cage, never both.
+type ShiftInput struct {
+	PublicID          string
+	ZookeeperPublicID string
+	ZonePublicID      *string
+	CagePublicID      *string
+	StartsAt          time.Time
+	EndsAt            time.Time
+	Notes             *string
+	ActorPublicID     string
+}
+
+// ShiftQuery lists the shifts that share any time with [From, To).
+type ShiftQuery struct {
+	From              time.Time
+	To                time.Time
+	ZookeeperPublicID *string
+	ZonePublicID      *string
+	// ManagerPublicID limits the shifts to the manager's team
+	ManagerPublicID *string
+}
+
+// ShiftGuard checks a shift against the zookeeper's other shifts that
+// share any time with it once widened by Margin on both sides.
+// Repositories run Check with the zookeeper locked, so two shifts for the
+// same zookeeper are never checked at the same time.
+type ShiftGuard struct {
+	Margin time.Duration
+	Check  func(others []ShiftDTO) error
+}
+
+type ShiftRepository interface {
+	// Create and Update return ErrNotFound when the zookeeper, zone or
+	// cage does not exist, and the guard's error when it refuses the
+	// shift.
+	Create(ctx context.Context, input ShiftInput, guard ShiftGuard) error
+	Update(ctx context.Context, input ShiftInput, guard ShiftGuard) error
+	FindByID(ctx context.Context, publicID string) (ShiftDTO, error)
+	List(ctx context.Context, query ShiftQuery) ([]ShiftDTO, error)
+	Delete(ctx context.Context, publicID string) error
+}
@@ -0,0 +1,104 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type SkillDTO struct {
+	PublicID    string  `json:"public_id"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+type SkillInput struct {
+	PublicID    string
+	Code        string
+	Name        string
+	Description *string
+}
+
+type CertificationDTO struct {
+	PublicID          string     `json:"public_id"`
+	ZookeeperPublicID string     `json:"zookeeper_public_id"`
+	Zookeeper         string     `json:"zookeeper"`
+	SkillPublicID     string     `json:"skill_public_id"`
+	SkillCode         string     `json:"skill_code"`
+	SkillName         string     `json:"skill_name"`
+	CertifiedOn       time.Time  `json:"certified_on"`
+	ExpiresOn         *time.Time `json:"expires_on,omitempty"`
+	Reference         *string    `json:"reference,omitempty"`
+	Notes             *string    `json:"notes,omitempty"`
+}
+
+type CertificationInput struct {
+	PublicID          string
+	ZookeeperPublicID string
+	SkillPublicID     string
+	CertifiedOn       time.Time
+	ExpiresOn         *time.Time
+	Reference         *string
+	Notes             *string
+	ActorPublicID     string
+}
+
+// CertificationQuery filters certifications. When ExpiresFrom or ExpiresTo
+// is set, only certifications with an expiry date in that inclusive range
+// are listed.
+type CertificationQuery struct {
+	ZookeeperPublicID *string
+	SkillPublicID     *string
+	// ManagerPublicID limits the list to the manager's team
+	ManagerPublicID *string
+	ExpiresFrom     time.Time
+	ExpiresTo       time.Time
+}
+
+// MissingSkill is a required skill the zookeeper has no valid certification
+// for. ExpiresOn is set when the certification they hold has expired.
+type MissingSkill struct {
+	SkillPublicID string     `json:"skill_public_id"`
+	Code          string     `json:"code"`
+	Name          string     `json:"name"`
+	ExpiresOn     *time.Time `json:"expires_on,omitempty"`
+}
+
+type SkillRepository interface {
+	// CreateSkill returns ErrDuplicate when the code is taken.
+	CreateSkill(ctx context.Context, input SkillInput) error
+	ListSkills(ctx context.Context) ([]SkillDTO, error)
+	FindSkill(ctx context.Context, publicID string) (SkillDTO, error)
+	UpdateSkill(ctx context.Context, input SkillInput) error
+	// DeleteSkill returns ErrInUse while certifications or requirements
+	// still reference the skill.
+	DeleteSkill(ctx context.Context, publicID string) error
+
+	SpeciesSkills(ctx context.Context, speciesPublicID string) ([]SkillDTO, error)
+	// SetSpeciesSkills replaces the skills required to work with animals of
+	// the species.
+	SetSpeciesSkills(ctx context.Context, speciesPublicID string, skillPublicIDs []string) error
+	TemplateSkills(ctx context.Context, templatePublicID string) ([]SkillDTO, error)
+	// SetTemplateSkills replaces the skills required for the tasks of the
+	// template.
+	SetTemplateSkills(ctx context.Context, templatePublicID string, skillPublicIDs []string) error
+
+	// CreateCertification returns ErrDuplicate when the zookeeper already
+	// holds a certification for the skill.
+	CreateCertification(ctx context.Context, input CertificationInput) error
+	FindCertification(ctx context.Context, publicID string) (CertificationDTO, error)
+	ListCertifications(ctx context.Context, query CertificationQuery) ([]CertificationDTO, error)
+	// UpdateCertification changes the dates, reference and notes; the
+	// zookeeper and skill are kept.
+	UpdateCertification(ctx context.Context, input CertificationInput) error
+	DeleteCertification(ctx context.Context, publicID string) error
+
+	// MissingSkills returns the skills required by the animal's species and
+	// the template that the zookeeper holds no certification for on the day.
+	MissingSkills(
+		ctx context.Context,
+		zookeeperPublicID string,
+		animalPublicID, templatePublicID *string,
+		day time.Time,
+	) ([]MissingSkill, error)
+}
@@ -0,0 +1,50 @@
+package ports
+
+import "context"
+
+type SpeciesDTO struct {
+	PublicID           string   `json:"public_id"`
+	CommonName         string   `json:"common_name"`
+	ScientificName     *string  `json:"scientific_name"`
+	ConservationStatus *string  `json:"conservation_status"`
+	DietType           *string  `json:"diet_type"`
+	MinTemperatureC    *float64 `json:"min_temperature_c"`
+	MaxTemperatureC    *float64 `json:"max_temperature_c"`
+	FeedingsPerDay     *int     `json:"feedings_per_day"`
+	CareNotes          *string  `json:"care_notes"`
+	AnimalCount        int      `json:"animal_count"`
+}
+
+type SpeciesInput struct {
+	PublicID           string
+	CommonName         string
+	ScientificName     *string
+	ConservationStatus *string
+	DietType           *string
+	MinTemperatureC    *float64
+	MaxTemperatureC    *float64
+	FeedingsPerDay     *int
+	CareNotes          *string
+}
+
+type SpeciesRepository interface {
+	// NameExists reports whether another species, other than
+	// excludePublicID, already uses name as its common or scientific name.
+	NameExists(ctx context.Context, name, excludePublicID string) (bool, error)
+
+	Create(ctx context.Context, input SpeciesInput) error
+
+	List(ctx context.Context, query SpeciesListQuery) (Page[SpeciesDTO], error)
+
+	FindByID(ctx context.Context, publicID string) (SpeciesDTO, error)
+
+	Update(ctx context.Context, input SpeciesInput) error
+
+	// Delete returns ErrInUse while animals still reference the species.
+	Delete(ctx context.Context, publicID string) error
+
+	// Merge moves every animal, threshold, cage limit and required skill of
+	// the duplicate onto the target species, fills the target's empty fields from the
+	// duplicate and deletes it.
+	Merge(ctx context.Context, targetPublicID, duplicatePublicID string) error
+}
@@ -3,14 +3,16 @@ package ports
 import (
 	"context"
 	"time"
+	"wit-leisure-park/backend/internal/domain/task"
 )
 
-type TaskStatus string
+// TaskStatus aliases the domain type, which owns the allowed transitions.
+type TaskStatus = task.Status
 
 const (
-	TaskPending    TaskStatus = "PENDING"
-	TaskInProgress TaskStatus = "IN_PROGRESS"
-	TaskDone       TaskStatus = "DONE"
+	TaskPending    = task.StatusPending
+	TaskInProgress = task.StatusInProgress
+	TaskDone       = task.StatusDone
 )
 
 type TaskDTO struct {
@@ -21,6 +23,9 @@ type TaskDTO struct {
 	DueDate     *time.Time `json:"due_date,omitempty"`
 	Zookeeper   string     `json:"zookeeper"`
 	Animal      *string    `json:"animal,omitempty"`
+	// AnimalPublicID and TemplatePublicID decide the skills the task needs.
+	AnimalPublicID   *string `json:"animal_public_id,omitempty"`
+	TemplatePublicID *string `json:"template_public_id,omitempty"`
 }
 
 type TaskCreateInput struct {
@@ -31,12 +36,57 @@ type TaskCreateInput struct {
 	ZookeeperPublicID string
 	AnimalPublicID    *string
 	DueDate           *time.Time
+	TemplatePublicID  *string
+	// QuarantinePublicID is set for the daily check tasks of a quarantine.
+	QuarantinePublicID *string
+}
+
+// TaskOwnership identifies who may act on a task: the manager who created
+// it, the assigned zookeeper and the manager of that zookeeper's team. The
+// current status is included so callers can validate transitions.
+type TaskOwnership struct {
+	ManagerPublicID     string
+	ZookeeperPublicID   string
+	TeamManagerPublicID *string
+	Status              TaskStatus
+}
+
+type TaskStatusChange struct {
+	PublicID      string
+	From          TaskStatus
+	To            TaskStatus
+	ActorPublicID string
+	Note          *string
+}
+
+type TaskStatusHistoryDTO struct {
+	FromStatus    *TaskStatus `json:"from_status"`
+	ToStatus      TaskStatus  `json:"to_status"`
+	ActorPublicID *string     `json:"actor_public_id,omitempty"`
+	ActorUsername *string     `json:"actor_username,omitempty"`
+	Note          *string     `json:"note,omitempty"`
+	ChangedAt     time.Time   `json:"changed_at"`
 }
 
 type TaskRepository interface {
 	Create(ctx context.Context, input TaskCreateInput) (string, error)
-	ListByManager(ctx context.Context, managerPublicID string) ([]TaskDTO, error)
-	ListByZookeeper(ctx context.Context, zookeeperPublicID string) ([]TaskDTO, error)
-	UpdateStatus(ctx context.Context, publicID string, status TaskStatus) error
+	ListByManager(ctx context.Context, managerPublicID string, query TaskListQuery) (Page[TaskDTO], error)
+	ListByZookeeper(ctx context.Context, zookeeperPublicID string, query TaskListQuery) (Page[TaskDTO], error)
+	// UpdateStatus applies the change only if the task still has the From
+	// status and records it in the status history. It returns ErrConflict
+	// when the status was changed concurrently.
+	UpdateStatus(ctx context.Context, change TaskStatusChange) error
 	Delete(ctx context.Context, publicID string) error
+	FindOwnership(ctx context.Context, publicID string) (TaskOwnership, error)
+	ListStatusHistory(ctx context.Context, publicID string) ([]TaskStatusHistoryDTO, error)
+	// ListOpen returns the zookeeper's tasks that are not done and are due
+	// between the two dates, both inclusive.
+	ListOpen(ctx context.Context, zookeeperPublicID string, from, to time.Time) ([]TaskDTO, error)
+	// Reassign hands the open tasks among publicIDs to another zookeeper and
+	// returns how many moved. Only tasks of the manager's team move, and only
+	// to a zookeeper of that team; ErrNotFound is returned for anyone else.
+	Reassign(ctx context.Context, publicIDs []string, zookeeperPublicID, managerPublicID string) (int, error)
+	// OpenCounts returns how many tasks that are not done each of the
+	// zookeepers has. Zookeepers without open tasks are missing from the map.
+	OpenCounts(ctx context.Context, zookeeperPublicIDs []string) (map[string]int, error)
 }
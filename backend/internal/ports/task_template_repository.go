@@ -0,0 +1,56 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type TaskTemplateDTO struct {
+	PublicID          string     `json:"public_id"`
+	Title             string     `json:"title"`
+	Description       *string    `json:"description,omitempty"`
+	ManagerPublicID   string     `json:"manager_public_id"`
+	ZookeeperPublicID string     `json:"zookeeper_public_id"`
+	AnimalPublicID    *string    `json:"animal_public_id,omitempty"`
+	Recurrence        string     `json:"recurrence"`
+	StartsOn          time.Time  `json:"starts_on"`
+	EndsOn            *time.Time `json:"ends_on,omitempty"`
+	LeadDays          int        `json:"lead_days"`
+	Active            bool       `json:"active"`
+	GeneratedUntil    *time.Time `json:"generated_until,omitempty"`
+}
+
+type TaskTemplateInput struct {
+	PublicID          string
+	Title             string
+	Description       *string
+	ManagerPublicID   string
+	ZookeeperPublicID string
+	AnimalPublicID    *string
+	Recurrence        string
+	StartsOn          time.Time
+	EndsOn            *time.Time
+	LeadDays          int
+	Active            bool
+}
+
+type TaskTemplateRepository interface {
+	Create(ctx context.Context, input TaskTemplateInput) (string, error)
+
+	// ListByManager returns the manager's templates. A non-nil scope keeps
+	// those without an animal or for animals in these zones.
+	ListByManager(ctx context.Context, managerPublicID string, scope []string) ([]TaskTemplateDTO, error)
+
+	// ListActive returns every active template, for the scheduler.
+	ListActive(ctx context.Context) ([]TaskTemplateDTO, error)
+
+	FindByID(ctx context.Context, publicID string) (TaskTemplateDTO, error)
+
+	// Update replaces the editable fields; PublicID and ManagerPublicID
+	// identify the template and are not changed.
+	Update(ctx context.Context, input TaskTemplateInput) error
+
+	Delete(ctx context.Context, publicID string) error
+
+	MarkGenerated(ctx context.Context, publicID string, until time.Time) error
+}
@@ -2,9 +2,69 @@ package ports
 
 import (
 	"context"
+	"time"
 	"wit-leisure-park/backend/internal/domain"
 )
 
+type PasswordResetInput struct {
+	UserPublicID      string
+	CreatedByPublicID string
+	TokenHash         string
+	ExpiresAt         time.Time
+	ForceChange       bool
+}
+
+type UserLockState struct {
+	PublicID         string     `json:"public_id"`
+	Username         string     `json:"username"`
+	Role             string     `json:"role"`
+	FailedLoginCount int        `json:"failed_login_count"`
+	LockoutCount     int        `json:"lockout_count"`
+	LockedUntil      *time.Time `json:"locked_until,omitempty"`
+	Locked           bool       `json:"locked"`
+}
+
 type UserRepository interface {
 	FindByUsername(ctx context.Context, username string) (*domain.User, error)
+
+	FindByPublicID(ctx context.Context, publicID string) (*domain.User, error)
+
+	// UpdatePassword replaces the password hash and sets the forced change flag.
+	UpdatePassword(
+		ctx context.Context,
+		publicID string,
+		passwordHash string,
+		mustChange bool,
+	) error
+
+	// CreatePasswordReset stores a reset token, invalidating any earlier
+	// unused tokens of the same user.
+	CreatePasswordReset(ctx context.Context, input PasswordResetInput) error
+
+	// ConsumePasswordReset marks an unexpired, unused token as used, stores
+	// the new password hash and clears the forced change flag in a single
+	// transaction. It returns the public id of the user the token belonged to.
+	ConsumePasswordReset(
+		ctx context.Context,
+		tokenHash string,
+		passwordHash string,
+	) (string, error)
+
+	// IncrementFailedLogins bumps the consecutive failure counter and
+	// returns the new failure count together with the number of lockouts
+	// the account already had.
+	IncrementFailedLogins(ctx context.Context, publicID string) (int, int, error)
+
+	// Lock locks the account until the given time, counts the lockout and
+	// resets the consecutive failure counter.
+	Lock(ctx context.Context, publicID string, until time.Time) error
+
+	// ResetFailedLogins clears failure and lockout counters after a
+	// successful login or a manager unlock.
+	ResetFailedLogins(ctx context.Context, publicID string) error
+
+	FindLockState(ctx context.Context, publicID string) (UserLockState, error)
+
+	// ListLocked returns the locked zookeepers of the manager's team.
+	ListLocked(ctx context.Context, managerPublicID string) ([]UserLockState, error)
 }
@@ -0,0 +1,65 @@
+package ports
+
+import "context"
+
+type ZoneDTO struct {
+	PublicID        string    `json:"public_id"`
+	Code            string    `json:"code"`
+	Name            string    `json:"name"`
+	Description     *string   `json:"description,omitempty"`
+	ManagerPublicID *string   `json:"manager_public_id"`
+	ManagerName     *string   `json:"manager_name"`
+	AreaCount       int       `json:"area_count"`
+	CageCount       int       `json:"cage_count"`
+	Areas           []AreaDTO `json:"areas,omitempty"`
+}
+
+type ZoneInput struct {
+	PublicID        string
+	Code            string
+	Name            string
+	Description     *string
+	ManagerPublicID *string
+}
+
+type AreaDTO struct {
+	PublicID     string  `json:"public_id"`
+	ZonePublicID string  `json:"zone_public_id"`
+	Name         string  `json:"name"`
+	Description  *string `json:"description,omitempty"`
+	CageCount    int     `json:"cage_count"`
+}
+
+type AreaInput struct {
+	PublicID     string
+	ZonePublicID string
+	Name         string
+	Description  *string
+}
+
+type ZoneRepository interface {
+	// Create and Update return ErrNotFound when the manager does not exist
+	// and ErrDuplicate when the code is taken.
+	Create(ctx context.Context, input ZoneInput) error
+	List(ctx context.Context) ([]ZoneDTO, error)
+	// FindByID returns the zone with its areas.
+	FindByID(ctx context.Context, publicID string) (ZoneDTO, error)
+	Update(ctx context.Context, input ZoneInput) error
+	// Delete returns ErrInUse while the zone still has areas.
+	Delete(ctx context.Context, publicID string) error
+
+	// CreateArea and UpdateArea return ErrDuplicate when the zone already
+	// has an area of that name.
+	CreateArea(ctx context.Context, input AreaInput) error
+	UpdateArea(ctx context.Context, input AreaInput) error
+	// DeleteArea returns ErrInUse while cages are placed in the area.
+	DeleteArea(ctx context.Context, zonePublicID, areaPublicID string) error
+
+	// ManagedBy returns the zones the manager is responsible for.
+	ManagedBy(ctx context.Context, managerPublicID string) ([]string, error)
+	// AreaZone returns the zone of an area, or ErrNotFound.
+	AreaZone(ctx context.Context, areaPublicID string) (string, error)
+	// CageZone returns the zone of a cage, nil when the cage is not placed
+	// in an area, or ErrNotFound.
+	CageZone(ctx context.Context, cagePublicID string) (*string, error)
+}
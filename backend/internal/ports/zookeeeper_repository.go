@@ -14,10 +14,12 @@ type ZookeeperRepository interface {
 		managerPublicID string,
 	) (string, error)
 
-	List(ctx context.Context) ([]ZookeeperDTO, error)
+	List(ctx context.Context, query ZookeeperListQuery) (Page[ZookeeperDTO], error)
 
 	FindByID(ctx context.Context, publicID string) (ZookeeperDTO, error)
 
+	InTeam(ctx context.Context, publicID string, managerPublicID string) (bool, error)
+
 	Update(ctx context.Context, publicID string, name string) error
 
 	Delete(ctx context.Context, publicID string) error
@@ -0,0 +1,33 @@
+CREATE TABLE sessions
+(
+    id         BIGSERIAL PRIMARY KEY,
+    public_id  UUID      NOT NULL UNIQUE,
+    user_id    BIGINT    NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_session_user
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions (user_id);
+
+-- Every refresh rotates the token: the presented row is marked as used and a
+-- new row is inserted for the same session. Presenting a used token again is
+-- treated as theft and revokes the whole session.
+CREATE TABLE refresh_tokens
+(
+    id         BIGSERIAL PRIMARY KEY,
+    session_id BIGINT    NOT NULL,
+    token_hash TEXT      NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_refresh_token_session
+        FOREIGN KEY (session_id)
+            REFERENCES sessions (id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX idx_refresh_tokens_session_id ON refresh_tokens (session_id);
@@ -0,0 +1,24 @@
+ALTER TABLE users
+    ADD COLUMN must_change_password BOOLEAN   NOT NULL DEFAULT FALSE,
+    ADD COLUMN password_changed_at  TIMESTAMP;
+
+CREATE TABLE password_reset_tokens
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT    NOT NULL,
+    token_hash TEXT      NOT NULL UNIQUE,
+    created_by BIGINT,
+    expires_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_password_reset_user
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_password_reset_created_by
+        FOREIGN KEY (created_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
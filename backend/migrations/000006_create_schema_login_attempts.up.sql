@@ -0,0 +1,16 @@
+ALTER TABLE users
+    ADD COLUMN failed_login_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN lockout_count      INT NOT NULL DEFAULT 0,
+    ADD COLUMN locked_until       TIMESTAMP;
+
+CREATE TABLE login_attempts
+(
+    id           BIGSERIAL PRIMARY KEY,
+    username     VARCHAR(50) NOT NULL,
+    ip_address   VARCHAR(64) NOT NULL,
+    success      BOOLEAN     NOT NULL,
+    attempted_at TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_attempts_username ON login_attempts (username, attempted_at);
+CREATE INDEX idx_login_attempts_ip_address ON login_attempts (ip_address, attempted_at);
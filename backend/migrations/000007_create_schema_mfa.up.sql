@@ -0,0 +1,27 @@
+CREATE TABLE user_mfa
+(
+    user_id        BIGINT PRIMARY KEY,
+    secret         TEXT      NOT NULL,
+    enabled_at     TIMESTAMP,
+    last_used_step BIGINT,
+    created_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_user_mfa_user
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE
+);
+
+CREATE TABLE mfa_recovery_codes
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT    NOT NULL,
+    code_hash  TEXT      NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_recovery_code_user
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX idx_mfa_recovery_codes_user_id ON mfa_recovery_codes (user_id);
@@ -0,0 +1,43 @@
+CREATE TABLE permissions
+(
+    code        VARCHAR(64) PRIMARY KEY,
+    description TEXT        NOT NULL
+);
+
+CREATE TABLE role_permissions
+(
+    role            user_role   NOT NULL,
+    permission_code VARCHAR(64) NOT NULL,
+    PRIMARY KEY (role, permission_code),
+    CONSTRAINT fk_role_permission_permission
+        FOREIGN KEY (permission_code)
+            REFERENCES permissions (code)
+            ON DELETE CASCADE
+);
+
+INSERT INTO permissions (code, description)
+VALUES ('managers:read', 'List and view managers'),
+       ('managers:write', 'Create, update and delete managers'),
+       ('zookeepers:read', 'List and view zookeepers'),
+       ('zookeepers:write', 'Create, update and delete zookeepers'),
+       ('cages:read', 'List and view cages'),
+       ('cages:write', 'Create, update and delete cages'),
+       ('animals:read', 'List and view animals'),
+       ('animals:write', 'Create, update and delete animals'),
+       ('tasks:read', 'List tasks'),
+       ('tasks:assign', 'Create tasks and assign them to zookeepers'),
+       ('tasks:delete', 'Delete tasks'),
+       ('tasks:update_status', 'Change the status of tasks'),
+       ('users:manage', 'Reset passwords and unlock accounts'),
+       ('roles:manage', 'Change the permissions granted to roles');
+
+-- managers keep everything they could do before
+INSERT INTO role_permissions (role, permission_code)
+SELECT 'MANAGER', code
+FROM permissions;
+
+INSERT INTO role_permissions (role, permission_code)
+VALUES ('ZOOKEEPER', 'animals:read'),
+       ('ZOOKEEPER', 'cages:read'),
+       ('ZOOKEEPER', 'tasks:read'),
+       ('ZOOKEEPER', 'tasks:update_status');
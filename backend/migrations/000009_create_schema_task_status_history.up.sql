@@ -0,0 +1,27 @@
+CREATE TABLE task_status_history
+(
+    id          BIGSERIAL PRIMARY KEY,
+    task_id     BIGINT      NOT NULL,
+    from_status task_status,
+    to_status   task_status NOT NULL,
+    actor_id    BIGINT,
+    note        TEXT,
+    changed_at  TIMESTAMP   NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_task_status_history_task
+        FOREIGN KEY (task_id)
+            REFERENCES tasks (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_task_status_history_actor
+        FOREIGN KEY (actor_id)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_task_status_history_task_id ON task_status_history (task_id, changed_at);
+
+-- existing tasks start their history with the status they currently have
+INSERT INTO task_status_history (task_id, from_status, to_status, changed_at)
+SELECT id, NULL, status, created_at
+FROM tasks;
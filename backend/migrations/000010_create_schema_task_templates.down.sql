@@ -0,0 +1,6 @@
+ALTER TABLE tasks
+    DROP CONSTRAINT IF EXISTS uq_task_template_due_date,
+    DROP CONSTRAINT IF EXISTS fk_task_template,
+    DROP COLUMN IF EXISTS template_id;
+
+DROP TABLE IF EXISTS task_templates;
@@ -0,0 +1,47 @@
+CREATE TABLE task_templates
+(
+    id              BIGSERIAL PRIMARY KEY,
+    public_id       UUID         NOT NULL UNIQUE,
+
+    title           VARCHAR(150) NOT NULL,
+    description     TEXT,
+
+    manager_id      BIGINT       NOT NULL,
+    zookeeper_id    BIGINT       NOT NULL,
+    animal_id       BIGINT,
+
+    recurrence      VARCHAR(255) NOT NULL,
+    starts_on       DATE         NOT NULL,
+    ends_on         DATE,
+    lead_days       INT          NOT NULL DEFAULT 7 CHECK (lead_days >= 0),
+    active          BOOLEAN      NOT NULL DEFAULT TRUE,
+
+    -- last due date the scheduler has created tasks for
+    generated_until DATE,
+
+    created_at      TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_task_template_manager
+        FOREIGN KEY (manager_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_task_template_zookeeper
+        FOREIGN KEY (zookeeper_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_task_template_animal
+        FOREIGN KEY (animal_id)
+            REFERENCES animals (id)
+            ON DELETE SET NULL
+);
+
+ALTER TABLE tasks
+    ADD COLUMN template_id BIGINT,
+    ADD CONSTRAINT fk_task_template
+        FOREIGN KEY (template_id)
+            REFERENCES task_templates (id)
+            ON DELETE SET NULL,
+    -- one task per template and due date; NULL template ids never collide
+    ADD CONSTRAINT uq_task_template_due_date UNIQUE (template_id, due_date);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_tasks_status;
+DROP INDEX IF EXISTS idx_tasks_zookeeper_due_date;
+DROP INDEX IF EXISTS idx_tasks_manager_due_date;
+
+DROP INDEX IF EXISTS idx_animals_cage_id;
+DROP INDEX IF EXISTS idx_animals_species;
+DROP INDEX IF EXISTS idx_animals_name;
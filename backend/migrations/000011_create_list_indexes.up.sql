@@ -0,0 +1,7 @@
+CREATE INDEX idx_animals_name ON animals (name, id);
+CREATE INDEX idx_animals_species ON animals (LOWER(species));
+CREATE INDEX idx_animals_cage_id ON animals (cage_id);
+
+CREATE INDEX idx_tasks_manager_due_date ON tasks (manager_id, due_date, id);
+CREATE INDEX idx_tasks_zookeeper_due_date ON tasks (zookeeper_id, due_date, id);
+CREATE INDEX idx_tasks_status ON tasks (status);
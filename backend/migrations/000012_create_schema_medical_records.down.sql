@@ -0,0 +1,7 @@
+DELETE FROM permissions
+WHERE code IN ('medical:read', 'medical:write');
+
+DROP TABLE IF EXISTS vaccinations;
+DROP TABLE IF EXISTS prescriptions;
+DROP TABLE IF EXISTS diagnoses;
+DROP TABLE IF EXISTS vet_visits;
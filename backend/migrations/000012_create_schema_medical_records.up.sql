@@ -0,0 +1,152 @@
+CREATE TABLE vet_visits
+(
+    id          BIGSERIAL PRIMARY KEY,
+    public_id   UUID         NOT NULL UNIQUE,
+    animal_id   BIGINT       NOT NULL,
+
+    visited_at  TIMESTAMP    NOT NULL,
+    vet_name    VARCHAR(100) NOT NULL,
+    reason      TEXT,
+    notes       TEXT,
+
+    recorded_by BIGINT,
+    created_at  TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_vet_visit_animal
+        FOREIGN KEY (animal_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_vet_visit_recorded_by
+        FOREIGN KEY (recorded_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_vet_visits_animal_id ON vet_visits (animal_id, visited_at);
+
+CREATE TABLE diagnoses
+(
+    id           BIGSERIAL PRIMARY KEY,
+    public_id    UUID         NOT NULL UNIQUE,
+    animal_id    BIGINT       NOT NULL,
+    visit_id     BIGINT,
+
+    condition    VARCHAR(150) NOT NULL,
+    severity     VARCHAR(20)  NOT NULL DEFAULT 'MODERATE'
+        CHECK (severity IN ('MILD', 'MODERATE', 'SEVERE', 'CRITICAL')),
+    notes        TEXT,
+    diagnosed_on DATE         NOT NULL,
+    resolved_on  DATE,
+
+    recorded_by  BIGINT,
+    created_at   TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_diagnosis_animal
+        FOREIGN KEY (animal_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_diagnosis_visit
+        FOREIGN KEY (visit_id)
+            REFERENCES vet_visits (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT fk_diagnosis_recorded_by
+        FOREIGN KEY (recorded_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_diagnoses_animal_id ON diagnoses (animal_id, diagnosed_on);
+
+CREATE TABLE prescriptions
+(
+    id             BIGSERIAL PRIMARY KEY,
+    public_id      UUID         NOT NULL UNIQUE,
+    animal_id      BIGINT       NOT NULL,
+    visit_id       BIGINT,
+    diagnosis_id   BIGINT,
+
+    medication     VARCHAR(150) NOT NULL,
+    dosage         VARCHAR(100) NOT NULL,
+    route          VARCHAR(50),
+    -- one dose every interval_hours between starts_on and ends_on
+    interval_hours INT          NOT NULL CHECK (interval_hours > 0),
+    starts_on      DATE         NOT NULL,
+    ends_on        DATE,
+    instructions   TEXT,
+
+    recorded_by    BIGINT,
+    created_at     TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_prescription_animal
+        FOREIGN KEY (animal_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_prescription_visit
+        FOREIGN KEY (visit_id)
+            REFERENCES vet_visits (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT fk_prescription_diagnosis
+        FOREIGN KEY (diagnosis_id)
+            REFERENCES diagnoses (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT fk_prescription_recorded_by
+        FOREIGN KEY (recorded_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT chk_prescription_dates
+        CHECK (ends_on IS NULL OR ends_on >= starts_on)
+);
+
+CREATE INDEX idx_prescriptions_animal_id ON prescriptions (animal_id, starts_on);
+
+CREATE TABLE vaccinations
+(
+    id              BIGSERIAL PRIMARY KEY,
+    public_id       UUID         NOT NULL UNIQUE,
+    animal_id       BIGINT       NOT NULL,
+    visit_id        BIGINT,
+
+    vaccine         VARCHAR(150) NOT NULL,
+    administered_on DATE         NOT NULL,
+    -- when the next dose of this vaccine is due
+    due_on          DATE,
+    batch_number    VARCHAR(50),
+    notes           TEXT,
+
+    recorded_by     BIGINT,
+    created_at      TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_vaccination_animal
+        FOREIGN KEY (animal_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_vaccination_visit
+        FOREIGN KEY (visit_id)
+            REFERENCES vet_visits (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT fk_vaccination_recorded_by
+        FOREIGN KEY (recorded_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_vaccinations_animal_id ON vaccinations (animal_id, administered_on);
+CREATE INDEX idx_vaccinations_due_on ON vaccinations (due_on);
+
+INSERT INTO permissions (code, description)
+VALUES ('medical:read', 'View animal medical records'),
+       ('medical:write', 'Record vet visits, diagnoses, prescriptions and vaccinations');
+
+INSERT INTO role_permissions (role, permission_code)
+VALUES ('MANAGER', 'medical:read'),
+       ('MANAGER', 'medical:write'),
+       ('ZOOKEEPER', 'medical:read');
@@ -0,0 +1,5 @@
+DELETE FROM permissions
+WHERE code IN ('feeding:read', 'feeding:write', 'feeding:log');
+
+DROP TABLE IF EXISTS feeding_logs;
+DROP TABLE IF EXISTS diet_plans;
@@ -0,0 +1,84 @@
+CREATE TABLE diet_plans
+(
+    id            BIGSERIAL PRIMARY KEY,
+    public_id     UUID           NOT NULL UNIQUE,
+    animal_id     BIGINT         NOT NULL,
+
+    food_item     VARCHAR(100)   NOT NULL,
+    quantity      NUMERIC(10, 2) NOT NULL CHECK (quantity > 0),
+    unit          VARCHAR(20)    NOT NULL,
+    times_per_day INT            NOT NULL CHECK (times_per_day > 0),
+    notes         TEXT,
+
+    starts_on     DATE           NOT NULL DEFAULT CURRENT_DATE,
+    ends_on       DATE,
+
+    created_by    BIGINT,
+    created_at    TIMESTAMP      NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_diet_plan_animal
+        FOREIGN KEY (animal_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_diet_plan_created_by
+        FOREIGN KEY (created_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT chk_diet_plan_dates
+        CHECK (ends_on IS NULL OR ends_on >= starts_on)
+);
+
+CREATE INDEX idx_diet_plans_animal_id ON diet_plans (animal_id);
+
+CREATE TABLE feeding_logs
+(
+    id               BIGSERIAL PRIMARY KEY,
+    public_id        UUID           NOT NULL UNIQUE,
+    animal_id        BIGINT         NOT NULL,
+    diet_plan_id     BIGINT,
+
+    fed_at           TIMESTAMP      NOT NULL,
+    food_item        VARCHAR(100)   NOT NULL,
+    quantity_given   NUMERIC(10, 2) NOT NULL CHECK (quantity_given >= 0),
+    quantity_refused NUMERIC(10, 2) NOT NULL DEFAULT 0 CHECK (quantity_refused >= 0),
+    unit             VARCHAR(20)    NOT NULL,
+    notes            TEXT,
+
+    fed_by           BIGINT,
+    created_at       TIMESTAMP      NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_feeding_log_animal
+        FOREIGN KEY (animal_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_feeding_log_diet_plan
+        FOREIGN KEY (diet_plan_id)
+            REFERENCES diet_plans (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT fk_feeding_log_fed_by
+        FOREIGN KEY (fed_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT chk_feeding_log_refused
+        CHECK (quantity_refused <= quantity_given)
+);
+
+CREATE INDEX idx_feeding_logs_animal_fed_at ON feeding_logs (animal_id, fed_at);
+CREATE INDEX idx_feeding_logs_diet_plan_fed_at ON feeding_logs (diet_plan_id, fed_at);
+
+INSERT INTO permissions (code, description)
+VALUES ('feeding:read', 'View diet plans, feeding logs and missed feedings'),
+       ('feeding:write', 'Create, update and delete diet plans'),
+       ('feeding:log', 'Record feedings');
+
+INSERT INTO role_permissions (role, permission_code)
+VALUES ('MANAGER', 'feeding:read'),
+       ('MANAGER', 'feeding:write'),
+       ('MANAGER', 'feeding:log'),
+       ('ZOOKEEPER', 'feeding:read'),
+       ('ZOOKEEPER', 'feeding:log');
@@ -0,0 +1,6 @@
+DELETE FROM permissions
+WHERE code IN ('measurements:read', 'measurements:write', 'measurements:configure');
+
+DROP TABLE IF EXISTS species_weight_thresholds;
+DROP TABLE IF EXISTS animal_measurements;
+DROP FUNCTION IF EXISTS prevent_measurement_update();
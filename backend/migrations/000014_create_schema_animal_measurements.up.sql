@@ -0,0 +1,67 @@
+CREATE TABLE animal_measurements
+(
+    id                   BIGSERIAL PRIMARY KEY,
+    public_id            UUID           NOT NULL UNIQUE,
+    animal_id            BIGINT         NOT NULL,
+
+    measured_at          TIMESTAMP      NOT NULL,
+    weight_kg            NUMERIC(10, 3) CHECK (weight_kg > 0),
+    length_cm            NUMERIC(10, 2) CHECK (length_cm > 0),
+    -- 1 (emaciated) to 9 (obese)
+    body_condition_score NUMERIC(3, 1) CHECK (body_condition_score BETWEEN 1 AND 9),
+    notes                TEXT,
+
+    recorded_by          BIGINT,
+    created_at           TIMESTAMP      NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_animal_measurement_animal
+        FOREIGN KEY (animal_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_animal_measurement_recorded_by
+        FOREIGN KEY (recorded_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT chk_animal_measurement_value
+        CHECK (weight_kg IS NOT NULL OR length_cm IS NOT NULL OR body_condition_score IS NOT NULL)
+);
+
+CREATE INDEX idx_animal_measurements_animal_id ON animal_measurements (animal_id, measured_at);
+
+-- measurements are append-only; corrections are recorded as new rows
+CREATE FUNCTION prevent_measurement_update() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'animal_measurements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_animal_measurements_append_only
+    BEFORE UPDATE
+    ON animal_measurements
+    FOR EACH ROW
+EXECUTE FUNCTION prevent_measurement_update();
+
+-- per-species override of the weight alert defaults, keyed by the
+-- lower-cased species name
+CREATE TABLE species_weight_thresholds
+(
+    species            VARCHAR(100) PRIMARY KEY,
+    max_change_percent NUMERIC(5, 2) NOT NULL CHECK (max_change_percent > 0),
+    window_days        INT           NOT NULL CHECK (window_days > 0),
+    updated_at         TIMESTAMP     NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO permissions (code, description)
+VALUES ('measurements:read', 'View animal measurements, trends and weight alerts'),
+       ('measurements:write', 'Record animal measurements'),
+       ('measurements:configure', 'Change the weight alert thresholds per species');
+
+INSERT INTO role_permissions (role, permission_code)
+VALUES ('MANAGER', 'measurements:read'),
+       ('MANAGER', 'measurements:write'),
+       ('MANAGER', 'measurements:configure'),
+       ('ZOOKEEPER', 'measurements:read'),
+       ('ZOOKEEPER', 'measurements:write');
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS cage_species_limits;
+
+ALTER TABLE cages
+    DROP COLUMN IF EXISTS capacity;
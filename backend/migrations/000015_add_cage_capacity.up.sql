@@ -0,0 +1,18 @@
+-- NULL means the cage has no overall limit
+ALTER TABLE cages
+    ADD COLUMN capacity INT CHECK (capacity > 0);
+
+-- optional per-species limits, keyed by the lower-cased species name
+CREATE TABLE cage_species_limits
+(
+    cage_id   BIGINT       NOT NULL,
+    species   VARCHAR(100) NOT NULL,
+    max_count INT          NOT NULL CHECK (max_count > 0),
+
+    PRIMARY KEY (cage_id, species),
+
+    CONSTRAINT fk_cage_species_limit_cage
+        FOREIGN KEY (cage_id)
+            REFERENCES cages (id)
+            ON DELETE CASCADE
+);
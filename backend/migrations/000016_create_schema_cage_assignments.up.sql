@@ -0,0 +1,40 @@
+CREATE TABLE cage_assignments
+(
+    id         BIGSERIAL PRIMARY KEY,
+    animal_id  BIGINT    NOT NULL,
+    cage_id    BIGINT    NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    ended_at   TIMESTAMP,
+    reason     TEXT,
+    actor_id   BIGINT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_cage_assignment_animal
+        FOREIGN KEY (animal_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_cage_assignment_cage
+        FOREIGN KEY (cage_id)
+            REFERENCES cages (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_cage_assignment_actor
+        FOREIGN KEY (actor_id)
+            REFERENCES users (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT chk_cage_assignment_interval
+        CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+-- an animal lives in exactly one cage at a time
+CREATE UNIQUE INDEX uq_cage_assignments_open ON cage_assignments (animal_id) WHERE ended_at IS NULL;
+
+CREATE INDEX idx_cage_assignments_animal_id ON cage_assignments (animal_id, started_at);
+CREATE INDEX idx_cage_assignments_cage_id ON cage_assignments (cage_id, started_at);
+
+-- existing animals start their history in the cage they currently live in
+INSERT INTO cage_assignments (animal_id, cage_id, started_at)
+SELECT id, cage_id, created_at
+FROM animals;
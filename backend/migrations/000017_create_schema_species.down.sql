@@ -0,0 +1,48 @@
+DELETE FROM permissions
+WHERE code IN ('species:read', 'species:write');
+
+ALTER TABLE cage_species_limits
+    ADD COLUMN species VARCHAR(100);
+
+UPDATE cage_species_limits l
+SET species = LOWER(s.common_name)
+FROM species s
+WHERE s.id = l.species_id;
+
+ALTER TABLE cage_species_limits
+    DROP CONSTRAINT cage_species_limits_pkey,
+    DROP COLUMN species_id,
+    ALTER COLUMN species SET NOT NULL,
+    ADD PRIMARY KEY (cage_id, species);
+
+ALTER TABLE species_weight_thresholds
+    ADD COLUMN species VARCHAR(100);
+
+UPDATE species_weight_thresholds t
+SET species = LOWER(s.common_name)
+FROM species s
+WHERE s.id = t.species_id;
+
+ALTER TABLE species_weight_thresholds
+    DROP CONSTRAINT species_weight_thresholds_pkey,
+    DROP COLUMN species_id,
+    ALTER COLUMN species SET NOT NULL,
+    ADD PRIMARY KEY (species);
+
+ALTER TABLE animals
+    ADD COLUMN species VARCHAR(100);
+
+UPDATE animals a
+SET species = s.common_name
+FROM species s
+WHERE s.id = a.species_id;
+
+DROP INDEX IF EXISTS idx_animals_species_id;
+
+ALTER TABLE animals
+    DROP COLUMN species_id,
+    ALTER COLUMN species SET NOT NULL;
+
+CREATE INDEX idx_animals_species ON animals (LOWER(species));
+
+DROP TABLE IF EXISTS species;
@@ -0,0 +1,114 @@
+CREATE TABLE species
+(
+    id                  BIGSERIAL PRIMARY KEY,
+    public_id           UUID         NOT NULL UNIQUE,
+    common_name         VARCHAR(100) NOT NULL,
+    scientific_name     VARCHAR(150),
+    -- IUCN Red List category
+    conservation_status VARCHAR(2)
+        CHECK (conservation_status IN ('EX', 'EW', 'CR', 'EN', 'VU', 'NT', 'LC', 'DD', 'NE')),
+    diet_type           VARCHAR(20)
+        CHECK (diet_type IN ('CARNIVORE', 'HERBIVORE', 'OMNIVORE', 'INSECTIVORE', 'PISCIVORE')),
+
+    -- default care parameters for animals of this species
+    min_temperature_c   NUMERIC(5, 2),
+    max_temperature_c   NUMERIC(5, 2),
+    feedings_per_day    INT CHECK (feedings_per_day > 0),
+    care_notes          TEXT,
+
+    created_at          TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_species_temperature
+        CHECK (min_temperature_c IS NULL OR max_temperature_c IS NULL OR min_temperature_c <= max_temperature_c)
+);
+
+CREATE UNIQUE INDEX uq_species_common_name ON species (LOWER(common_name));
+CREATE UNIQUE INDEX uq_species_scientific_name ON species (LOWER(scientific_name))
+    WHERE scientific_name IS NOT NULL;
+
+-- one catalog entry per free-text species, ignoring case and whitespace;
+-- the most used spelling becomes the common name. Synonyms such as a
+-- scientific name used as the species are merged through the API.
+INSERT INTO species (public_id, common_name)
+SELECT gen_random_uuid(), name
+FROM (SELECT DISTINCT ON (key) key, name
+      FROM (SELECT LOWER(regexp_replace(btrim(species), '\s+', ' ', 'g')) AS key,
+                   regexp_replace(btrim(species), '\s+', ' ', 'g')        AS name,
+                   COUNT(*)                                               AS uses
+            FROM animals
+            GROUP BY 1, 2) spellings
+      ORDER BY key, uses DESC, name) names;
+
+-- thresholds and cage limits may name species no animal has yet
+INSERT INTO species (public_id, common_name)
+SELECT gen_random_uuid(), species
+FROM (SELECT species FROM species_weight_thresholds
+      UNION
+      SELECT species FROM cage_species_limits) configured
+ON CONFLICT ((LOWER(common_name))) DO NOTHING;
+
+ALTER TABLE animals
+    ADD COLUMN species_id BIGINT;
+
+UPDATE animals a
+SET species_id = s.id
+FROM species s
+WHERE LOWER(s.common_name) = LOWER(regexp_replace(btrim(a.species), '\s+', ' ', 'g'));
+
+DROP INDEX IF EXISTS idx_animals_species;
+
+ALTER TABLE animals
+    ALTER COLUMN species_id SET NOT NULL,
+    ADD CONSTRAINT fk_animal_species
+        FOREIGN KEY (species_id)
+            REFERENCES species (id)
+            ON DELETE RESTRICT,
+    DROP COLUMN species;
+
+CREATE INDEX idx_animals_species_id ON animals (species_id);
+
+ALTER TABLE species_weight_thresholds
+    ADD COLUMN species_id BIGINT;
+
+UPDATE species_weight_thresholds t
+SET species_id = s.id
+FROM species s
+WHERE LOWER(s.common_name) = t.species;
+
+ALTER TABLE species_weight_thresholds
+    DROP CONSTRAINT species_weight_thresholds_pkey,
+    DROP COLUMN species,
+    ALTER COLUMN species_id SET NOT NULL,
+    ADD PRIMARY KEY (species_id),
+    ADD CONSTRAINT fk_species_weight_threshold_species
+        FOREIGN KEY (species_id)
+            REFERENCES species (id)
+            ON DELETE CASCADE;
+
+ALTER TABLE cage_species_limits
+    ADD COLUMN species_id BIGINT;
+
+UPDATE cage_species_limits l
+SET species_id = s.id
+FROM species s
+WHERE LOWER(s.common_name) = l.species;
+
+ALTER TABLE cage_species_limits
+    DROP CONSTRAINT cage_species_limits_pkey,
+    DROP COLUMN species,
+    ALTER COLUMN species_id SET NOT NULL,
+    ADD PRIMARY KEY (cage_id, species_id),
+    ADD CONSTRAINT fk_cage_species_limit_species
+        FOREIGN KEY (species_id)
+            REFERENCES species (id)
+            ON DELETE CASCADE;
+
+INSERT INTO permissions (code, description)
+VALUES ('species:read', 'View the species catalog'),
+       ('species:write', 'Manage the species catalog');
+
+INSERT INTO role_permissions (role, permission_code)
+VALUES ('MANAGER', 'species:read'),
+       ('MANAGER', 'species:write'),
+       ('ZOOKEEPER', 'species:read');
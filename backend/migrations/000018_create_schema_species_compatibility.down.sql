@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS compatibility_overrides;
+DROP TABLE IF EXISTS species_compatibility;
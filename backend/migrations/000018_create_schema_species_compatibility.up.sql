@@ -0,0 +1,58 @@
+-- one rule per unordered pair of species, stored with the lower id first;
+-- pairs without a rule are allowed to share a cage
+CREATE TABLE species_compatibility
+(
+    species_a_id BIGINT      NOT NULL,
+    species_b_id BIGINT      NOT NULL,
+    rule         VARCHAR(20) NOT NULL
+        CHECK (rule IN ('ALLOWED', 'FORBIDDEN', 'REQUIRES_APPROVAL')),
+    notes        TEXT,
+    updated_at   TIMESTAMP   NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (species_a_id, species_b_id),
+
+    CONSTRAINT chk_species_compatibility_order
+        CHECK (species_a_id <= species_b_id),
+
+    CONSTRAINT fk_species_compatibility_a
+        FOREIGN KEY (species_a_id)
+            REFERENCES species (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_species_compatibility_b
+        FOREIGN KEY (species_b_id)
+            REFERENCES species (id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX idx_species_compatibility_b ON species_compatibility (species_b_id);
+
+-- placements a manager approved despite a REQUIRES_APPROVAL rule, with the
+-- conflicting animals as they were at the time
+CREATE TABLE compatibility_overrides
+(
+    id          BIGSERIAL PRIMARY KEY,
+    animal_id   BIGINT    NOT NULL,
+    cage_id     BIGINT    NOT NULL,
+    approved_by BIGINT,
+    reason      TEXT      NOT NULL,
+    conflicts   JSONB     NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_compatibility_override_animal
+        FOREIGN KEY (animal_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_compatibility_override_cage
+        FOREIGN KEY (cage_id)
+            REFERENCES cages (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_compatibility_override_approved_by
+        FOREIGN KEY (approved_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_compatibility_overrides_cage_id ON compatibility_overrides (cage_id, created_at);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS animal_status_history;
+
+DROP INDEX IF EXISTS idx_animals_status;
+
+ALTER TABLE animals
+    DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS animal_status;
@@ -0,0 +1,43 @@
+CREATE TYPE animal_status AS ENUM (
+    'ACTIVE',
+    'QUARANTINED',
+    'ON_LOAN',
+    'DECEASED',
+    'TRANSFERRED_OUT'
+    );
+
+-- animals that are not ACTIVE or QUARANTINED keep their last cage but no
+-- longer occupy it
+ALTER TABLE animals
+    ADD COLUMN status animal_status NOT NULL DEFAULT 'ACTIVE';
+
+CREATE INDEX idx_animals_status ON animals (status);
+
+CREATE TABLE animal_status_history
+(
+    id           BIGSERIAL PRIMARY KEY,
+    animal_id    BIGINT        NOT NULL,
+    from_status  animal_status,
+    to_status    animal_status NOT NULL,
+    reason       TEXT,
+    effective_at TIMESTAMP     NOT NULL,
+    actor_id     BIGINT,
+    created_at   TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_animal_status_history_animal
+        FOREIGN KEY (animal_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_animal_status_history_actor
+        FOREIGN KEY (actor_id)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_animal_status_history_animal_id ON animal_status_history (animal_id, effective_at);
+
+-- existing animals start their history as active from when they were added
+INSERT INTO animal_status_history (animal_id, from_status, to_status, effective_at)
+SELECT id, NULL, 'ACTIVE', created_at
+FROM animals;
@@ -0,0 +1,10 @@
+DELETE FROM permissions
+WHERE code IN ('quarantine:read', 'quarantine:write');
+
+ALTER TABLE tasks
+    DROP CONSTRAINT IF EXISTS uq_task_quarantine_due_date,
+    DROP CONSTRAINT IF EXISTS fk_task_quarantine,
+    DROP COLUMN IF EXISTS quarantine_id;
+
+DROP TABLE IF EXISTS quarantine_checks;
+DROP TABLE IF EXISTS quarantines;
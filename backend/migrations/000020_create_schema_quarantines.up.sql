@@ -0,0 +1,99 @@
+CREATE TABLE quarantines
+(
+    id              BIGSERIAL PRIMARY KEY,
+    public_id       UUID         NOT NULL UNIQUE,
+    animal_id       BIGINT       NOT NULL,
+    cage_id         BIGINT       NOT NULL,
+
+    reason          TEXT         NOT NULL,
+    vet_name        VARCHAR(100) NOT NULL,
+    manager_id      BIGINT       NOT NULL,
+    zookeeper_id    BIGINT       NOT NULL,
+
+    started_on      DATE         NOT NULL,
+    expected_end_on DATE         NOT NULL,
+    status          VARCHAR(20)  NOT NULL DEFAULT 'OPEN'
+        CHECK (status IN ('OPEN', 'CLEARED', 'CLOSED')),
+    ended_at        TIMESTAMP,
+    ended_by        BIGINT,
+    outcome_notes   TEXT,
+
+    -- last day the scheduler has created a daily check task for
+    generated_until DATE,
+
+    created_at      TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_quarantine_period CHECK (expected_end_on >= started_on),
+
+    CONSTRAINT fk_quarantine_animal
+        FOREIGN KEY (animal_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_quarantine_cage
+        FOREIGN KEY (cage_id)
+            REFERENCES cages (id)
+            ON DELETE RESTRICT,
+
+    CONSTRAINT fk_quarantine_manager
+        FOREIGN KEY (manager_id)
+            REFERENCES users (id)
+            ON DELETE RESTRICT,
+
+    CONSTRAINT fk_quarantine_zookeeper
+        FOREIGN KEY (zookeeper_id)
+            REFERENCES users (id)
+            ON DELETE RESTRICT,
+
+    CONSTRAINT fk_quarantine_ended_by
+        FOREIGN KEY (ended_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+-- an animal is in at most one open quarantine
+CREATE UNIQUE INDEX uq_quarantines_open_animal ON quarantines (animal_id) WHERE status = 'OPEN';
+CREATE INDEX idx_quarantines_animal_id ON quarantines (animal_id, started_on);
+CREATE INDEX idx_quarantines_status ON quarantines (status);
+
+CREATE TABLE quarantine_checks
+(
+    id            BIGSERIAL PRIMARY KEY,
+    public_id     UUID         NOT NULL UNIQUE,
+    quarantine_id BIGINT       NOT NULL,
+
+    name          VARCHAR(150) NOT NULL,
+    passed_at     TIMESTAMP,
+    passed_by     BIGINT,
+    notes         TEXT,
+
+    CONSTRAINT fk_quarantine_check_quarantine
+        FOREIGN KEY (quarantine_id)
+            REFERENCES quarantines (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_quarantine_check_passed_by
+        FOREIGN KEY (passed_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_quarantine_checks_quarantine_id ON quarantine_checks (quarantine_id);
+
+ALTER TABLE tasks
+    ADD COLUMN quarantine_id BIGINT,
+    ADD CONSTRAINT fk_task_quarantine
+        FOREIGN KEY (quarantine_id)
+            REFERENCES quarantines (id)
+            ON DELETE SET NULL,
+    -- one daily check task per quarantine and day
+    ADD CONSTRAINT uq_task_quarantine_due_date UNIQUE (quarantine_id, due_date);
+
+INSERT INTO permissions (code, description)
+VALUES ('quarantine:read', 'View quarantine periods'),
+       ('quarantine:write', 'Open, clear and record checks of quarantine periods');
+
+INSERT INTO role_permissions (role, permission_code)
+VALUES ('MANAGER', 'quarantine:read'),
+       ('MANAGER', 'quarantine:write'),
+       ('ZOOKEEPER', 'quarantine:read');
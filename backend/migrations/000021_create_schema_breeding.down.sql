@@ -0,0 +1,25 @@
+DELETE FROM permissions
+WHERE code IN ('breeding:read', 'breeding:write');
+
+ALTER TABLE animals
+    DROP CONSTRAINT IF EXISTS fk_animal_birth,
+    DROP COLUMN IF EXISTS birth_id;
+
+DROP TABLE IF EXISTS births;
+DROP TABLE IF EXISTS pregnancies;
+DROP TABLE IF EXISTS breeding_pairings;
+
+DROP INDEX IF EXISTS idx_animals_sire_id;
+DROP INDEX IF EXISTS idx_animals_dam_id;
+DROP INDEX IF EXISTS uq_animals_studbook_id;
+DROP INDEX IF EXISTS uq_animals_microchip_id;
+
+ALTER TABLE animals
+    DROP CONSTRAINT IF EXISTS fk_animal_sire,
+    DROP CONSTRAINT IF EXISTS fk_animal_dam,
+    DROP CONSTRAINT IF EXISTS chk_animal_not_own_parent,
+    DROP COLUMN IF EXISTS studbook_id,
+    DROP COLUMN IF EXISTS microchip_id,
+    DROP COLUMN IF EXISTS sire_id,
+    DROP COLUMN IF EXISTS dam_id,
+    DROP COLUMN IF EXISTS sex;
@@ -0,0 +1,154 @@
+ALTER TABLE animals
+    ADD COLUMN sex          VARCHAR(10) NOT NULL DEFAULT 'UNKNOWN'
+        CHECK (sex IN ('MALE', 'FEMALE', 'UNKNOWN')),
+    ADD COLUMN dam_id       BIGINT,
+    ADD COLUMN sire_id      BIGINT,
+    ADD COLUMN microchip_id VARCHAR(50),
+    ADD COLUMN studbook_id  VARCHAR(50),
+    ADD CONSTRAINT chk_animal_not_own_parent CHECK (dam_id <> id AND sire_id <> id),
+    ADD CONSTRAINT fk_animal_dam
+        FOREIGN KEY (dam_id)
+            REFERENCES animals (id)
+            ON DELETE SET NULL,
+    ADD CONSTRAINT fk_animal_sire
+        FOREIGN KEY (sire_id)
+            REFERENCES animals (id)
+            ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX uq_animals_microchip_id ON animals (microchip_id) WHERE microchip_id IS NOT NULL;
+CREATE UNIQUE INDEX uq_animals_studbook_id ON animals (studbook_id) WHERE studbook_id IS NOT NULL;
+CREATE INDEX idx_animals_dam_id ON animals (dam_id);
+CREATE INDEX idx_animals_sire_id ON animals (sire_id);
+
+CREATE TABLE breeding_pairings
+(
+    id           BIGSERIAL PRIMARY KEY,
+    public_id    UUID      NOT NULL UNIQUE,
+    dam_id       BIGINT    NOT NULL,
+    sire_id      BIGINT    NOT NULL,
+
+    paired_on    DATE      NOT NULL,
+    separated_on DATE,
+    -- inbreeding coefficient of the expected offspring when the pair was set up
+    inbreeding   NUMERIC(6, 5),
+    notes        TEXT,
+
+    created_by   BIGINT,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_pairing_period CHECK (separated_on IS NULL OR separated_on >= paired_on),
+
+    CONSTRAINT fk_pairing_dam
+        FOREIGN KEY (dam_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_pairing_sire
+        FOREIGN KEY (sire_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_pairing_created_by
+        FOREIGN KEY (created_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_breeding_pairings_dam_id ON breeding_pairings (dam_id);
+CREATE INDEX idx_breeding_pairings_sire_id ON breeding_pairings (sire_id);
+
+CREATE TABLE pregnancies
+(
+    id              BIGSERIAL PRIMARY KEY,
+    public_id       UUID        NOT NULL UNIQUE,
+    dam_id          BIGINT      NOT NULL,
+    sire_id         BIGINT,
+    pairing_id      BIGINT,
+
+    detected_on     DATE        NOT NULL,
+    expected_due_on DATE,
+    status          VARCHAR(20) NOT NULL DEFAULT 'ONGOING'
+        CHECK (status IN ('ONGOING', 'BORN', 'LOST')),
+    ended_on        DATE,
+    notes           TEXT,
+
+    recorded_by     BIGINT,
+    created_at      TIMESTAMP   NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_pregnancy_dam
+        FOREIGN KEY (dam_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_pregnancy_sire
+        FOREIGN KEY (sire_id)
+            REFERENCES animals (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT fk_pregnancy_pairing
+        FOREIGN KEY (pairing_id)
+            REFERENCES breeding_pairings (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT fk_pregnancy_recorded_by
+        FOREIGN KEY (recorded_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+-- a dam carries at most one ongoing pregnancy
+CREATE UNIQUE INDEX uq_pregnancies_ongoing_dam ON pregnancies (dam_id) WHERE status = 'ONGOING';
+
+CREATE TABLE births
+(
+    id              BIGSERIAL PRIMARY KEY,
+    public_id       UUID      NOT NULL UNIQUE,
+    dam_id          BIGINT    NOT NULL,
+    sire_id         BIGINT,
+    pregnancy_id    BIGINT,
+
+    born_on         DATE      NOT NULL,
+    stillborn_count INT       NOT NULL DEFAULT 0 CHECK (stillborn_count >= 0),
+    notes           TEXT,
+
+    recorded_by     BIGINT,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_birth_dam
+        FOREIGN KEY (dam_id)
+            REFERENCES animals (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_birth_sire
+        FOREIGN KEY (sire_id)
+            REFERENCES animals (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT fk_birth_pregnancy
+        FOREIGN KEY (pregnancy_id)
+            REFERENCES pregnancies (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT fk_birth_recorded_by
+        FOREIGN KEY (recorded_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_births_dam_id ON births (dam_id, born_on);
+
+ALTER TABLE animals
+    ADD COLUMN birth_id BIGINT,
+    ADD CONSTRAINT fk_animal_birth
+        FOREIGN KEY (birth_id)
+            REFERENCES births (id)
+            ON DELETE SET NULL;
+
+INSERT INTO permissions (code, description)
+VALUES ('breeding:read', 'View parentage, pairings, pregnancies and births'),
+       ('breeding:write', 'Record parentage, pairings, pregnancies and births');
+
+INSERT INTO role_permissions (role, permission_code)
+VALUES ('MANAGER', 'breeding:read'),
+       ('MANAGER', 'breeding:write'),
+       ('ZOOKEEPER', 'breeding:read');
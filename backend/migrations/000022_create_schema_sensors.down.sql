@@ -0,0 +1,7 @@
+DELETE FROM permissions
+WHERE code IN ('sensors:read', 'sensors:write');
+
+DROP TABLE IF EXISTS environment_alerts;
+DROP TABLE IF EXISTS cage_environment_thresholds;
+DROP TABLE IF EXISTS sensor_readings;
+DROP TABLE IF EXISTS sensors;
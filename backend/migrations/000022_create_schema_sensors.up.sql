@@ -0,0 +1,127 @@
+CREATE TABLE sensors
+(
+    id              BIGSERIAL PRIMARY KEY,
+    public_id       UUID         NOT NULL UNIQUE,
+    cage_id         BIGINT       NOT NULL,
+
+    name            VARCHAR(100) NOT NULL,
+    serial_number   VARCHAR(100) UNIQUE,
+    -- SHA-256 of the device key; the key itself is only shown once
+    device_key_hash VARCHAR(64)  NOT NULL UNIQUE,
+    active          BOOLEAN      NOT NULL DEFAULT TRUE,
+    last_seen_at    TIMESTAMP,
+
+    created_at      TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_sensor_cage
+        FOREIGN KEY (cage_id)
+            REFERENCES cages (id)
+            ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_sensors_cage_id ON sensors (cage_id);
+
+CREATE TABLE sensor_readings
+(
+    id          BIGSERIAL PRIMARY KEY,
+    sensor_id   BIGINT           NOT NULL,
+    -- the cage the sensor was in when the reading was taken
+    cage_id     BIGINT           NOT NULL,
+
+    metric      VARCHAR(20)      NOT NULL
+        CHECK (metric IN ('TEMPERATURE', 'HUMIDITY')),
+    value       DOUBLE PRECISION NOT NULL,
+    recorded_at TIMESTAMP        NOT NULL,
+    received_at TIMESTAMP        NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_sensor_reading_sensor
+        FOREIGN KEY (sensor_id)
+            REFERENCES sensors (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_sensor_reading_cage
+        FOREIGN KEY (cage_id)
+            REFERENCES cages (id)
+            ON DELETE CASCADE,
+
+    -- devices resend batches they are unsure about
+    CONSTRAINT uq_sensor_reading UNIQUE (sensor_id, metric, recorded_at)
+);
+
+CREATE INDEX idx_sensor_readings_cage_id ON sensor_readings (cage_id, metric, recorded_at);
+
+CREATE TABLE cage_environment_thresholds
+(
+    cage_id    BIGINT           NOT NULL,
+    metric     VARCHAR(20)      NOT NULL
+        CHECK (metric IN ('TEMPERATURE', 'HUMIDITY')),
+    min_value  DOUBLE PRECISION,
+    max_value  DOUBLE PRECISION,
+    updated_at TIMESTAMP        NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (cage_id, metric),
+
+    CONSTRAINT chk_environment_threshold_bound
+        CHECK (min_value IS NOT NULL OR max_value IS NOT NULL),
+    CONSTRAINT chk_environment_threshold_range
+        CHECK (min_value IS NULL OR max_value IS NULL OR min_value < max_value),
+
+    CONSTRAINT fk_environment_threshold_cage
+        FOREIGN KEY (cage_id)
+            REFERENCES cages (id)
+            ON DELETE CASCADE
+);
+
+-- an alert stays open while the readings of a cage are out of range and
+-- is resolved by the first reading back in range
+CREATE TABLE environment_alerts
+(
+    id              BIGSERIAL PRIMARY KEY,
+    public_id       UUID             NOT NULL UNIQUE,
+    cage_id         BIGINT           NOT NULL,
+    sensor_id       BIGINT,
+
+    metric          VARCHAR(20)      NOT NULL
+        CHECK (metric IN ('TEMPERATURE', 'HUMIDITY')),
+    kind            VARCHAR(10)      NOT NULL
+        CHECK (kind IN ('LOW', 'HIGH')),
+    min_value       DOUBLE PRECISION,
+    max_value       DOUBLE PRECISION,
+
+    started_at      TIMESTAMP        NOT NULL,
+    last_reading_at TIMESTAMP        NOT NULL,
+    last_value      DOUBLE PRECISION NOT NULL,
+    peak_value      DOUBLE PRECISION NOT NULL,
+    resolved_at     TIMESTAMP,
+
+    acknowledged_at TIMESTAMP,
+    acknowledged_by BIGINT,
+
+    CONSTRAINT fk_environment_alert_cage
+        FOREIGN KEY (cage_id)
+            REFERENCES cages (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_environment_alert_sensor
+        FOREIGN KEY (sensor_id)
+            REFERENCES sensors (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT fk_environment_alert_acknowledged_by
+        FOREIGN KEY (acknowledged_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX uq_environment_alerts_open ON environment_alerts (cage_id, metric) WHERE resolved_at IS NULL;
+CREATE INDEX idx_environment_alerts_started_at ON environment_alerts (started_at);
+
+INSERT INTO permissions (code, description)
+VALUES ('sensors:read', 'View sensors, environment readings and alerts'),
+       ('sensors:write', 'Register sensors, set environment thresholds and acknowledge alerts');
+
+INSERT INTO role_permissions (role, permission_code)
+VALUES ('MANAGER', 'sensors:read'),
+       ('MANAGER', 'sensors:write'),
+       ('ZOOKEEPER', 'sensors:read');
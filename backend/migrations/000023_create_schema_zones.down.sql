@@ -0,0 +1,11 @@
+DELETE FROM permissions
+WHERE code IN ('zones:read', 'zones:write');
+
+DROP INDEX IF EXISTS idx_cages_area_id;
+
+ALTER TABLE cages
+    DROP CONSTRAINT IF EXISTS fk_cage_area,
+    DROP COLUMN IF EXISTS area_id;
+
+DROP TABLE IF EXISTS areas;
+DROP TABLE IF EXISTS zones;
@@ -0,0 +1,59 @@
+-- the park is the root of the hierarchy: park -> zone -> area -> cage
+CREATE TABLE zones
+(
+    id          BIGSERIAL PRIMARY KEY,
+    public_id   UUID         NOT NULL UNIQUE,
+    code        VARCHAR(50)  NOT NULL UNIQUE,
+    name        VARCHAR(100) NOT NULL,
+    description TEXT,
+    -- the manager responsible for the zone
+    manager_id  BIGINT,
+
+    created_at  TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_zone_manager
+        FOREIGN KEY (manager_id)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_zones_manager_id ON zones (manager_id);
+
+CREATE TABLE areas
+(
+    id          BIGSERIAL PRIMARY KEY,
+    public_id   UUID         NOT NULL UNIQUE,
+    zone_id     BIGINT       NOT NULL,
+    name        VARCHAR(100) NOT NULL,
+    description TEXT,
+
+    created_at  TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_area_zone_name UNIQUE (zone_id, name),
+
+    CONSTRAINT fk_area_zone
+        FOREIGN KEY (zone_id)
+            REFERENCES zones (id)
+            ON DELETE RESTRICT
+);
+
+-- cages keep their free-text location; the area places them in the
+-- hierarchy
+ALTER TABLE cages
+    ADD COLUMN area_id BIGINT,
+    ADD CONSTRAINT fk_cage_area
+        FOREIGN KEY (area_id)
+            REFERENCES areas (id)
+            ON DELETE RESTRICT;
+
+CREATE INDEX idx_cages_area_id ON cages (area_id);
+
+INSERT INTO permissions (code, description)
+VALUES ('zones:read', 'View park zones and areas'),
+       ('zones:write', 'Create, update and delete park zones and areas');
+
+INSERT INTO role_permissions (role, permission_code)
+VALUES ('MANAGER', 'zones:read'),
+       ('MANAGER', 'zones:write'),
+       ('ZOOKEEPER', 'zones:read');
@@ -0,0 +1,4 @@
+DELETE FROM permissions
+WHERE code IN ('shifts:read', 'shifts:write');
+
+DROP TABLE IF EXISTS shifts;
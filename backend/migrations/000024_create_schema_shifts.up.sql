@@ -0,0 +1,51 @@
+-- a shift is the time a zookeeper is on duty, covering a zone or a cage
+CREATE TABLE shifts
+(
+    id           BIGSERIAL PRIMARY KEY,
+    public_id    UUID      NOT NULL UNIQUE,
+    zookeeper_id BIGINT    NOT NULL,
+    zone_id      BIGINT,
+    cage_id      BIGINT,
+    starts_at    TIMESTAMP NOT NULL,
+    ends_at      TIMESTAMP NOT NULL,
+    notes        TEXT,
+    created_by   BIGINT,
+
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_shift_period CHECK (ends_at > starts_at),
+    CONSTRAINT chk_shift_coverage CHECK (num_nonnulls(zone_id, cage_id) = 1),
+
+    CONSTRAINT fk_shift_zookeeper
+        FOREIGN KEY (zookeeper_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_shift_zone
+        FOREIGN KEY (zone_id)
+            REFERENCES zones (id)
+            ON DELETE RESTRICT,
+
+    CONSTRAINT fk_shift_cage
+        FOREIGN KEY (cage_id)
+            REFERENCES cages (id)
+            ON DELETE RESTRICT,
+
+    CONSTRAINT fk_shift_created_by
+        FOREIGN KEY (created_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_shifts_zookeeper_starts_at ON shifts (zookeeper_id, starts_at);
+CREATE INDEX idx_shifts_starts_at ON shifts (starts_at);
+
+INSERT INTO permissions (code, description)
+VALUES ('shifts:read', 'View zookeeper shifts and the weekly roster'),
+       ('shifts:write', 'Schedule, change and cancel zookeeper shifts');
+
+INSERT INTO role_permissions (role, permission_code)
+VALUES ('MANAGER', 'shifts:read'),
+       ('MANAGER', 'shifts:write'),
+       ('ZOOKEEPER', 'shifts:read');
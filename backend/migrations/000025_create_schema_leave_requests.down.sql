@@ -0,0 +1,4 @@
+DELETE FROM permissions
+WHERE code IN ('leave:read', 'leave:request', 'leave:approve');
+
+DROP TABLE IF EXISTS leave_requests;
@@ -0,0 +1,44 @@
+CREATE TABLE leave_requests
+(
+    id            BIGSERIAL PRIMARY KEY,
+    public_id     UUID        NOT NULL UNIQUE,
+    zookeeper_id  BIGINT      NOT NULL,
+    type          VARCHAR(20) NOT NULL,
+    starts_on     DATE        NOT NULL,
+    ends_on       DATE        NOT NULL,
+    reason        TEXT,
+    status        VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    decided_by    BIGINT,
+    decided_at    TIMESTAMP,
+    decision_note TEXT,
+
+    created_at    TIMESTAMP   NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_leave_type CHECK (type IN ('ANNUAL', 'SICK', 'TRAINING', 'PERSONAL', 'OTHER')),
+    CONSTRAINT chk_leave_status CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED', 'CANCELLED')),
+    CONSTRAINT chk_leave_period CHECK (ends_on >= starts_on),
+
+    CONSTRAINT fk_leave_zookeeper
+        FOREIGN KEY (zookeeper_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_leave_decided_by
+        FOREIGN KEY (decided_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_leave_requests_zookeeper_starts_on ON leave_requests (zookeeper_id, starts_on);
+CREATE INDEX idx_leave_requests_status ON leave_requests (status);
+
+INSERT INTO permissions (code, description)
+VALUES ('leave:read', 'View leave requests and zookeeper availability'),
+       ('leave:request', 'Submit and cancel own leave requests'),
+       ('leave:approve', 'Approve or reject leave requests and reassign tasks');
+
+INSERT INTO role_permissions (role, permission_code)
+VALUES ('MANAGER', 'leave:read'),
+       ('MANAGER', 'leave:approve'),
+       ('ZOOKEEPER', 'leave:read'),
+       ('ZOOKEEPER', 'leave:request');
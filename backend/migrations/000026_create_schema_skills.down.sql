@@ -0,0 +1,7 @@
+DELETE FROM permissions
+WHERE code IN ('skills:read', 'skills:write');
+
+DROP TABLE IF EXISTS task_template_skills;
+DROP TABLE IF EXISTS species_skills;
+DROP TABLE IF EXISTS certifications;
+DROP TABLE IF EXISTS skills;
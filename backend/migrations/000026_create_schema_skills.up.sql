@@ -0,0 +1,94 @@
+-- a skill is training some work needs, e.g. venomous reptile handling
+CREATE TABLE skills
+(
+    id          BIGSERIAL PRIMARY KEY,
+    public_id   UUID         NOT NULL UNIQUE,
+    code        VARCHAR(50)  NOT NULL UNIQUE,
+    name        VARCHAR(100) NOT NULL,
+    description TEXT,
+
+    created_at  TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+-- a zookeeper holds one certification per skill; renewals update it
+CREATE TABLE certifications
+(
+    id           BIGSERIAL PRIMARY KEY,
+    public_id    UUID      NOT NULL UNIQUE,
+    zookeeper_id BIGINT    NOT NULL,
+    skill_id     BIGINT    NOT NULL,
+    certified_on DATE      NOT NULL,
+    expires_on   DATE,
+    reference    VARCHAR(100),
+    notes        TEXT,
+    created_by   BIGINT,
+
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_certification_zookeeper_skill UNIQUE (zookeeper_id, skill_id),
+    CONSTRAINT chk_certification_period CHECK (expires_on IS NULL OR expires_on >= certified_on),
+
+    CONSTRAINT fk_certification_zookeeper
+        FOREIGN KEY (zookeeper_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_certification_skill
+        FOREIGN KEY (skill_id)
+            REFERENCES skills (id)
+            ON DELETE RESTRICT,
+
+    CONSTRAINT fk_certification_created_by
+        FOREIGN KEY (created_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX idx_certifications_expires_on ON certifications (expires_on);
+
+CREATE TABLE species_skills
+(
+    species_id BIGINT NOT NULL,
+    skill_id   BIGINT NOT NULL,
+
+    PRIMARY KEY (species_id, skill_id),
+
+    CONSTRAINT fk_species_skill_species
+        FOREIGN KEY (species_id)
+            REFERENCES species (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_species_skill_skill
+        FOREIGN KEY (skill_id)
+            REFERENCES skills (id)
+            ON DELETE RESTRICT
+);
+
+CREATE TABLE task_template_skills
+(
+    template_id BIGINT NOT NULL,
+    skill_id    BIGINT NOT NULL,
+
+    PRIMARY KEY (template_id, skill_id),
+
+    CONSTRAINT fk_template_skill_template
+        FOREIGN KEY (template_id)
+            REFERENCES task_templates (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_template_skill_skill
+        FOREIGN KEY (skill_id)
+            REFERENCES skills (id)
+            ON DELETE RESTRICT
+);
+
+INSERT INTO permissions (code, description)
+VALUES ('skills:read', 'View skills, required skills and certifications'),
+       ('skills:write', 'Manage skills, required skills and certifications');
+
+INSERT INTO role_permissions (role, permission_code)
+VALUES ('MANAGER', 'skills:read'),
+       ('MANAGER', 'skills:write'),
+       ('ZOOKEEPER', 'skills:read');
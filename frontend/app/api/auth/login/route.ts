@@ -3,11 +3,21 @@ import { NextRequest, NextResponse } from 'next/server'
 export async function POST(req: NextRequest) {
   const body = await req.json()
 
+  // The backend throttles failed logins per client IP, so pass on the
+  // caller's address rather than our own
+  const headers: Record<string, string> = { 'Content-Type': 'application/json' }
+  const clientIP =
+    req.headers.get('x-forwarded-for')?.split(',')[0].trim() ||
+    req.headers.get('x-real-ip')
+  if (clientIP) {
+    headers['X-Forwarded-For'] = clientIP
+  }
+
   const backendRes = await fetch(
     `${process.env.BACKEND_URL}/auth/login`,
     {
       method: 'POST',
-      headers: { 'Content-Type': 'application/json' },
+      headers,
       body: JSON.stringify(body),
     }
   )
@@ -18,6 +28,14 @@ export async function POST(req: NextRequest) {
     return NextResponse.json(data, { status: backendRes.status })
   }
 
+  // Second factor required → hand the pending token back to the login page
+  if (data.mfa_required) {
+    return NextResponse.json(
+      { mfa_required: true, mfa_token: data.mfa_token },
+      { status: 200 }
+    )
+  }
+
   const response = NextResponse.json(
     { message: 'Login successful' },
     { status: 200 }
@@ -31,5 +49,12 @@ export async function POST(req: NextRequest) {
     maxAge: data.expires_in,
   })
 
+  response.cookies.set('refresh_token', data.refresh_token, {
+    httpOnly: true,
+    secure: false,
+    sameSite: 'lax',
+    path: '/',
+  })
+
   return response
 }
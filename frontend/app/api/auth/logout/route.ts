@@ -1,6 +1,17 @@
-import { NextResponse } from 'next/server'
+import { NextRequest, NextResponse } from 'next/server'
+
+export async function POST(req: NextRequest) {
+  const refreshToken = req.cookies.get('refresh_token')?.value
+
+  // Revoke the server-side session so the tokens cannot be reused
+  if (refreshToken) {
+    await fetch(`${process.env.BACKEND_URL}/auth/logout`, {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({ refresh_token: refreshToken }),
+    }).catch(() => undefined)
+  }
 
-export async function POST() {
   const response = NextResponse.json({ message: 'Logged out' })
 
   response.cookies.set('access_token', '', {
@@ -9,5 +20,11 @@ export async function POST() {
     path: '/',
   })
 
+  response.cookies.set('refresh_token', '', {
+    httpOnly: true,
+    expires: new Date(0),
+    path: '/',
+  })
+
   return response
 }
@@ -0,0 +1,52 @@
+import { NextRequest, NextResponse } from 'next/server'
+
+export async function POST(req: NextRequest) {
+  const body = await req.json()
+
+  // The backend throttles failed logins per client IP, so pass on the
+  // caller's address rather than our own
+  const headers: Record<string, string> = { 'Content-Type': 'application/json' }
+  const clientIP =
+    req.headers.get('x-forwarded-for')?.split(',')[0].trim() ||
+    req.headers.get('x-real-ip')
+  if (clientIP) {
+    headers['X-Forwarded-For'] = clientIP
+  }
+
+  const backendRes = await fetch(
+    `${process.env.BACKEND_URL}/auth/mfa/verify`,
+    {
+      method: 'POST',
+      headers,
+      body: JSON.stringify(body),
+    }
+  )
+
+  const data = await backendRes.json()
+
+  if (!backendRes.ok) {
+    return NextResponse.json(data, { status: backendRes.status })
+  }
+
+  const response = NextResponse.json(
+    { message: 'Login successful' },
+    { status: 200 }
+  )
+
+  response.cookies.set('access_token', data.access_token, {
+    httpOnly: true,
+    secure: false,
+    sameSite: 'lax',
+    path: '/',
+    maxAge: data.expires_in,
+  })
+
+  response.cookies.set('refresh_token', data.refresh_token, {
+    httpOnly: true,
+    secure: false,
+    sameSite: 'lax',
+    path: '/',
+  })
+
+  return response
+}
@@ -35,28 +35,57 @@ export async function DELETE(
   return handle(req, context)
 }
 
+async function refreshTokens(refreshToken: string) {
+  const res = await fetch(`${process.env.BACKEND_URL}/auth/refresh`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ refresh_token: refreshToken }),
+  })
+
+  if (!res.ok) return null
+
+  return res.json()
+}
+
 async function handle(
   req: NextRequest,
   context: { params: Promise<{ path?: string[] }> }
 ) {
   const { path } = await context.params
 
-  const token = req.cookies.get('access_token')?.value
+  let token = req.cookies.get('access_token')?.value
+  const refreshToken = req.cookies.get('refresh_token')?.value
 
   const joinedPath = path?.join('/') || ''
+  const search = req.nextUrl.search
 
-  const backendURL = `${process.env.BACKEND_URL}/api/${joinedPath}`
+  const backendURL = `${process.env.BACKEND_URL}/api/${joinedPath}${search}`
 
   console.log('Proxy →', backendURL)
 
-  const backendRes = await fetch(backendURL, {
-    method: req.method,
-    headers: {
-      'Content-Type': 'application/json',
-      ...(token && { Authorization: `Bearer ${token}` }),
-    },
-    body: req.method !== 'GET' ? await req.text() : undefined,
-  })
+  const body = req.method !== 'GET' ? await req.text() : undefined
+
+  const send = (accessToken?: string) =>
+    fetch(backendURL, {
+      method: req.method,
+      headers: {
+        'Content-Type': 'application/json',
+        ...(accessToken && { Authorization: `Bearer ${accessToken}` }),
+      },
+      body,
+    })
+
+  let backendRes = await send(token)
+
+  // Access token expired → rotate with the refresh token and retry once
+  let refreshed: { access_token: string; refresh_token: string; expires_in: number } | null = null
+  if (backendRes.status === 401 && refreshToken) {
+    refreshed = await refreshTokens(refreshToken)
+    if (refreshed) {
+      token = refreshed.access_token
+      backendRes = await send(token)
+    }
+  }
 
   if (backendRes.status === 401) {
     const response = NextResponse.json(
@@ -64,29 +93,47 @@ async function handle(
       { status: 401 }
     )
 
-    // Clear cookie
+    // Clear cookies
     response.cookies.set('access_token', '', {
       httpOnly: true,
       expires: new Date(0),
       path: '/',
     })
+    response.cookies.set('refresh_token', '', {
+      httpOnly: true,
+      expires: new Date(0),
+      path: '/',
+    })
 
     return response
   }
 
-  if (backendRes.status === 204) {
-    return new NextResponse(null, {
-      status: 204,
+  const response =
+    backendRes.status === 204
+      ? new NextResponse(null, { status: 204 })
+      : new NextResponse(await backendRes.text(), {
+          status: backendRes.status,
+          headers: {
+            'Content-Type':
+              backendRes.headers.get('content-type') || 'application/json',
+          },
+        })
+
+  if (refreshed) {
+    response.cookies.set('access_token', refreshed.access_token, {
+      httpOnly: true,
+      secure: false,
+      sameSite: 'lax',
+      path: '/',
+      maxAge: refreshed.expires_in,
+    })
+    response.cookies.set('refresh_token', refreshed.refresh_token, {
+      httpOnly: true,
+      secure: false,
+      sameSite: 'lax',
+      path: '/',
     })
   }
 
-  const body = await backendRes.text()
-
-  return new NextResponse(body, {
-    status: backendRes.status,
-    headers: {
-      'Content-Type':
-        backendRes.headers.get('content-type') || 'application/json',
-    },
-  })
+  return response
 }
@@ -7,9 +7,16 @@ import {useToast} from '@/components/ui/ToastContext'
 type Animal = {
   public_id: string
   name: string
+  species_public_id: string
   species: string
   cage_public_id: string
   date_of_birth: string | null
+  status: string
+}
+
+type Species = {
+  public_id: string
+  common_name: string
 }
 
 type Cage = {
@@ -24,6 +31,7 @@ export default function AnimalsPage() {
   const [animals, setAnimals] = useState<Animal[]>([])
   const [filtered, setFiltered] = useState<Animal[]>([])
   const [cages, setCages] = useState<Cage[]>([])
+  const [species, setSpecies] = useState<Species[]>([])
 
   const [loading, setLoading] = useState(true)
   const [search, setSearch] = useState('')
@@ -39,7 +47,7 @@ export default function AnimalsPage() {
 
   const [form, setForm] = useState({
     name: '',
-    species: '',
+    species_public_id: '',
     cage_public_id: '',
     date_of_birth: '',
   })
@@ -49,7 +57,7 @@ export default function AnimalsPage() {
   async function fetchAnimals() {
     setLoading(true)
     try {
-      const res = await fetch('/api/proxy/animals')
+      const res = await fetch('/api/proxy/animals?sort=name&limit=200')
       const data = await res.json()
 
       if (!res.ok) {
@@ -57,8 +65,8 @@ export default function AnimalsPage() {
         return
       }
 
-      setAnimals(data)
-      setFiltered(data)
+      setAnimals(data.items)
+      setFiltered(data.items)
     } catch {
       showToast('Network error', 'error')
     } finally {
@@ -68,9 +76,18 @@ export default function AnimalsPage() {
 
   async function fetchCages() {
     try {
-      const res = await fetch('/api/proxy/cages')
+      const res = await fetch('/api/proxy/cages?sort=code&limit=200')
+      const data = await res.json()
+      if (res.ok) setCages(data.items)
+    } catch {
+    }
+  }
+
+  async function fetchSpecies() {
+    try {
+      const res = await fetch('/api/proxy/species?sort=common_name&limit=200')
       const data = await res.json()
-      if (res.ok) setCages(data)
+      if (res.ok) setSpecies(data.items)
     } catch {
     }
   }
@@ -78,6 +95,7 @@ export default function AnimalsPage() {
   useEffect(() => {
     fetchAnimals()
     fetchCages()
+    fetchSpecies()
   }, [])
 
   useEffect(() => {
@@ -114,7 +132,7 @@ export default function AnimalsPage() {
 
       showToast('Animal created successfully', 'success')
       setOpenCreate(false)
-      setForm({name: '', species: '', cage_public_id: '', date_of_birth: ''})
+      setForm({name: '', species_public_id: '', cage_public_id: '', date_of_birth: ''})
       fetchAnimals()
     } catch {
       showToast('Unexpected error occurred', 'error')
@@ -139,7 +157,7 @@ export default function AnimalsPage() {
           headers: {'Content-Type': 'application/json'},
           body: JSON.stringify({
             name: editData.name,
-            species: editData.species,
+            species_public_id: editData.species_public_id,
             cage_public_id: editData.cage_public_id,
             date_of_birth: editData.date_of_birth || null,
           }),
@@ -244,6 +262,7 @@ export default function AnimalsPage() {
               <th className="px-6 py-3 text-left">Species</th>
               <th className="px-6 py-3 text-left">Cage</th>
               <th className="px-6 py-3 text-left">Birth Date</th>
+              <th className="px-6 py-3 text-left">Status</th>
               <th className="px-6 py-3 text-right">Action</th>
             </tr>
             </thead>
@@ -258,6 +277,7 @@ export default function AnimalsPage() {
                     </span>
                 </td>
                 <td className="px-6 py-4">{formatDate(a.date_of_birth)}</td>
+                <td className="px-6 py-4">{a.status}</td>
                 <td className="px-6 py-4 text-right space-x-3">
                   <button
                     onClick={() => {
@@ -302,15 +322,21 @@ export default function AnimalsPage() {
               className="w-full border rounded-md px-3 py-2"
             />
 
-            <input
-              placeholder="Species"
+            <select
               required
-              value={form.species}
+              value={form.species_public_id}
               onChange={(e) =>
-                setForm({...form, species: e.target.value})
+                setForm({...form, species_public_id: e.target.value})
               }
               className="w-full border rounded-md px-3 py-2"
-            />
+            >
+              <option value="">Select Species</option>
+              {species.map((s) => (
+                <option key={s.public_id} value={s.public_id}>
+                  {s.common_name}
+                </option>
+              ))}
+            </select>
 
             <select
               required
@@ -364,13 +390,19 @@ export default function AnimalsPage() {
               className="w-full border rounded-md px-3 py-2"
             />
 
-            <input
-              value={editData.species}
+            <select
+              value={editData.species_public_id}
               onChange={(e) =>
-                setEditData({...editData, species: e.target.value})
+                setEditData({...editData, species_public_id: e.target.value})
               }
               className="w-full border rounded-md px-3 py-2"
-            />
+            >
+              {species.map((s) => (
+                <option key={s.public_id} value={s.public_id}>
+                  {s.common_name}
+                </option>
+              ))}
+            </select>
 
             <div>
               <label className="block text-sm font-medium text-gray-700 mb-1">
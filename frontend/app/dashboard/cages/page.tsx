@@ -40,7 +40,7 @@ export default function CagesPage() {
   async function fetchCages() {
     setLoading(true)
     try {
-      const res = await fetch('/api/proxy/cages')
+      const res = await fetch('/api/proxy/cages?sort=code&limit=200')
       const data = await res.json()
 
       if (!res.ok) {
@@ -48,8 +48,8 @@ export default function CagesPage() {
         return
       }
 
-      setCages(data)
-      setFiltered(data)
+      setCages(data.items)
+      setFiltered(data.items)
     } catch {
       showToast('Network error', 'error')
     } finally {
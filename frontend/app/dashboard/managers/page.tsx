@@ -41,7 +41,7 @@ export default function ManagersPage() {
   async function fetchManagers() {
     setLoading(true)
     try {
-      const res = await fetch('/api/proxy/managers')
+      const res = await fetch('/api/proxy/managers?sort=name&limit=200')
       const data = await res.json()
 
       if (!res.ok) {
@@ -49,8 +49,8 @@ export default function ManagersPage() {
         return
       }
 
-      setManagers(data)
-      setFiltered(data)
+      setManagers(data.items)
+      setFiltered(data.items)
     } catch {
       showToast('Network error', 'error')
     } finally {
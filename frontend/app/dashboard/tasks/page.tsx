@@ -43,7 +43,7 @@ export default function TasksPage() {
 
   async function fetchTasks() {
     setLoading(true)
-    const res = await fetch('/api/proxy/tasks')
+    const res = await fetch('/api/proxy/tasks?sort=due_date&limit=200')
     const data = await res.json()
 
     if (!res.ok) {
@@ -51,14 +51,14 @@ export default function TasksPage() {
       return
     }
 
-    setTasks(data)
+    setTasks(data.items)
     setLoading(false)
   }
 
   async function fetchZookeepers() {
-    const res = await fetch('/api/proxy/zookeepers')
+    const res = await fetch('/api/proxy/zookeepers?sort=name&limit=200')
     const data = await res.json()
-    if (res.ok) setZookeepers(data)
+    if (res.ok) setZookeepers(data.items)
   }
 
   useEffect(() => {
@@ -43,7 +43,7 @@ export default function ZookeepersPage() {
   async function fetchZookeepers() {
     setLoading(true)
     try {
-      const res = await fetch('/api/proxy/zookeepers')
+      const res = await fetch('/api/proxy/zookeepers?sort=name&limit=200')
       const data = await res.json()
 
       if (!res.ok) {
@@ -51,8 +51,8 @@ export default function ZookeepersPage() {
         return
       }
 
-      setZookeepers(data)
-      setFiltered(data)
+      setZookeepers(data.items)
+      setFiltered(data.items)
     } catch {
       showToast('Network error', 'error')
     } finally {
@@ -8,6 +8,8 @@ export default function LoginPage() {
 
   const [username, setUsername] = useState('')
   const [password, setPassword] = useState('')
+  const [mfaToken, setMfaToken] = useState('')
+  const [code, setCode] = useState('')
   const [loading, setLoading] = useState(false)
   const [error, setError] = useState('')
 
@@ -17,11 +19,17 @@ export default function LoginPage() {
     setError('')
 
     try {
-      const res = await fetch('/api/auth/login', {
-        method: 'POST',
-        headers: { 'Content-Type': 'application/json' },
-        body: JSON.stringify({ username, password }),
-      })
+      const res = mfaToken
+        ? await fetch('/api/auth/mfa', {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({ mfa_token: mfaToken, code }),
+          })
+        : await fetch('/api/auth/login', {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({ username, password }),
+          })
 
       const data = await res.json()
 
@@ -30,6 +38,11 @@ export default function LoginPage() {
         return
       }
 
+      if (data.mfa_required) {
+        setMfaToken(data.mfa_token)
+        return
+      }
+
       router.push('/dashboard')
     } catch (err: unknown) {
       setError('Something went wrong')
@@ -81,6 +94,24 @@ export default function LoginPage() {
             </div>
           </div>
 
+          {mfaToken && (
+            <div>
+              <label className="block text-sm font-medium text-gray-900">
+                Authentication code
+              </label>
+              <div className="mt-2">
+                <input
+                  type="text"
+                  required
+                  autoComplete="one-time-code"
+                  value={code}
+                  onChange={(e) => setCode(e.target.value)}
+                  className="block w-full rounded-md border px-3 py-2 text-gray-900 focus:outline-none focus:ring-2 focus:ring-indigo-600"
+                />
+              </div>
+            </div>
+          )}
+
           {error && (
             <div className="text-sm text-red-600 bg-red-50 p-2 rounded">
               {error}
@@ -93,7 +124,7 @@ export default function LoginPage() {
               disabled={loading}
               className="flex w-full justify-center rounded-md bg-indigo-600 px-3 py-2 text-sm font-semibold text-white hover:bg-indigo-500 disabled:opacity-50"
             >
-              {loading ? 'Signing in...' : 'Sign in'}
+              {loading ? 'Signing in...' : mfaToken ? 'Verify' : 'Sign in'}
             </button>
           </div>
         </form>
@@ -13,13 +13,14 @@ function parseJwt(token: string) {
 
 export function middleware(request: NextRequest) {
   const token = request.cookies.get('access_token')?.value
+  const hasRefreshToken = !!request.cookies.get('refresh_token')?.value
   const { pathname } = request.nextUrl
 
   const isLoginPage = pathname.startsWith('/login')
   const isApiAuth = pathname.startsWith('/api/auth')
 
   // 🚫 No token
-  if (!token && !isLoginPage && !isApiAuth) {
+  if (!token && !hasRefreshToken && !isLoginPage && !isApiAuth) {
     return NextResponse.redirect(new URL('/login', request.url))
   }
 
@@ -36,7 +37,7 @@ export function middleware(request: NextRequest) {
 
     // ⏳ Expired token
     const now = Date.now() / 1000
-    if (payload.exp && payload.exp < now) {
+    if (payload.exp && payload.exp < now && !hasRefreshToken) {
       const response = NextResponse.redirect(new URL('/login', request.url))
       response.cookies.delete('access_token')
       return response